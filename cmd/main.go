@@ -1,41 +1,207 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/handlers"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/ratelimit"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/memory"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/mongodb"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/postgres"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/email"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/federation"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/keys"
 )
 
 func main() {
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("failed to load config: $v", err)
+		log.Fatalf("failed to load config: %v", err)
 	}
 
 	// 라우터 설정
 	r := mux.NewRouter()
 
+	// 저장소 초기화
+	stores, keyStore, err := openStores(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize stores: %v", err)
+	}
+
+	// Email Service 초기화
+	sender, err := email.NewSender(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize email sender: %v", err)
+	}
+	emailService := email.NewEmailService(sender, cfg.SMTPFrom)
+
+	// WebAuthn 초기화
+	webAuthn, err := services.NewWebAuthn(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize webauthn: %v", err)
+	}
+
+	// JWT 서명 키 로드
+	tokenKeys, err := services.NewTokenKeys(context.Background(), cfg, keyStore)
+	if err != nil {
+		log.Fatalf("failed to load signing keys: %v", err)
+	}
+	if tokenKeys.SigningKey().IsSymmetric() {
+		log.Printf("JWT_ALGORITHM is %s, openid scope and ID tokens are disabled", cfg.JWTAlgorithm)
+	}
+
+	// 외부 로그인 제공자
+	federatedProviders, err := federation.NewProviders(cfg.FederatedProviders)
+	if err != nil {
+		log.Fatalf("failed to initialize federated providers: %v", err)
+	}
+
 	// 핸들러 설정
-	authHandler := handlers.NewAuthHandler(cfg)
+	authService := services.NewAuthService(stores, emailService, webAuthn, tokenKeys, federatedProviders, cfg)
+	if err := authService.BootstrapAdmins(context.Background()); err != nil {
+		log.Fatalf("failed to bootstrap admins: %v", err)
+	}
+	authHandler := handlers.NewAuthHandler(authService)
+
+	// 요청 횟수 제한
+	rateLimitStore, err := ratelimit.NewStore(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize rate limit store: %v", err)
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore, cfg.RateLimitTrustProxy)
+
+	// 세션 목록에 표시할 기기 정보
+	r.Use(handlers.ClientInfoMiddleware(limiter.ClientIP))
 
 	// 라우트 등록
 	// 인증 관련
 	r.HandleFunc("/auth/register", authHandler.Register).Methods("POST")
-	r.HandleFunc("/auth/login", authHandler.Login).Methods("POST")
+	r.Handle("/auth/login", limiter.Wrap(authHandler.Login,
+		limiter.PerIP("login-ip", ratelimit.PerMinute(20)),
+		limiter.PerEmail("login-email", ratelimit.PerMinute(10)),
+	)).Methods("POST")
+	r.HandleFunc("/auth/refresh", authHandler.Refresh).Methods("POST")
+	r.HandleFunc("/auth/logout", authHandler.Logout).Methods("POST")
+	r.HandleFunc("/auth/logout-all", authHandler.LogoutAll).Methods("POST")
+
+	// 세션(로그인한 기기) 관리
+	r.HandleFunc("/auth/sessions", authHandler.ListSessions).Methods("GET")
+	r.HandleFunc("/auth/sessions/revoke-others", authHandler.RevokeOtherSessions).Methods("POST")
+	r.HandleFunc("/auth/sessions/{id}", authHandler.RevokeSession).Methods("DELETE")
+
+	// 2단계 인증 라우트
+	r.HandleFunc("/auth/mfa/totp/enroll", authHandler.EnrollTOTP).Methods("POST")
+	r.HandleFunc("/auth/mfa/totp/verify", authHandler.VerifyTOTP).Methods("POST")
+	r.HandleFunc("/auth/mfa/totp/disable", authHandler.DisableTOTP).Methods("POST")
+	r.Handle("/auth/mfa/challenge", limiter.Wrap(authHandler.MFAChallenge,
+		limiter.PerIP("mfa-ip", ratelimit.PerMinute(20)),
+	)).Methods("POST")
+	r.HandleFunc("/auth/mfa/webauthn/begin", authHandler.BeginWebAuthnMFA).Methods("POST")
+	r.HandleFunc("/auth/mfa/webauthn/finish", authHandler.FinishWebAuthnMFA).Methods("POST")
+
+	// 패스키 라우트
+	r.HandleFunc("/auth/webauthn/register/begin", authHandler.BeginWebAuthnRegistration).Methods("POST")
+	r.HandleFunc("/auth/webauthn/register/finish", authHandler.FinishWebAuthnRegistration).Methods("POST")
+	r.HandleFunc("/auth/webauthn/credentials", authHandler.ListWebAuthnCredentials).Methods("GET")
+	r.HandleFunc("/auth/webauthn/credentials/{id}", authHandler.DeleteWebAuthnCredential).Methods("DELETE")
+	r.HandleFunc("/auth/webauthn/login/begin", authHandler.BeginWebAuthnLogin).Methods("POST")
+	r.HandleFunc("/auth/webauthn/login/finish", authHandler.FinishWebAuthnLogin).Methods("POST")
+
+	// 매직 링크 로그인 라우트
+	r.Handle("/auth/magic-link", limiter.Wrap(authHandler.RequestMagicLink,
+		limiter.PerIP("magic-link-ip", ratelimit.PerHour(20)),
+		limiter.PerEmail("magic-link-email", ratelimit.PerHour(5)),
+	)).Methods("POST")
+	r.Handle("/auth/magic-link/consume", limiter.Wrap(authHandler.ConsumeMagicLink,
+		limiter.PerIP("magic-link-consume-ip", ratelimit.PerMinute(20)),
+	)).Methods("POST")
+
+	// 이메일 OTP 로그인 라우트
+	r.Handle("/auth/otp", limiter.Wrap(authHandler.SendLoginCode,
+		limiter.PerIP("otp-ip", ratelimit.PerHour(20)),
+		limiter.PerEmail("otp-email", ratelimit.PerHour(5)),
+	)).Methods("POST")
+	r.Handle("/auth/otp/verify", limiter.Wrap(authHandler.LoginWithCode,
+		limiter.PerIP("otp-verify-ip", ratelimit.PerMinute(20)),
+		limiter.PerEmail("otp-verify-email", ratelimit.PerMinute(10)),
+	)).Methods("POST")
 
 	// 비밀번호 재설정 라우트
-	r.HandleFunc("/auth/forgot-password", authHandler.ForgotPassword).Methods("POST")
-	r.HandleFunc("/auth/reset-password", authHandler.ResetPassword).Methods("POST")
+	r.Handle("/auth/forgot-password", limiter.Wrap(authHandler.ForgotPassword,
+		limiter.PerIP("forgot-password-ip", ratelimit.PerHour(20)),
+		limiter.PerEmail("forgot-password-email", ratelimit.PerHour(5)),
+	)).Methods("POST")
+	r.Handle("/auth/reset-password", limiter.Wrap(authHandler.ResetPassword,
+		limiter.PerIP("reset-password-ip", ratelimit.PerMinute(20)),
+		limiter.PerEmail("reset-password-email", ratelimit.PerMinute(10)),
+	)).Methods("POST")
+	r.HandleFunc("/auth/change-password", authHandler.ChangePassword).Methods("POST")
 
 	// 이메일 인증 라우트
-	r.HandleFunc("/auth/verify-email", authHandler.VerifyEmail).Methods("POST")
-	r.HandleFunc("/auth/send-verification", authHandler.SendVerificationEmail).Methods("POST")
+	r.Handle("/auth/verify-email", limiter.Wrap(authHandler.VerifyEmail,
+		limiter.PerIP("verify-email-ip", ratelimit.PerMinute(20)),
+		limiter.PerEmail("verify-email-email", ratelimit.PerMinute(10)),
+	)).Methods("POST")
+	r.Handle("/auth/send-verification", limiter.Wrap(authHandler.SendVerificationEmail,
+		limiter.PerIP("send-verification-ip", ratelimit.PerHour(20)),
+		limiter.PerEmail("send-verification-email", ratelimit.PerHour(5)),
+	)).Methods("POST")
+
+	// 외부 로그인 라우트
+	r.HandleFunc("/auth/federated", authHandler.ListFederatedProviders).Methods("GET")
+	r.HandleFunc("/auth/federated/{provider}", authHandler.BeginFederatedLogin).Methods("GET")
+	r.HandleFunc("/auth/federated/{provider}/callback", authHandler.FederatedCallback).Methods("GET")
+	r.Handle("/auth/federated/exchange", limiter.Wrap(authHandler.ExchangeFederatedLogin,
+		limiter.PerIP("federated-exchange-ip", ratelimit.PerMinute(20)),
+	)).Methods("POST")
 
 	// jwt
 	r.HandleFunc("/auth/verify", authHandler.VerifyToken).Methods("GET")
+	r.HandleFunc("/.well-known/jwks.json", authHandler.JWKS).Methods("GET")
+
+	// OpenID Connect 라우트
+	r.HandleFunc("/.well-known/openid-configuration", authHandler.OpenIDConfiguration).Methods("GET")
+	r.HandleFunc("/.well-known/oauth-authorization-server", authHandler.OpenIDConfiguration).Methods("GET")
+	r.HandleFunc("/userinfo", authHandler.UserInfo).Methods("GET", "POST")
+
+	// OAuth 2.0 인가 서버 라우트
+	r.HandleFunc("/oauth/clients", authHandler.CreateOAuthClient).Methods("POST")
+	r.HandleFunc("/oauth/clients", authHandler.ListOAuthClients).Methods("GET")
+	r.HandleFunc("/oauth/clients/{id}", authHandler.DeleteOAuthClient).Methods("DELETE")
+	r.HandleFunc("/oauth/authorize", authHandler.BeginAuthorization).Methods("GET")
+	r.HandleFunc("/oauth/authorize", authHandler.Authorize).Methods("POST")
+	r.Handle("/oauth/token", limiter.Wrap(authHandler.OAuthToken,
+		limiter.PerIP("oauth-token-ip", ratelimit.PerMinute(60)),
+	)).Methods("POST")
+	r.Handle("/oauth/revoke", limiter.Wrap(authHandler.RevokeOAuthToken,
+		limiter.PerIP("oauth-revoke-ip", ratelimit.PerMinute(60)),
+	)).Methods("POST")
+
+	// 관리자 라우트 (토큰의 권한 클레임으로 먼저 거르고, 서비스에서 현재 권한을 다시 확인)
+	r.Handle("/admin/users", authHandler.RequirePermission(authHandler.ListUsers, models.PermissionUsersRead)).Methods("GET")
+	r.Handle("/admin/users/{id}", authHandler.RequirePermission(authHandler.GetUser, models.PermissionUsersRead)).Methods("GET")
+	r.Handle("/admin/users/{id}", authHandler.RequirePermission(authHandler.DeleteUser, models.PermissionUsersWrite)).Methods("DELETE")
+	r.Handle("/admin/users/{id}/suspend", authHandler.RequirePermission(authHandler.SuspendUser, models.PermissionUsersWrite)).Methods("POST")
+	r.Handle("/admin/users/{id}/unsuspend", authHandler.RequirePermission(authHandler.UnsuspendUser, models.PermissionUsersWrite)).Methods("POST")
+	r.Handle("/admin/users/{id}/force-password-reset", authHandler.RequirePermission(authHandler.ForcePasswordReset, models.PermissionUsersWrite)).Methods("POST")
+	r.Handle("/admin/users/{id}/verify-email", authHandler.RequirePermission(authHandler.VerifyUserEmail, models.PermissionUsersWrite)).Methods("POST")
+	r.Handle("/admin/users/{id}/unlock", authHandler.RequirePermission(authHandler.UnlockUser, models.PermissionUsersWrite)).Methods("POST")
+	r.Handle("/admin/users/{id}/status", authHandler.RequirePermission(authHandler.UpdateUserStatus, models.PermissionUsersWrite)).Methods("POST")
+	r.Handle("/admin/users/{id}/roles", authHandler.RequirePermission(authHandler.UpdateUserRoles, models.PermissionRolesAssign)).Methods("PUT")
+	r.Handle("/admin/users/{id}/permissions", authHandler.RequirePermission(authHandler.UpdateUserPermissions, models.PermissionRolesAssign)).Methods("PUT")
+	r.Handle("/admin/users/{id}/audit-log", authHandler.RequirePermission(authHandler.ListUserAuditLogs, models.PermissionAuditLogsRead)).Methods("GET")
+	r.Handle("/admin/roles", authHandler.RequirePermission(authHandler.ListRoles, models.PermissionRolesAssign)).Methods("GET")
+	r.Handle("/admin/service-clients", authHandler.RequirePermission(authHandler.CreateServiceClient, models.PermissionServiceClientsManage)).Methods("POST")
+	r.Handle("/admin/service-clients", authHandler.RequirePermission(authHandler.ListServiceClients, models.PermissionServiceClientsManage)).Methods("GET")
+	r.Handle("/admin/service-clients/{id}", authHandler.RequirePermission(authHandler.DeleteServiceClient, models.PermissionServiceClientsManage)).Methods("DELETE")
 
 	// 서버 시작
 	log.Printf("Server starting on port %s", cfg.ServerPort)
@@ -43,3 +209,102 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// openStores 설정된 백엔드(DB_BACKEND)로 저장소와 서명 키 저장소 생성
+func openStores(cfg *config.Config) (repository.Stores, keys.Store, error) {
+	var stores repository.Stores
+	var dbKeyStore keys.Store // DB 백엔드에 서명 키를 저장할 때 사용
+
+	backend := cfg.DBBackend
+	switch backend {
+	case "", "mongo":
+		backend = "mongo"
+		repo, err := mongodb.NewAuthRepository(cfg.MongoURI, cfg.MongoDatabase)
+		if err != nil {
+			return stores, nil, err
+		}
+		db := repo.Database()
+
+		refreshRepo, err := mongodb.NewRefreshTokenRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		revokedRepo, err := mongodb.NewRevokedTokenRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		credentialRepo, err := mongodb.NewWebAuthnCredentialRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		flowRepo, err := mongodb.NewAuthFlowRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		oauthClientRepo, err := mongodb.NewOAuthClientRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		serviceClientRepo, err := mongodb.NewServiceClientRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		identityRepo, err := mongodb.NewFederatedIdentityRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		sessionRepo, err := mongodb.NewSessionRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+		auditLogRepo, err := mongodb.NewAuditLogRepository(db)
+		if err != nil {
+			return stores, nil, err
+		}
+
+		stores = repository.Stores{
+			Users:               repo,
+			RefreshTokens:       refreshRepo,
+			RevokedTokens:       revokedRepo,
+			WebAuthnCredentials: credentialRepo,
+			AuthFlows:           flowRepo,
+			OAuthClients:        oauthClientRepo,
+			ServiceClients:      serviceClientRepo,
+			FederatedIdentities: identityRepo,
+			Sessions:            sessionRepo,
+			AuditLogs:           auditLogRepo,
+		}
+		dbKeyStore = mongodb.NewSigningKeyRepository(db)
+	case "postgres":
+		pool, err := postgres.Open(context.Background(), cfg.PostgresURL)
+		if err != nil {
+			return stores, nil, err
+		}
+		stores = postgres.NewStores(pool)
+		dbKeyStore = postgres.NewSigningKeyRepository(pool)
+	case "memory":
+		log.Printf("DB_BACKEND is memory, data will be lost on restart")
+		stores = memory.NewStores()
+	default:
+		return stores, nil, fmt.Errorf("unknown db backend: %s", cfg.DBBackend)
+	}
+
+	// JWT 서명 키 저장소 (비어 있으면 고정 키 사용)
+	switch cfg.JWTKeyStore {
+	case "file":
+		fileStore, err := keys.NewFileStore(cfg.JWTKeyDir)
+		if err != nil {
+			return stores, nil, err
+		}
+		return stores, fileStore, nil
+	case "mongo", "postgres":
+		if cfg.JWTKeyStore != backend {
+			return stores, nil, fmt.Errorf("key store %s requires DB_BACKEND=%s", cfg.JWTKeyStore, cfg.JWTKeyStore)
+		}
+		return stores, dbKeyStore, nil
+	case "":
+		return stores, nil, nil
+	default:
+		return stores, nil, fmt.Errorf("unknown key store: %s", cfg.JWTKeyStore)
+	}
+}
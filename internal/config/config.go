@@ -1,17 +1,109 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	ServerPort string `mapstructure:"SERVER_PORT"`
-	MongoURI   string `mapstructure:"MONGO_URI"`
-	JWTSecret  string `mapstructure:"JWT_SECRET"`
-	JWTExpires int    `mapstructure:"JWT_EXPIRES"` // 시간 단위: 시간
+	ServerPort    string `mapstructure:"SERVER_PORT"`
+	MongoURI      string `mapstructure:"MONGO_URI"`
+	MongoDatabase string `mapstructure:"MONGO_DATABASE"`
+	PostgresURL   string `mapstructure:"POSTGRES_URL"`
+	DBBackend     string `mapstructure:"DB_BACKEND"` // mongo | postgres | memory
+	JWTSecret     string `mapstructure:"JWT_SECRET"`
+	JWTExpires    int    `mapstructure:"JWT_EXPIRES"` // 시간 단위: 시간
+
+	// JWT 서명 알고리즘 (HS256 | RS256 | ES256 | EdDSA)
+	JWTAlgorithm      string `mapstructure:"JWT_ALGORITHM"`
+	JWTPrivateKeyFile string `mapstructure:"JWT_PRIVATE_KEY_FILE"` // 비대칭 알고리즘용 PEM 개인키
+	JWTKeyID          string `mapstructure:"JWT_KEY_ID"`           // 비우면 JWK thumbprint 사용
+
+	// 서명 키 교체 (JWT_KEY_STORE가 비어 있으면 위의 고정 키 사용)
+	JWTKeyStore    string `mapstructure:"JWT_KEY_STORE"`    // file | mongo | postgres
+	JWTKeyDir      string `mapstructure:"JWT_KEY_DIR"`      // file 저장소 경로
+	JWTKeyRotation int    `mapstructure:"JWT_KEY_ROTATION"` // 시간 단위
+
+	// 액세스/리프레시 토큰 수명
+	AccessTokenTTL  int `mapstructure:"ACCESS_TOKEN_TTL"`  // 분 단위, 0이면 JWT_EXPIRES 사용
+	RefreshTokenTTL int `mapstructure:"REFRESH_TOKEN_TTL"` // 시간 단위
+
+	// 로그인 실패 잠금 (LOGIN_MAX_ATTEMPTS가 0이면 잠그지 않음)
+	LoginMaxAttempts   int `mapstructure:"LOGIN_MAX_ATTEMPTS"`    // 잠금까지 허용하는 연속 실패 횟수
+	LoginLockoutBase   int `mapstructure:"LOGIN_LOCKOUT_BASE"`    // 첫 잠금 시간, 분 단위 (이후 실패마다 두 배)
+	LoginLockoutMaxTTL int `mapstructure:"LOGIN_LOCKOUT_MAX_TTL"` // 최대 잠금 시간, 분 단위
+
+	// 요청 횟수 제한
+	RateLimitStore      string `mapstructure:"RATE_LIMIT_STORE"`       // memory | redis | none
+	RateLimitTrustProxy bool   `mapstructure:"RATE_LIMIT_TRUST_PROXY"` // 프록시 뒤에서 X-Forwarded-For 사용
+	RedisAddr           string `mapstructure:"REDIS_ADDR"`
+	RedisPassword       string `mapstructure:"REDIS_PASSWORD"`
+	RedisDB             int    `mapstructure:"REDIS_DB"`
+
+	// 매직 링크 로그인 링크 수명 (분 단위)
+	MagicLinkTTL int `mapstructure:"MAGIC_LINK_TTL"`
+
+	// 이메일 OTP (로그인, 이메일 인증, 비밀번호 재설정용 숫자 코드)
+	EmailOTPTTL         int `mapstructure:"EMAIL_OTP_TTL"`          // 분 단위
+	EmailOTPMaxAttempts int `mapstructure:"EMAIL_OTP_MAX_ATTEMPTS"` // 코드 하나당 허용하는 검증 시도 횟수
+
+	// 이메일 미인증 계정의 로그인 정책 (allow | restrict | deny)
+	EmailVerificationPolicy   string `mapstructure:"EMAIL_VERIFICATION_POLICY"`
+	VerificationEmailCooldown int    `mapstructure:"VERIFICATION_EMAIL_COOLDOWN"` // 분 단위, 로그인할 때 인증 메일을 다시 보내는 최소 간격
+
+	// 시작할 때 admin 역할을 부여할 계정 (쉼표로 구분, 이메일 인증을 마친 기존 계정만)
+	AdminEmails []string `mapstructure:"ADMIN_EMAILS"`
+
+	// OAuth 2.0 인가 서버가 지원하는 scope (쉼표로 구분)
+	OAuthScopes []string `mapstructure:"OAUTH_SCOPES"`
+
+	// 외부 로그인 제공자 이름 (쉼표로 구분, 각 설정은 FEDERATED_<NAME>_*)
+	FederatedProviderNames []string            `mapstructure:"FEDERATED_PROVIDERS"`
+	FederatedProviders     []FederatedProvider `mapstructure:"-"`
+
+	// 이 서비스의 외부 URL (OpenID Connect issuer, 비우면 http://localhost:SERVER_PORT)
+	IssuerURL string `mapstructure:"ISSUER_URL"`
+
+	// 2단계 인증 앱에 표시될 발급자 이름
+	MFAIssuer string `mapstructure:"MFA_ISSUER"`
+
+	// WebAuthn(패스키) Relying Party 설정
+	WebAuthnRPID      string   `mapstructure:"WEBAUTHN_RP_ID"`      // 웹 앱 도메인 (예: example.com)
+	WebAuthnRPName    string   `mapstructure:"WEBAUTHN_RP_NAME"`    // 인증기에 표시될 이름
+	WebAuthnRPOrigins []string `mapstructure:"WEBAUTHN_RP_ORIGINS"` // 허용 origin, 쉼표로 구분
 
 	// 웹 앱 URL (이메일 링크용)
 	WebAppURL string `mapstructure:"WEB_APP_URL"`
+
+	// 이메일 발송 설정
+	EmailSender  string `mapstructure:"EMAIL_SENDER"` // smtp | mailbox
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	SMTPSecurity string `mapstructure:"SMTP_SECURITY"` // starttls | tls | none
+	MailboxDir   string `mapstructure:"MAILBOX_DIR"`   // mailbox 발송 시 .eml 저장 경로
+}
+
+// 이메일 미인증 계정의 로그인 정책
+const (
+	EmailVerificationAllow    = "allow"    // 제한 없이 로그인
+	EmailVerificationRestrict = "restrict" // email_verified=false와 제한된 scope의 토큰 발급
+	EmailVerificationDeny     = "deny"     // 인증할 때까지 로그인 거부
+)
+
+// FederatedProvider 외부 로그인 제공자 설정
+// google, kakao, naver는 엔드포인트가 미리 정해져 있고, 그 밖의 이름은 ISSUER의 OIDC discovery를 사용한다.
+type FederatedProvider struct {
+	Name         string
+	Issuer       string   // FEDERATED_<NAME>_ISSUER
+	ClientID     string   // FEDERATED_<NAME>_CLIENT_ID
+	ClientSecret string   // FEDERATED_<NAME>_CLIENT_SECRET
+	Scopes       []string // FEDERATED_<NAME>_SCOPES, 쉼표로 구분 (비우면 제공자 기본값)
 }
 
 func LoadConfig() (*Config, error) {
@@ -20,7 +112,42 @@ func LoadConfig() (*Config, error) {
 
 	// 기본값 설정
 	viper.SetDefault("SERVER_PORT", "8001")
+	viper.SetDefault("DB_BACKEND", "mongo")
+	viper.SetDefault("MONGO_DATABASE", "prisma_market")
+	viper.SetDefault("POSTGRES_URL", "")
 	viper.SetDefault("JWT_EXPIRES", 24) // 24시간
+	viper.SetDefault("JWT_ALGORITHM", "HS256")
+	viper.SetDefault("JWT_PRIVATE_KEY_FILE", "")
+	viper.SetDefault("JWT_KEY_ID", "")
+	viper.SetDefault("JWT_KEY_STORE", "")
+	viper.SetDefault("JWT_KEY_DIR", "./keys")
+	viper.SetDefault("JWT_KEY_ROTATION", 720)  // 30일
+	viper.SetDefault("ACCESS_TOKEN_TTL", 15)   // 15분
+	viper.SetDefault("REFRESH_TOKEN_TTL", 720) // 30일
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_LOCKOUT_BASE", 5)       // 5분
+	viper.SetDefault("LOGIN_LOCKOUT_MAX_TTL", 1440) // 24시간
+	viper.SetDefault("RATE_LIMIT_STORE", "memory")
+	viper.SetDefault("RATE_LIMIT_TRUST_PROXY", false)
+	viper.SetDefault("REDIS_ADDR", "localhost:6379")
+	viper.SetDefault("REDIS_PASSWORD", "")
+	viper.SetDefault("REDIS_DB", 0)
+	viper.SetDefault("MAGIC_LINK_TTL", 15) // 15분
+	viper.SetDefault("EMAIL_OTP_TTL", 10)  // 10분
+	viper.SetDefault("EMAIL_OTP_MAX_ATTEMPTS", 5)
+	viper.SetDefault("EMAIL_VERIFICATION_POLICY", EmailVerificationAllow)
+	viper.SetDefault("VERIFICATION_EMAIL_COOLDOWN", 10) // 10분
+	viper.SetDefault("OAUTH_SCOPES", "openid,profile,email,offline_access")
+	viper.SetDefault("ISSUER_URL", "")
+	viper.SetDefault("FEDERATED_PROVIDERS", "")
+	viper.SetDefault("MFA_ISSUER", "Prisma Market")
+	viper.SetDefault("WEBAUTHN_RP_ID", "localhost")
+	viper.SetDefault("WEBAUTHN_RP_NAME", "Prisma Market")
+	viper.SetDefault("WEBAUTHN_RP_ORIGINS", "http://localhost:3000")
+	viper.SetDefault("EMAIL_SENDER", "smtp")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMTP_SECURITY", "starttls")
+	viper.SetDefault("MAILBOX_DIR", "./mailbox")
 
 	if err := viper.ReadInConfig(); err != nil {
 		// .env 파일이 없어도 환경변수로 실행 가능하게
@@ -33,6 +160,57 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, err
 	}
+	config.FederatedProviders = loadFederatedProviders(config.FederatedProviderNames)
+
+	switch config.EmailVerificationPolicy {
+	case EmailVerificationAllow, EmailVerificationRestrict, EmailVerificationDeny:
+	default:
+		return nil, fmt.Errorf("invalid EMAIL_VERIFICATION_POLICY: %s", config.EmailVerificationPolicy)
+	}
 
 	return config, nil
 }
+
+// loadFederatedProviders 제공자별 FEDERATED_<NAME>_* 설정 읽기
+func loadFederatedProviders(names []string) []FederatedProvider {
+	providers := make([]FederatedProvider, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "FEDERATED_" + strings.ToUpper(name) + "_"
+
+		var scopes []string
+		for _, scope := range strings.Split(viper.GetString(prefix+"SCOPES"), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		providers = append(providers, FederatedProvider{
+			Name:         name,
+			Issuer:       viper.GetString(prefix + "ISSUER"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			Scopes:       scopes,
+		})
+	}
+	return providers
+}
+
+// Issuer OpenID Connect issuer (끝의 /는 제거)
+func (c *Config) Issuer() string {
+	if c.IssuerURL == "" {
+		return "http://localhost:" + c.ServerPort
+	}
+	return strings.TrimRight(c.IssuerURL, "/")
+}
+
+// AccessTokenLifetime 액세스 토큰 수명 (ACCESS_TOKEN_TTL이 없으면 JWT_EXPIRES 사용)
+func (c *Config) AccessTokenLifetime() time.Duration {
+	if c.AccessTokenTTL > 0 {
+		return time.Duration(c.AccessTokenTTL) * time.Minute
+	}
+	return time.Duration(c.JWTExpires) * time.Hour
+}
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// UnlockUser 관리자: 사용자의 로그인 잠금 해제
+func (h *AuthHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.UnlockUser(r.Context(), claims, mux.Vars(r)["id"]); err != nil {
+		h.sendAdminError(w, err, http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Account has been unlocked",
+	})
+}
+
+// UpdateUserStatus 관리자: 계정 상태 변경 (정지, 차단, 삭제, 다시 활성화)
+func (h *AuthHandler) UpdateUserStatus(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateUserStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.authService.UpdateUserStatus(r.Context(), claims, mux.Vars(r)["id"], &req)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// ListUsers 관리자: 사용자 검색
+// 쿼리: email(부분 일치), status, created_after, created_before(RFC 3339), page, page_size
+func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := listUsersRequest(r.URL.Query())
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.ListUsers(r.Context(), claims, req)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetUser 관리자: 사용자 조회
+func (h *AuthHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.authService.GetUser(r.Context(), claims, mux.Vars(r)["id"])
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// SuspendUser 관리자: 계정 일시 정지
+func (h *AuthHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUserStatus(w, r, h.authService.SuspendUser)
+}
+
+// UnsuspendUser 관리자: 일시 정지 해제
+func (h *AuthHandler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUserStatus(w, r, h.authService.UnsuspendUser)
+}
+
+// DeleteUser 관리자: 계정 삭제
+func (h *AuthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUserStatus(w, r, h.authService.DeleteUser)
+}
+
+// ForcePasswordReset 관리자: 비밀번호 강제 재설정
+func (h *AuthHandler) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AdminUserActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.ForcePasswordReset(r.Context(), claims, mux.Vars(r)["id"], req.Reason); err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Password has been reset and a reset link has been sent",
+	})
+}
+
+// VerifyUserEmail 관리자: 이메일 인증 처리
+func (h *AuthHandler) VerifyUserEmail(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AdminUserActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.VerifyUserEmail(r.Context(), claims, mux.Vars(r)["id"], req.Reason); err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Email has been marked as verified",
+	})
+}
+
+// ListUserAuditLogs 관리자: 사용자 계정에 대한 관리자 작업 기록
+func (h *AuthHandler) ListUserAuditLogs(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.authService.ListUserAuditLogs(r.Context(), claims, mux.Vars(r)["id"])
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// changeUserStatus 사유만 받는 상태 변경 요청 처리
+func (h *AuthHandler) changeUserStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, claims *utils.JWTClaim, userID, reason string) (*models.User, error)) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AdminUserActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := change(r.Context(), claims, mux.Vars(r)["id"], req.Reason)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// CreateServiceClient 관리자: 서비스 클라이언트 등록
+func (h *AuthHandler) CreateServiceClient(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateServiceClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.CreateServiceClient(r.Context(), claims, &req)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListServiceClients 관리자: 서비스 클라이언트 목록
+func (h *AuthHandler) ListServiceClients(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	clients, err := h.authService.ListServiceClients(r.Context(), claims)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clients)
+}
+
+// DeleteServiceClient 관리자: 서비스 클라이언트 삭제
+func (h *AuthHandler) DeleteServiceClient(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DeleteServiceClient(r.Context(), claims, mux.Vars(r)["id"]); err != nil {
+		h.sendAdminError(w, err, http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Service client has been deleted",
+	})
+}
+
+// listUsersRequest 사용자 검색 쿼리 해석
+func listUsersRequest(query url.Values) (*models.ListUsersRequest, error) {
+	req := &models.ListUsersRequest{
+		Email:  query.Get("email"),
+		Status: models.UserStatus(query.Get("status")),
+	}
+
+	var err error
+	if req.CreatedAfter, err = queryTime(query, "created_after"); err != nil {
+		return nil, err
+	}
+	if req.CreatedBefore, err = queryTime(query, "created_before"); err != nil {
+		return nil, err
+	}
+	if req.Page, err = queryInt(query, "page"); err != nil {
+		return nil, err
+	}
+	if req.PageSize, err = queryInt(query, "page_size"); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// queryTime RFC 3339 시각 쿼리 (없으면 nil)
+func queryTime(query url.Values, key string) (*time.Time, error) {
+	value := query.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	return &t, nil
+}
+
+// queryInt 정수 쿼리 (없으면 0)
+func queryInt(query url.Values, key string) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+	return n, nil
+}
+
+// sendAdminError 권한이 없으면 403, 그 밖의 에러는 status로 응답
+func (h *AuthHandler) sendAdminError(w http.ResponseWriter, err error, status int) {
+	if errors.Is(err, services.ErrPermissionDenied) {
+		status = http.StatusForbidden
+	}
+	h.sendError(w, err.Error(), status)
+}
@@ -2,20 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 
-	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
-	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/mongodb"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
-	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/email"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
 )
 
 type AuthHandler struct {
 	authService *services.AuthService
-	jwtSecret   string
 }
 
 type ErrorResponse struct {
@@ -23,29 +21,9 @@ type ErrorResponse struct {
 }
 
 // NewAuthHandler AuthHandler 생성자
-func NewAuthHandler(cfg *config.Config) *AuthHandler {
-	// MongoDB Repository 초기화
-	repo, err := mongodb.NewAuthRepository(cfg.MongoURI)
-	if err != nil {
-		// 에러처리 예약
-		panic(err)
-	}
-
-	// Email Service 초기화
-	emailService := email.NewEmailService(
-		cfg.SMTPHost,
-		cfg.SMTPPort,
-		cfg.SMTPUsername,
-		cfg.SMTPPassword,
-		cfg.SMTPFrom,
-	)
-
-	// Auth Service 초기화
-	authService := services.NewAuthService(repo, emailService, cfg)
-
+func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
-		jwtSecret:   cfg.JWTSecret,
 	}
 }
 
@@ -78,7 +56,25 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.authService.LoginUser(r.Context(), &req)
 	if err != nil {
-		h.sendError(w, err.Error(), http.StatusUnauthorized)
+		h.sendLoginError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Refresh 리프레시 토큰으로 토큰 재발급
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req models.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.RefreshToken(r.Context(), &req)
+	if err != nil {
+		h.sendLoginError(w, err)
 		return
 	}
 
@@ -86,6 +82,99 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// Logout 현재 토큰 로그아웃
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.sessionClaims(w, r)
+	if !ok {
+		return
+	}
+
+	// 리프레시 토큰은 선택 사항이므로 빈 본문 허용
+	var req models.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.Logout(r.Context(), claims, &req); err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Logged out successfully",
+	})
+}
+
+// LogoutAll 모든 기기에서 로그아웃
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.sessionClaims(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.LogoutAll(r.Context(), claims); err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Logged out from all devices",
+	})
+}
+
+// authenticate Authorization 헤더의 Bearer 토큰 검증 (실패 시 401 응답 후 false)
+// OAuth 클라이언트에 위임된 토큰과 이메일 미인증 제한 토큰으로는 계정 관리 API를 호출할 수 없다.
+func (h *AuthHandler) authenticate(w http.ResponseWriter, r *http.Request) (*utils.JWTClaim, bool) {
+	claims, ok := h.sessionClaims(w, r)
+	if !ok {
+		return nil, false
+	}
+	if claims.Scope == models.ScopeUnverified {
+		h.sendError(w, "email verification required", http.StatusForbidden)
+		return nil, false
+	}
+	return claims, true
+}
+
+// sessionClaims 자체 로그인 토큰 검증 (이메일 미인증 제한 토큰 포함, 로그아웃과 세션 관리용)
+func (h *AuthHandler) sessionClaims(w http.ResponseWriter, r *http.Request) (*utils.JWTClaim, bool) {
+	claims, ok := h.bearerClaims(w, r)
+	if !ok {
+		return nil, false
+	}
+	if claims.ClientID != "" {
+		h.sendError(w, "delegated tokens cannot be used for this endpoint", http.StatusForbidden)
+		return nil, false
+	}
+	return claims, true
+}
+
+// bearerClaims Bearer 토큰 검증 (OAuth 클라이언트 토큰 포함, 실패 시 401 응답 후 false)
+// RequirePermission이 이미 검증한 요청이면 그 클레임을 그대로 사용한다.
+func (h *AuthHandler) bearerClaims(w http.ResponseWriter, r *http.Request) (*utils.JWTClaim, bool) {
+	if claims, ok := r.Context().Value(claimsKey{}).(*utils.JWTClaim); ok {
+		return claims, true
+	}
+
+	tokenString := r.Header.Get("Authorization")
+	if tokenString == "" {
+		h.sendError(w, "no token provided", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+	claims, err := h.authService.ValidateToken(r.Context(), tokenString)
+	if err != nil {
+		h.sendError(w, "invalid token", http.StatusUnauthorized)
+		return nil, false
+	}
+	return claims, true
+}
+
 // sendError 에러 응답 전송 헬퍼 함수
 func (h *AuthHandler) sendError(w http.ResponseWriter, message string, status int) {
 	w.Header().Set("Content-Type", "application/json")
@@ -93,6 +182,15 @@ func (h *AuthHandler) sendError(w http.ResponseWriter, message string, status in
 	json.NewEncoder(w).Encode(ErrorResponse{Error: message})
 }
 
+// sendLoginError 로그인, 토큰 재발급 실패 응답 (정지, 차단된 계정과 인증 전 로그인 거부는 403)
+func (h *AuthHandler) sendLoginError(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	if services.IsAccountStatusError(err) || errors.Is(err, services.ErrEmailNotVerified) {
+		status = http.StatusForbidden
+	}
+	h.sendError(w, err.Error(), status)
+}
+
 // ForgotPassword 비밀번호 재설정 요청
 func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	var req models.ForgotPasswordRequest
@@ -106,9 +204,14 @@ func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	message := "If an account exists with that email, a password reset link has been sent"
+	if req.Mode == models.DeliveryModeOTP {
+		message = "If an account exists with that email, a password reset code has been sent"
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
-		"message": "If an account exists with that email, a password reset link has been sent",
+		"message": message,
 	})
 }
 
@@ -121,6 +224,10 @@ func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.authService.ResetPassword(r.Context(), &req); err != nil {
+		if services.IsAccountStatusError(err) {
+			h.sendError(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		h.sendError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -131,6 +238,29 @@ func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ChangePassword 로그인한 사용자의 비밀번호 변경
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.ChangePassword(r.Context(), claims, &req)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // SendVerificationEmail 이메일 인증 메일 발송
 func (h *AuthHandler) SendVerificationEmail(w http.ResponseWriter, r *http.Request) {
 	var req models.ResendVerificationRequest
@@ -139,7 +269,7 @@ func (h *AuthHandler) SendVerificationEmail(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if err := h.authService.SendVerificationEmail(r.Context(), req.Email); err != nil {
+	if err := h.authService.SendVerificationEmail(r.Context(), &req); err != nil {
 		h.sendError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -159,6 +289,10 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.authService.VerifyEmail(r.Context(), &req); err != nil {
+		if services.IsAccountStatusError(err) {
+			h.sendError(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		h.sendError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -170,24 +304,55 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 }
 
 // VerifyToken JWT 토큰 검증 핸들러
+// 자체 로그인 토큰은 roles와 permissions를 함께 반환한다.
+// OAuth 클라이언트 토큰이면 client_id와 scope도 함께 반환하고 (이메일 미인증 제한 토큰은 scope가 unverified),
+// 서비스 토큰은 사용자 정보 없이 token_use가 service로 표시된다.
 func (h *AuthHandler) VerifyToken(w http.ResponseWriter, r *http.Request) {
-	tokenString := r.Header.Get("Authorization")
-	if tokenString == "" {
-		h.sendError(w, "no token provided", http.StatusUnauthorized)
+	claims, ok := h.bearerClaims(w, r)
+	if !ok {
 		return
 	}
 
-	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-
-	claims, err := utils.ValidateJWT(tokenString, h.jwtSecret)
-	if err != nil {
-		h.sendError(w, "invalid token", http.StatusUnauthorized)
+	if claims.TokenUse == utils.TokenUseService {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token_use": claims.TokenUse,
+			"client_id": claims.ClientID,
+			"scope":     claims.Scope,
+		})
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	response := map[string]interface{}{
 		"id":    claims.UserID,
 		"email": claims.Email,
-	})
+	}
+	if claims.SessionID != "" {
+		response["session_id"] = claims.SessionID
+	}
+	if claims.EmailVerified != nil {
+		response["email_verified"] = *claims.EmailVerified
+	}
+	if claims.ClientID != "" {
+		response["client_id"] = claims.ClientID
+		response["scope"] = claims.Scope
+	} else if claims.Scope != "" {
+		response["scope"] = claims.Scope
+	}
+	if len(claims.Roles) > 0 {
+		response["roles"] = claims.Roles
+	}
+	if len(claims.Permissions) > 0 {
+		response["permissions"] = claims.Permissions
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// JWKS 토큰 검증용 공개키 목록 (/.well-known/jwks.json)
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(h.authService.JWKS())
 }
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// SendLoginCode 로그인용 숫자 코드 메일 발송
+func (h *AuthHandler) SendLoginCode(w http.ResponseWriter, r *http.Request) {
+	var req models.EmailOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.SendLoginCode(r.Context(), &req); err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "If an account exists with that email, a sign-in code has been sent",
+	})
+}
+
+// LoginWithCode 이메일과 숫자 코드로 로그인
+func (h *AuthHandler) LoginWithCode(w http.ResponseWriter, r *http.Request) {
+	var req models.EmailOTPLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.LoginWithCode(r.Context(), &req)
+	if err != nil {
+		h.sendLoginError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+)
+
+// ListFederatedProviders 사용할 수 있는 외부 로그인 제공자 목록
+func (h *AuthHandler) ListFederatedProviders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{
+		"providers": h.authService.FederatedProviders(),
+	})
+}
+
+// BeginFederatedLogin 외부 제공자의 로그인 화면으로 리다이렉트
+func (h *AuthHandler) BeginFederatedLogin(w http.ResponseWriter, r *http.Request) {
+	redirectTo, err := h.authService.BeginFederatedLogin(r.Context(), mux.Vars(r)["provider"])
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownProvider) {
+			h.sendError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.sendError(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// FederatedCallback 제공자 콜백 처리 후 웹 앱으로 리다이렉트 (로그인 코드 또는 오류)
+func (h *AuthHandler) FederatedCallback(w http.ResponseWriter, r *http.Request) {
+	redirectTo, err := h.authService.FinishFederatedLogin(r.Context(), mux.Vars(r)["provider"], r.URL.Query())
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// ExchangeFederatedLogin 로그인 코드로 토큰 발급
+func (h *AuthHandler) ExchangeFederatedLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.FederatedExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.ExchangeFederatedLogin(r.Context(), &req)
+	if err != nil {
+		h.sendLoginError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
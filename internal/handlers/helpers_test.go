@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/memory"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/email"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// discardSender 메일을 보내지 않는 테스트용 Sender
+type discardSender struct{}
+
+func (discardSender) Send(ctx context.Context, msg *email.Message) error { return nil }
+
+// testHandler 메모리 저장소를 쓰는 테스트용 AuthHandler
+type testHandler struct {
+	*AuthHandler
+	service *services.AuthService
+	stores  repository.Stores
+}
+
+// newTestHandler 기본 설정으로 테스트용 AuthHandler 생성
+func newTestHandler(t *testing.T) *testHandler {
+	t.Helper()
+
+	cfg := &config.Config{
+		AccessTokenTTL:          15,
+		RefreshTokenTTL:         24,
+		WebAppURL:               "http://localhost:3000",
+		EmailVerificationPolicy: config.EmailVerificationAllow,
+	}
+	stores := memory.NewStores()
+	keys := utils.NewKeySet(utils.NewHMACSigningKey("", []byte("0123456789abcdef0123456789abcdef")))
+	service := services.NewAuthService(stores, email.NewEmailService(discardSender{}, "no-reply@example.com"), nil, keys, nil, cfg)
+	return &testHandler{AuthHandler: NewAuthHandler(service), service: service, stores: stores}
+}
+
+// login roles와 permissions를 가진 인증된 사용자를 만들고 액세스 토큰 반환
+func (th *testHandler) login(t *testing.T, emailAddr string, roles, permissions []string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	hashed, err := utils.HashPassword("Password123!")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	user := &models.User{Email: emailAddr, Password: hashed, EmailVerified: true, Roles: roles}
+	if err := th.stores.Users.CreateUser(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	created, err := th.stores.Users.FindUserByEmail(ctx, emailAddr)
+	if err != nil || created == nil {
+		t.Fatalf("find created user: %v", err)
+	}
+	if len(permissions) > 0 {
+		if _, err := th.stores.Users.SetPermissions(ctx, created.ID, permissions); err != nil {
+			t.Fatalf("set permissions: %v", err)
+		}
+	}
+
+	resp, err := th.service.LoginUser(ctx, &models.LoginRequest{Email: emailAddr, Password: "Password123!"})
+	if err != nil || resp.Token == "" {
+		t.Fatalf("login %s: %+v, %v", emailAddr, resp, err)
+	}
+	return resp.Token
+}
+
+// serviceToken client_credentials로 발급받은 서비스 토큰
+func (th *testHandler) serviceToken(t *testing.T, scopes ...string) string {
+	t.Helper()
+
+	secret := utils.GenerateRandomToken(32)
+	client := &models.ServiceClient{
+		ClientID:   utils.GenerateRandomToken(16),
+		SecretHash: utils.HashToken(secret),
+		Name:       "order service",
+		Scopes:     scopes,
+	}
+	if err := th.stores.ServiceClients.CreateServiceClient(context.Background(), client); err != nil {
+		t.Fatalf("create service client: %v", err)
+	}
+
+	resp, err := th.service.OAuthToken(context.Background(), &models.OAuthTokenRequest{
+		GrantType:    "client_credentials",
+		ClientID:     client.ClientID,
+		ClientSecret: secret,
+	})
+	if err != nil {
+		t.Fatalf("client credentials: %v", err)
+	}
+	return resp.AccessToken
+}
+
+// serve token을 Bearer로 붙여 handler에 요청 (token이 비어 있으면 Authorization 없음)
+func serve(handler http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
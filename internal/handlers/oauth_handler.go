@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+)
+
+// OAuthErrorResponse RFC 6749 오류 응답
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// CreateOAuthClient OAuth 클라이언트 등록
+func (h *AuthHandler) CreateOAuthClient(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateOAuthClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.CreateOAuthClient(r.Context(), claims, &req)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListOAuthClients 등록한 OAuth 클라이언트 목록
+func (h *AuthHandler) ListOAuthClients(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	clients, err := h.authService.ListOAuthClients(r.Context(), claims)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clients)
+}
+
+// DeleteOAuthClient OAuth 클라이언트 삭제
+func (h *AuthHandler) DeleteOAuthClient(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DeleteOAuthClient(r.Context(), claims, mux.Vars(r)["id"]); err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "OAuth client has been deleted",
+	})
+}
+
+// BeginAuthorization 인가 요청을 확인하고 웹 앱의 동의 화면으로 리다이렉트
+func (h *AuthHandler) BeginAuthorization(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := models.AuthorizeRequest{
+		ResponseType:        query.Get("response_type"),
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+		Nonce:               query.Get("nonce"),
+	}
+
+	redirectTo, err := h.authService.BeginAuthorization(r.Context(), &req)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// Authorize 로그인한 사용자의 동의 결과 처리 (인가 코드가 붙은 redirect_uri 반환)
+func (h *AuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AuthorizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.Authorize(r.Context(), claims, &req)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// OAuthToken 토큰 엔드포인트 (authorization_code, refresh_token, client_credentials)
+func (h *AuthHandler) OAuthToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.sendOAuthError(w, &services.OAuthError{Code: "invalid_request", Description: "invalid form body"})
+		return
+	}
+
+	clientID, clientSecret, ok := clientCredentials(r)
+	if !ok {
+		h.sendOAuthError(w, &services.OAuthError{Code: "invalid_client", Description: "client authentication failed"})
+		return
+	}
+
+	req := models.OAuthTokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+
+	response, err := h.authService.OAuthToken(r.Context(), &req)
+	if err != nil {
+		h.sendOAuthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RevokeOAuthToken 클라이언트의 토큰 폐기 (RFC 7009)
+func (h *AuthHandler) RevokeOAuthToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.sendOAuthError(w, &services.OAuthError{Code: "invalid_request", Description: "invalid form body"})
+		return
+	}
+
+	clientID, clientSecret, ok := clientCredentials(r)
+	if !ok {
+		h.sendOAuthError(w, &services.OAuthError{Code: "invalid_client", Description: "client authentication failed"})
+		return
+	}
+
+	req := models.OAuthRevokeRequest{
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+	}
+
+	if err := h.authService.RevokeOAuthToken(r.Context(), &req); err != nil {
+		h.sendOAuthError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// clientCredentials HTTP Basic(client_secret_basic) 또는 폼(client_secret_post)의 클라이언트 인증 정보
+func clientCredentials(r *http.Request) (string, string, bool) {
+	if username, password, ok := r.BasicAuth(); ok {
+		// RFC 6749 2.3.1: Basic 인증 값은 form-urlencoded
+		clientID, err := url.QueryUnescape(username)
+		if err != nil {
+			return "", "", false
+		}
+		clientSecret, err := url.QueryUnescape(password)
+		if err != nil {
+			return "", "", false
+		}
+		return clientID, clientSecret, true
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"), true
+}
+
+// sendOAuthError RFC 6749 형식의 오류 응답 (OAuthError가 아니면 server_error)
+func (h *AuthHandler) sendOAuthError(w http.ResponseWriter, err error) {
+	var oauthErr *services.OAuthError
+	if !errors.As(err, &oauthErr) {
+		oauthErr = &services.OAuthError{Code: "server_error", Description: err.Error()}
+	}
+
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case "invalid_client":
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	case "server_error":
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+)
+
+// OpenIDConfiguration OpenID Connect Discovery / RFC 8414 메타데이터
+func (h *AuthHandler) OpenIDConfiguration(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(h.authService.ProviderMetadata())
+}
+
+// UserInfo OpenID Connect userinfo 엔드포인트 (openid scope가 있는 액세스 토큰)
+func (h *AuthHandler) UserInfo(w http.ResponseWriter, r *http.Request) {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if tokenString == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="userinfo"`)
+		h.sendError(w, "no token provided", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.authService.ValidateToken(r.Context(), tokenString)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		h.sendError(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	info, err := h.authService.UserInfo(r.Context(), claims)
+	if err != nil {
+		var oauthErr *services.OAuthError
+		if errors.As(err, &oauthErr) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="`+oauthErr.Code+`"`)
+			h.sendError(w, oauthErr.Description, http.StatusForbidden)
+			return
+		}
+		h.sendError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// claimsKey RequirePermission이 검증한 클레임의 context 키
+type claimsKey struct{}
+
+// RequirePermission 토큰의 permissions 클레임에 모든 권한이 있어야 next 실행
+// 검증한 클레임은 context에 넣어 핸들러가 토큰을 다시 검증하지 않게 한다.
+func (h *AuthHandler) RequirePermission(next http.HandlerFunc, permissions ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := h.authenticate(w, r)
+		if !ok {
+			return
+		}
+
+		var missing []string
+		for _, permission := range permissions {
+			if !hasClaim(claims.Permissions, permission) {
+				missing = append(missing, permission)
+			}
+		}
+		if len(missing) > 0 {
+			h.sendError(w, "missing permission: "+strings.Join(missing, ", "), http.StatusForbidden)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims)))
+	})
+}
+
+// ListRoles 관리자: 역할과 역할별 권한 목록
+func (h *AuthHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	roles, err := h.authService.ListRoles(r.Context(), claims)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(roles)
+}
+
+// UpdateUserRoles 관리자: 사용자의 역할 교체
+func (h *AuthHandler) UpdateUserRoles(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.authService.SetUserRoles(r.Context(), claims, mux.Vars(r)["id"], &req)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// UpdateUserPermissions 관리자: 사용자에게 직접 부여한 권한 교체
+func (h *AuthHandler) UpdateUserPermissions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.authService.SetUserPermissions(r.Context(), claims, mux.Vars(r)["id"], &req)
+	if err != nil {
+		h.sendAdminError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// hasClaim 클레임 목록에 값이 있는지 확인
+func hasClaim(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// protected RequirePermission 뒤에서 context의 클레임을 확인하고 200을 응답하는 핸들러
+func (th *testHandler) protected(t *testing.T, permissions ...string) http.Handler {
+	return th.RequirePermission(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(claimsKey{}).(*utils.JWTClaim); !ok {
+			t.Error("verified claims were not passed to the handler")
+		}
+		w.WriteHeader(http.StatusOK)
+	}, permissions...)
+}
+
+func TestRequirePermission(t *testing.T) {
+	th := newTestHandler(t)
+	buyer := th.login(t, "buyer@example.com", []string{models.RoleBuyer}, nil)
+	seller := th.login(t, "seller@example.com", []string{models.RoleSeller}, nil)
+	admin := th.login(t, "admin@example.com", []string{models.RoleBuyer, models.RoleAdmin}, nil)
+	support := th.login(t, "support@example.com", []string{models.RoleBuyer}, []string{models.PermissionUsersRead})
+	service := th.serviceToken(t, models.PermissionUsersRead)
+
+	tests := map[string]struct {
+		token       string
+		permissions []string
+		status      int
+		message     string
+	}{
+		"permission from role":        {seller, []string{models.PermissionProductsWrite}, http.StatusOK, ""},
+		"permission from admin role":  {admin, []string{models.PermissionUsersRead, models.PermissionUsersWrite}, http.StatusOK, ""},
+		"direct permission":           {support, []string{models.PermissionUsersRead}, http.StatusOK, ""},
+		"direct and role permissions": {support, []string{models.PermissionUsersRead, models.PermissionOrdersCreate}, http.StatusOK, ""},
+		"missing permission":          {buyer, []string{models.PermissionUsersRead}, http.StatusForbidden, "missing permission: users:read"},
+		"one of several missing":      {support, []string{models.PermissionUsersRead, models.PermissionUsersWrite}, http.StatusForbidden, "missing permission: users:write"},
+		"other role's permission":     {seller, []string{models.PermissionOrdersCreate}, http.StatusForbidden, "missing permission: orders:create"},
+		"service token":               {service, []string{models.PermissionUsersRead}, http.StatusForbidden, "delegated tokens"},
+		"missing bearer":              {"", []string{models.PermissionUsersRead}, http.StatusUnauthorized, "no token provided"},
+		"invalid bearer":              {"not-a-jwt", []string{models.PermissionUsersRead}, http.StatusUnauthorized, "invalid token"},
+		"tampered bearer":             {admin[:len(admin)-2] + "xx", []string{models.PermissionUsersRead}, http.StatusUnauthorized, "invalid token"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(th.protected(t, tt.permissions...), "GET", "/admin/users", tt.token)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.message != "" && !strings.Contains(rec.Body.String(), tt.message) {
+				t.Errorf("body = %s, want %q", rec.Body, tt.message)
+			}
+		})
+	}
+}
+
+func TestRequirePermissionRejectsRevokedToken(t *testing.T) {
+	th := newTestHandler(t)
+	admin := th.login(t, "admin@example.com", []string{models.RoleBuyer, models.RoleAdmin}, nil)
+
+	claims, err := th.service.ValidateToken(context.Background(), admin)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	if err := th.service.LogoutAll(context.Background(), claims); err != nil {
+		t.Fatalf("logout all: %v", err)
+	}
+
+	// 권한 클레임이 남아 있어도 폐기된 토큰은 401
+	if rec := serve(th.protected(t, models.PermissionUsersRead), "GET", "/admin/users", admin); rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestRequirePermissionWithoutBearerPrefix(t *testing.T) {
+	th := newTestHandler(t)
+	admin := th.login(t, "admin@example.com", []string{models.RoleBuyer, models.RoleAdmin}, nil)
+
+	req := httptest.NewRequest("GET", "/admin/users", nil)
+	req.Header.Set("Authorization", "Basic "+admin)
+	rec := httptest.NewRecorder()
+	th.protected(t, models.PermissionUsersRead).ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401: %s", rec.Code, rec.Body)
+	}
+}
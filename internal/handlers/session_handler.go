@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services"
+)
+
+// maxUserAgentLength 세션에 저장할 User-Agent 최대 길이
+const maxUserAgentLength = 512
+
+// ClientInfoMiddleware 세션 기록용 기기 정보(User-Agent, 클라이언트 IP)를 요청 context에 저장
+func ClientInfoMiddleware(clientIP func(r *http.Request) string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent := r.UserAgent()
+			if len(userAgent) > maxUserAgentLength {
+				userAgent = userAgent[:maxUserAgentLength]
+			}
+
+			ctx := services.WithClientInfo(r.Context(), services.ClientInfo{
+				UserAgent: userAgent,
+				IP:        clientIP(r),
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ListSessions 로그인한 기기 목록
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.sessionClaims(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(r.Context(), claims)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// RevokeSession 기기 하나를 원격 로그아웃
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.sessionClaims(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeSession(r.Context(), claims, mux.Vars(r)["id"]); err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Session has been revoked",
+	})
+}
+
+// RevokeOtherSessions 현재 기기를 뺀 모든 기기를 원격 로그아웃
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.sessionClaims(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeOtherSessions(r.Context(), claims); err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Other sessions have been revoked",
+	})
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+func TestSessionEndpointsRejectServiceToken(t *testing.T) {
+	th := newTestHandler(t)
+	serviceToken := th.serviceToken(t, models.PermissionOrdersRead)
+
+	// 서비스 토큰은 검증 엔드포인트에서는 유효하지만 사용자 세션 API에는 쓸 수 없다
+	if rec := serve(http.HandlerFunc(th.VerifyToken), "GET", "/auth/verify", serviceToken); rec.Code != http.StatusOK {
+		t.Fatalf("verify service token = %d, want 200", rec.Code)
+	}
+
+	endpoints := map[string]http.HandlerFunc{
+		"list sessions":         th.ListSessions,
+		"revoke other sessions": th.RevokeOtherSessions,
+		"logout":                th.Logout,
+		"logout all":            th.LogoutAll,
+	}
+	for name, handler := range endpoints {
+		t.Run(name, func(t *testing.T) {
+			if rec := serve(handler, "POST", "/", serviceToken); rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403: %s", rec.Code, rec.Body)
+			}
+		})
+	}
+
+	// 같은 엔드포인트가 사용자 토큰은 받아들인다
+	userToken := th.login(t, "member@example.com", []string{models.RoleBuyer}, nil)
+	if rec := serve(http.HandlerFunc(th.ListSessions), "GET", "/auth/sessions", userToken); rec.Code != http.StatusOK {
+		t.Errorf("list sessions with a user token = %d, want 200: %s", rec.Code, rec.Body)
+	}
+}
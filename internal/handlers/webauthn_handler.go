@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// BeginWebAuthnRegistration 패스키 등록 시작 (navigator.credentials.create 옵션)
+func (h *AuthHandler) BeginWebAuthnRegistration(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.authService.BeginWebAuthnRegistration(r.Context(), claims)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// FinishWebAuthnRegistration 인증기 응답 검증 후 패스키 저장
+func (h *AuthHandler) FinishWebAuthnRegistration(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.WebAuthnRegisterFinishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	credential, err := h.authService.FinishWebAuthnRegistration(r.Context(), claims, &req)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(credential)
+}
+
+// ListWebAuthnCredentials 등록된 패스키 목록
+func (h *AuthHandler) ListWebAuthnCredentials(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	credentials, err := h.authService.ListWebAuthnCredentials(r.Context(), claims)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"credentials": credentials,
+	})
+}
+
+// DeleteWebAuthnCredential 패스키 삭제
+func (h *AuthHandler) DeleteWebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DeleteWebAuthnCredential(r.Context(), claims, mux.Vars(r)["id"]); err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Passkey has been removed",
+	})
+}
+
+// BeginWebAuthnLogin 패스키 로그인 시작 (navigator.credentials.get 옵션)
+func (h *AuthHandler) BeginWebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	response, err := h.authService.BeginWebAuthnLogin(r.Context())
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// FinishWebAuthnLogin 패스키 로그인 완료 후 토큰 발급
+func (h *AuthHandler) FinishWebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.WebAuthnLoginFinishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.FinishWebAuthnLogin(r.Context(), &req)
+	if err != nil {
+		h.sendLoginError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// BeginWebAuthnMFA 로그인 2단계를 패스키로 진행 (MFA 토큰 필요)
+func (h *AuthHandler) BeginWebAuthnMFA(w http.ResponseWriter, r *http.Request) {
+	var req models.WebAuthnMFABeginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.BeginWebAuthnMFA(r.Context(), &req)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// FinishWebAuthnMFA 패스키로 로그인 2단계 완료 후 토큰 발급
+func (h *AuthHandler) FinishWebAuthnMFA(w http.ResponseWriter, r *http.Request) {
+	var req models.WebAuthnMFAFinishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authService.FinishWebAuthnMFA(r.Context(), &req)
+	if err != nil {
+		h.sendLoginError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ListUsersRequest 관리자 사용자 검색 (page는 1부터)
+type ListUsersRequest struct {
+	Email         string
+	Status        UserStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Page          int
+	PageSize      int
+}
+
+// UserFilter 사용자 검색 조건 (비어 있는 조건은 적용하지 않는다)
+type UserFilter struct {
+	Email         string     // 이메일 부분 일치 (대소문자 무시)
+	Status        UserStatus // active는 상태가 저장되지 않은 이전 계정도 포함
+	CreatedAfter  *time.Time // 이 시각 이후 가입 (포함)
+	CreatedBefore *time.Time // 이 시각 이전 가입 (제외)
+	Offset        int
+	Limit         int
+}
+
+// UserListResponse 사용자 검색 결과 한 페이지
+type UserListResponse struct {
+	Users    []*User `json:"users"`
+	Total    int64   `json:"total"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"page_size"`
+}
+
+// AdminUserActionRequest 관리자 작업 사유 (필수, 감사 기록에 남는다)
+type AdminUserActionRequest struct {
+	Reason string `json:"reason"`
+}
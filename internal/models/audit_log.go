@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditLog 관리자가 사용자 계정에 한 작업 기록
+type AuditLog struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ActorID      primitive.ObjectID `bson:"actor_id" json:"actor_id"`
+	ActorEmail   string             `bson:"actor_email" json:"actor_email"` // 작업 당시 관리자 이메일
+	Action       string             `bson:"action" json:"action"`
+	TargetUserID primitive.ObjectID `bson:"target_user_id" json:"target_user_id"`
+	Detail       string             `bson:"detail,omitempty" json:"detail,omitempty"` // 상태 변경 전후 등
+	Reason       string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	IP           string             `bson:"ip,omitempty" json:"ip,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// 감사 기록 작업 종류
+const (
+	AuditActionSuspendUser        = "user.suspend"
+	AuditActionBanUser            = "user.ban"
+	AuditActionReactivateUser     = "user.reactivate"
+	AuditActionDeleteUser         = "user.delete"
+	AuditActionForcePasswordReset = "user.force_password_reset"
+	AuditActionVerifyEmail        = "user.verify_email"
+	AuditActionUnlockUser         = "user.unlock"
+	AuditActionUpdateRoles        = "user.update_roles"
+	AuditActionUpdatePermissions  = "user.update_permissions"
+	AuditActionBootstrapAdmin     = "user.bootstrap_admin" // 시작할 때 ADMIN_EMAILS 계정에 admin 역할 부여
+)
+
+// AuditActorSystem 관리자가 아닌 서비스 설정으로 한 작업의 ActorEmail (ActorID는 비어 있음)
+const AuditActorSystem = "system"
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuthFlow 여러 요청에 걸친 인증 절차의 임시 상태 (WebAuthn 챌린지, 이메일 OTP 등)
+// 한 번 꺼내면 삭제되며, ExpiresAt이 지나면 자동으로 정리된다.
+type AuthFlow struct {
+	ID        string             `bson:"_id"`
+	Kind      string             `bson:"kind"`
+	UserID    primitive.ObjectID `bson:"user_id,omitempty"`
+	Data      []byte             `bson:"data"`
+	Attempts  int                `bson:"attempts"` // 검증 시도 횟수 (이메일 OTP)
+	ExpiresAt time.Time          `bson:"expires_at"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FederatedIdentity 사용자와 연결된 외부 로그인 계정 (google, kakao, naver 등)
+type FederatedIdentity struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Provider  string             `bson:"provider" json:"provider"`
+	Subject   string             `bson:"subject" json:"-"` // 제공자 안에서 고유한 사용자 ID
+	UserID    primitive.ObjectID `bson:"user_id" json:"-"`
+	Email     string             `bson:"email" json:"email"` // 연결 당시 제공자가 확인한 이메일
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// 외부 로그인 API 요청 구조체
+type FederatedExchangeRequest struct {
+	Code string `json:"code"` // 콜백 후 웹 앱으로 전달된 일회용 로그인 코드
+}
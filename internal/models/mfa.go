@@ -0,0 +1,29 @@
+package models
+
+// 2단계 인증 API 요청/응답 구조체
+type TOTPEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	QRCodePNG  string `json:"qr_code_png"` // base64 인코딩된 PNG
+}
+
+type TOTPVerifyRequest struct {
+	Code string `json:"code"`
+}
+
+type TOTPVerifyResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TOTPDisableRequest struct {
+	Password     string `json:"password"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// MFAChallengeRequest 로그인 2단계: TOTP 코드 또는 복구 코드 중 하나
+type MFAChallengeRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
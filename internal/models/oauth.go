@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OAuth scope (서버가 지원하는 scope는 OAUTH_SCOPES로 설정)
+const (
+	ScopeOpenID        = "openid"         // OpenID Connect ID 토큰 발급
+	ScopeProfile       = "profile"        // 프로필 정보 (userinfo)
+	ScopeEmail         = "email"          // 이메일과 인증 여부
+	ScopeOfflineAccess = "offline_access" // 리프레시 토큰 발급
+)
+
+// OAuthClient OAuth 2.0 클라이언트 (서드파티 판매자 연동 앱 등)
+// 공개 클라이언트(모바일/SPA)는 시크릿이 없고 PKCE만으로 인가 코드를 교환한다.
+type OAuthClient struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ClientID     string             `bson:"client_id" json:"client_id"`
+	SecretHash   string             `bson:"secret_hash,omitempty" json:"-"` // SHA-256, 원문은 등록 응답으로만 전달
+	Name         string             `bson:"name" json:"name"`
+	RedirectURIs []string           `bson:"redirect_uris" json:"redirect_uris"`
+	Scopes       []string           `bson:"scopes" json:"scopes"` // 요청할 수 있는 scope
+	Public       bool               `bson:"public" json:"public"`
+	OwnerID      primitive.ObjectID `bson:"owner_id" json:"-"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// OAuth API 요청/응답 구조체
+type CreateOAuthClientRequest struct {
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	Public       bool     `json:"public"`
+}
+
+// CreateOAuthClientResponse 등록 결과 (ClientSecret은 이때 한 번만 반환)
+type CreateOAuthClientResponse struct {
+	*OAuthClient
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// AuthorizeRequest /oauth/authorize 파라미터 (GET 쿼리, POST JSON 공통)
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	Nonce               string `json:"nonce,omitempty"` // OpenID Connect, ID 토큰에 그대로 포함
+	Deny                bool   `json:"deny,omitempty"`  // 사용자가 동의를 거부한 경우
+}
+
+// AuthorizeResponse 동의 결과 (웹 앱이 RedirectTo로 이동)
+type AuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// OAuthTokenRequest /oauth/token 파라미터 (application/x-www-form-urlencoded)
+type OAuthTokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+	ClientID     string
+	ClientSecret string
+}
+
+// OAuthTokenResponse RFC 6749 토큰 응답
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"` // openid scope가 있을 때
+}
+
+// OAuthRevokeRequest /oauth/revoke 파라미터 (RFC 7009)
+type OAuthRevokeRequest struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RevokedToken 만료 전에 폐기된 액세스 토큰 (jti 기준)
+// 원래 토큰의 만료 시각이 지나면 자동으로 삭제된다.
+type RevokedToken struct {
+	JTI       string             `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	ExpiresAt time.Time          `bson:"expires_at"`
+	RevokedAt time.Time          `bson:"revoked_at"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import "sort"
+
+// 사용자 역할
+const (
+	RoleBuyer  = "buyer" // 가입하면 기본으로 부여
+	RoleSeller = "seller"
+	RoleAdmin  = "admin"
+)
+
+// 권한 (리소스:동작), 다른 prisma-market 서비스는 토큰의 permissions 클레임으로 확인한다
+const (
+	PermissionOrdersCreate         = "orders:create"
+	PermissionOrdersRead           = "orders:read"
+	PermissionProductsWrite        = "products:write"
+	PermissionOrdersFulfill        = "orders:fulfill"
+	PermissionUsersRead            = "users:read"
+	PermissionUsersWrite           = "users:write"
+	PermissionRolesAssign          = "roles:assign"
+	PermissionAuditLogsRead        = "audit_logs:read"
+	PermissionServiceClientsManage = "service_clients:manage"
+)
+
+// allPermissions 알려진 모든 권한 (관리자 역할의 권한)
+var allPermissions = []string{
+	PermissionOrdersCreate,
+	PermissionOrdersRead,
+	PermissionProductsWrite,
+	PermissionOrdersFulfill,
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionRolesAssign,
+	PermissionAuditLogsRead,
+	PermissionServiceClientsManage,
+}
+
+// rolePermissions 역할별 권한
+var rolePermissions = map[string][]string{
+	RoleBuyer:  {PermissionOrdersCreate, PermissionOrdersRead},
+	RoleSeller: {PermissionProductsWrite, PermissionOrdersFulfill},
+	RoleAdmin:  allPermissions,
+}
+
+// RoleInfo 역할과 그 역할이 가진 권한
+type RoleInfo struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// Roles 정의된 역할 목록 (이름 순)
+func Roles() []RoleInfo {
+	roles := make([]RoleInfo, 0, len(rolePermissions))
+	for name, permissions := range rolePermissions {
+		roles = append(roles, RoleInfo{
+			Name:        name,
+			Permissions: append([]string(nil), permissions...),
+		})
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+	return roles
+}
+
+// IsKnownRole 정의된 역할인지 확인
+func IsKnownRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// IsKnownPermission 정의된 권한인지 확인
+func IsKnownPermission(permission string) bool {
+	for _, p := range allPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateRolesRequest 관리자의 역할 변경 (목록 전체를 교체)
+type UpdateRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// UpdatePermissionsRequest 관리자의 직접 권한 변경 (목록 전체를 교체, 역할 권한과 별개)
+type UpdatePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ServiceClient 서비스 간 인증용 클라이언트 (주문, 결제 등 백엔드 서비스)
+// client_credentials 그랜트로 사용자 없이 자신의 토큰을 발급받는다.
+type ServiceClient struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ClientID   string             `bson:"client_id" json:"client_id"`
+	SecretHash string             `bson:"secret_hash" json:"-"` // SHA-256, 원문은 등록 응답으로만 전달
+	Name       string             `bson:"name" json:"name"`
+	Scopes     []string           `bson:"scopes" json:"scopes"` // 요청할 수 있는 scope
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// 서비스 클라이언트 API 요청/응답 구조체
+type CreateServiceClientRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateServiceClientResponse 등록 결과 (ClientSecret은 이때 한 번만 반환)
+type CreateServiceClientResponse struct {
+	*ServiceClient
+	ClientSecret string `json:"client_secret"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session 로그인한 기기 (ID는 리프레시 토큰 패밀리 ID와 같고 액세스 토큰의 sid로 들어간다)
+type Session struct {
+	ID         string             `bson:"_id" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"-"`
+	UserAgent  string             `bson:"user_agent" json:"user_agent"`
+	IP         string             `bson:"ip" json:"ip"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastSeenAt time.Time          `bson:"last_seen_at" json:"last_seen_at"` // 로그인 또는 마지막 토큰 갱신 시각
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`     // 리프레시 토큰 만료 시각
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"-"`
+	Current    bool               `bson:"-" json:"current"` // 목록을 요청한 토큰의 세션
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// SigningKeyRecord 저장소에 보관되는 JWT 서명 키
+// 서명 키는 ActivatesAt이 가장 최근(현재 이전)인 키이며, 나머지는 검증 전용이다.
+type SigningKeyRecord struct {
+	ID          string    `bson:"_id" json:"kid"`
+	Algorithm   string    `bson:"algorithm" json:"alg"`
+	PrivateKey  string    `bson:"private_key" json:"private_key"` // PEM (HS256은 base64 비밀값)
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	ActivatesAt time.Time `bson:"activates_at" json:"activates_at"`
+}
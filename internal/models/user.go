@@ -1,24 +1,144 @@
 package models
 
 import (
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type User struct {
-	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Email             string             `bson:"email" json:"email"`
-	Password          string             `bson:"password" json:"-"`
-	EmailVerified     bool               `bson:"email_verified" json:"email_verified"`
-	EmailVerifyToken  string             `bson:"email_verify_token,omitempty" json:"-"`
-	EmailVerifyExpiry time.Time          `bson:"email_verify_expiry,omitempty" json:"-"`
-	ResetToken        string             `bson:"reset_token,omitempty" json:"-"`
-	ResetTokenExpiry  time.Time          `bson:"reset_token_expiry,omitempty" json:"-"`
-	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
-	Status            string             `bson:"status" json:"status"`
-	LastLogin         *time.Time         `bson:"last_login,omitempty" json:"last_login,omitempty"`
+	ID                   primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Email                string             `bson:"email" json:"email"`
+	Password             string             `bson:"password" json:"-"`
+	EmailVerified        bool               `bson:"email_verified" json:"email_verified"`
+	EmailVerifyTokenHash string             `bson:"email_verify_token_hash,omitempty" json:"-"` // SHA-256, 원문은 메일로만 전달
+	EmailVerifyExpiry    time.Time          `bson:"email_verify_expiry,omitempty" json:"-"`
+	ResetTokenHash       string             `bson:"reset_token_hash,omitempty" json:"-"` // SHA-256, 원문은 메일로만 전달
+	ResetTokenExpiry     time.Time          `bson:"reset_token_expiry,omitempty" json:"-"`
+	MagicLinkTokenHash   string             `bson:"magic_link_token_hash,omitempty" json:"-"` // SHA-256, 원문은 메일로만 전달
+	MagicLinkExpiry      time.Time          `bson:"magic_link_expiry,omitempty" json:"-"`
+	CreatedAt            time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt            time.Time          `bson:"updated_at" json:"updated_at"`
+	Status               UserStatus         `bson:"status" json:"status"`
+	StatusReason         string             `bson:"status_reason,omitempty" json:"status_reason,omitempty"`         // 정지, 차단, 삭제 사유
+	StatusChangedAt      *time.Time         `bson:"status_changed_at,omitempty" json:"status_changed_at,omitempty"` // 마지막 상태 변경 시각
+	LastLogin            *time.Time         `bson:"last_login,omitempty" json:"last_login,omitempty"`
+	TokensValidAfter     *time.Time         `bson:"tokens_valid_after,omitempty" json:"-"` // 이 시각 이전에 발급된 토큰은 무효
+	TokenVersion         int                `bson:"token_version" json:"-"`                // 보안 스탬프, 올라가면 기존 토큰 무효
+
+	// TOTP 2단계 인증 (TOTPSecret은 등록 중에도 저장되며 검증 후 TOTPEnabled가 된다)
+	TOTPSecret    string   `bson:"totp_secret,omitempty" json:"-"`
+	TOTPEnabled   bool     `bson:"totp_enabled" json:"totp_enabled"`
+	TOTPLastStep  int64    `bson:"totp_last_step,omitempty" json:"-"` // 마지막으로 사용된 시간 단계 (재사용 방지)
+	RecoveryCodes []string `bson:"recovery_codes,omitempty" json:"-"` // bcrypt 해시
+
+	// 로그인 실패 잠금
+	FailedLoginAttempts int        `bson:"failed_login_attempts" json:"-"`
+	LockedUntil         *time.Time `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+
+	Roles       []string `bson:"roles,omitempty" json:"roles,omitempty"`
+	Permissions []string `bson:"permissions,omitempty" json:"permissions,omitempty"` // 역할 외에 직접 부여한 권한
+}
+
+// ScopeUnverified 이메일 미인증 계정에 발급된 제한된 토큰의 scope (EMAIL_VERIFICATION_POLICY=restrict)
+const ScopeUnverified = "unverified"
+
+// UserStatus 계정 상태
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended" // 일시 정지 (다시 활성화할 수 있음)
+	UserStatusBanned    UserStatus = "banned"    // 이용 정지 (관리자만 해제)
+	UserStatusDeleted   UserStatus = "deleted"   // 삭제된 계정 (되돌릴 수 없음)
+)
+
+// userStatusTransitions 상태별로 바꿀 수 있는 다음 상태
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusActive:    {UserStatusSuspended, UserStatusBanned, UserStatusDeleted},
+	UserStatusSuspended: {UserStatusActive, UserStatusBanned, UserStatusDeleted},
+	UserStatusBanned:    {UserStatusActive, UserStatusDeleted},
+}
+
+// Valid 알려진 상태인지 확인
+func (s UserStatus) Valid() bool {
+	switch s {
+	case UserStatusActive, UserStatusSuspended, UserStatusBanned, UserStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo next 상태로 바꿀 수 있는지 확인
+func (s UserStatus) CanTransitionTo(next UserStatus) bool {
+	for _, allowed := range userStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountStatus 계정 상태 (상태가 저장되지 않은 이전 계정은 active)
+func (u *User) AccountStatus() UserStatus {
+	if u.Status == "" {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
+// AccountRoles 계정의 역할 (역할이 저장되지 않은 이전 계정은 buyer)
+func (u *User) AccountRoles() []string {
+	if len(u.Roles) == 0 {
+		return []string{RoleBuyer}
+	}
+	return u.Roles
+}
+
+// HasRole 역할 보유 여부
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.AccountRoles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectivePermissions 역할 권한과 직접 부여한 권한의 합 (이름 순)
+func (u *User) EffectivePermissions() []string {
+	seen := map[string]bool{}
+	for _, role := range u.AccountRoles() {
+		for _, permission := range rolePermissions[role] {
+			seen[permission] = true
+		}
+	}
+	for _, permission := range u.Permissions {
+		seen[permission] = true
+	}
+
+	permissions := make([]string, 0, len(seen))
+	for permission := range seen {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// HasPermission 역할 또는 직접 부여로 권한을 가졌는지 확인
+func (u *User) HasPermission(permission string) bool {
+	for _, p := range u.EffectivePermissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLocked 로그인 잠금 상태 여부
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 // API 요청/응답 구조체
@@ -32,24 +152,78 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+// LoginResponse 로그인 결과
+// 2단계 인증이 필요하면 토큰 대신 MFARequired와 MFAToken만 채워진다.
 type LoginResponse struct {
-	Token     string `json:"token"`
-	ExpiresIn int    `json:"expires_in"`
+	Token            string   `json:"token,omitempty"`
+	ExpiresIn        int      `json:"expires_in,omitempty"`
+	RefreshToken     string   `json:"refresh_token,omitempty"`
+	RefreshExpiresIn int      `json:"refresh_expires_in,omitempty"`
+	MFARequired      bool     `json:"mfa_required,omitempty"`
+	MFAToken         string   `json:"mfa_token,omitempty"`
+	MFAMethods       []string `json:"mfa_methods,omitempty"`             // totp, webauthn
+	VerificationSent bool     `json:"verification_email_sent,omitempty"` // 미인증 이메일로 로그인해 인증 메일을 다시 보낸 경우
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
+// 토큰 전달 방식 (링크 또는 직접 입력하는 숫자 코드)
+const (
+	DeliveryModeLink = "link"
+	DeliveryModeOTP  = "otp"
+)
+
 type ForgotPasswordRequest struct {
 	Email string `json:"email"`
+	Mode  string `json:"mode,omitempty"` // link(기본) | otp
 }
 
+// ResetPasswordRequest 링크의 Token 또는 Email과 OTP Code 중 하나로 재설정
 type ResetPasswordRequest struct {
-	Token       string `json:"token"`
+	Token       string `json:"token,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Code        string `json:"code,omitempty"`
 	NewPassword string `json:"new_password"`
 }
 
-type VerifyEmailRequest struct {
+// UpdateUserStatusRequest 관리자의 계정 상태 변경 (active가 아니면 사유 필요)
+type UpdateUserStatusRequest struct {
+	Status UserStatus `json:"status"`
+	Reason string     `json:"reason"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+type ConsumeMagicLinkRequest struct {
 	Token string `json:"token"`
 }
 
+// VerifyEmailRequest 링크의 Token 또는 Email과 OTP Code 중 하나로 인증
+type VerifyEmailRequest struct {
+	Token string `json:"token,omitempty"`
+	Email string `json:"email,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
 type ResendVerificationRequest struct {
 	Email string `json:"email"`
+	Mode  string `json:"mode,omitempty"` // link(기본) | otp
+}
+
+type EmailOTPRequest struct {
+	Email string `json:"email"`
+}
+
+type EmailOTPLoginRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
 }
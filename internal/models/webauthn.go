@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebAuthnCredential 사용자가 등록한 패스키(WebAuthn 자격 증명)
+type WebAuthnCredential struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID          primitive.ObjectID `bson:"user_id" json:"-"`
+	Name            string             `bson:"name" json:"name"`
+	CredentialID    []byte             `bson:"credential_id" json:"-"`
+	PublicKey       []byte             `bson:"public_key" json:"-"`
+	AttestationType string             `bson:"attestation_type" json:"-"`
+	Transports      []string           `bson:"transports,omitempty" json:"transports,omitempty"`
+	AAGUID          []byte             `bson:"aaguid,omitempty" json:"-"`
+	SignCount       uint32             `bson:"sign_count" json:"-"`
+	CloneWarning    bool               `bson:"clone_warning" json:"clone_warning"`
+	UserVerified    bool               `bson:"user_verified" json:"-"`
+	BackupEligible  bool               `bson:"backup_eligible" json:"backup_eligible"`
+	BackupState     bool               `bson:"backup_state" json:"backup_state"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt      *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+}
+
+// WebAuthn API 요청/응답 구조체
+// Options와 Credential은 브라우저의 navigator.credentials API와 주고받는 JSON 그대로 전달한다.
+type WebAuthnBeginResponse struct {
+	CeremonyID string      `json:"ceremony_id"`
+	Options    interface{} `json:"options"`
+}
+
+type WebAuthnRegisterFinishRequest struct {
+	CeremonyID string          `json:"ceremony_id"`
+	Name       string          `json:"name"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+type WebAuthnLoginFinishRequest struct {
+	CeremonyID string          `json:"ceremony_id"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+type WebAuthnMFABeginRequest struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+type WebAuthnMFAFinishRequest struct {
+	MFAToken   string          `json:"mfa_token"`
+	CeremonyID string          `json:"ceremony_id"`
+	Credential json.RawMessage `json:"credential"`
+}
@@ -0,0 +1,183 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+)
+
+// Limit 토큰 버킷 파라미터
+// 버킷은 Burst개까지 쌓이고 초당 Rate개씩 다시 채워진다.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute 분당 n회 (한 번에 n회까지 허용)
+func PerMinute(n int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: n}
+}
+
+// PerHour 시간당 n회 (한 번에 n회까지 허용)
+func PerHour(n int) Limit {
+	return Limit{Rate: float64(n) / 3600, Burst: n}
+}
+
+// refillTime 빈 버킷이 가득 찰 때까지 걸리는 시간 (키 만료에 사용)
+func (l Limit) refillTime() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Store 버킷 상태 저장소
+type Store interface {
+	// Take 키의 버킷에서 토큰 하나를 꺼낸다. 부족하면 다시 시도할 수 있을 때까지의 시간을 반환한다.
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// NewStore 설정에 맞는 Store 생성 (RATE_LIMIT_STORE가 none이면 nil)
+func NewStore(cfg *config.Config) (Store, error) {
+	switch cfg.RateLimitStore {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "redis":
+		return NewRedisStore(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store: %s", cfg.RateLimitStore)
+	}
+}
+
+// KeyFunc 요청에서 제한 기준 키 추출 (빈 문자열이면 해당 정책은 건너뜀)
+type KeyFunc func(r *http.Request) string
+
+// Policy 라우트에 적용할 제한 정책
+type Policy struct {
+	Name  string
+	Limit Limit
+	Key   KeyFunc
+}
+
+// Limiter 정책에 따라 요청을 제한하는 미들웨어 생성기
+type Limiter struct {
+	store      Store
+	trustProxy bool
+}
+
+// NewLimiter Limiter 생성자 (store가 nil이면 제한하지 않음)
+// trustProxy가 true면 X-Forwarded-For의 마지막 주소를 클라이언트 IP로 사용한다.
+func NewLimiter(store Store, trustProxy bool) *Limiter {
+	return &Limiter{store: store, trustProxy: trustProxy}
+}
+
+// PerIP 클라이언트 IP 기준 정책
+func (l *Limiter) PerIP(name string, limit Limit) Policy {
+	return Policy{Name: name, Limit: limit, Key: l.ClientIP}
+}
+
+// PerEmail 요청 본문의 email 필드 기준 정책
+func (l *Limiter) PerEmail(name string, limit Limit) Policy {
+	return Policy{Name: name, Limit: limit, Key: EmailFromBody}
+}
+
+// Wrap 핸들러에 정책을 순서대로 적용
+func (l *Limiter) Wrap(next http.HandlerFunc, policies ...Policy) http.Handler {
+	if l.store == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, policy := range policies {
+			key := policy.Key(r)
+			if key == "" {
+				continue
+			}
+
+			allowed, retryAfter, err := l.store.Take(r.Context(), policy.Name+":"+key, policy.Limit)
+			if err != nil {
+				// 저장소 장애로 로그인이 막히지 않도록 통과시킨다
+				log.Printf("rate limit store error: %v", err)
+				continue
+			}
+			if !allowed {
+				tooManyRequests(w, retryAfter)
+				return
+			}
+		}
+		next(w, r)
+	})
+}
+
+// ClientIP 요청의 클라이언트 IP
+func (l *Limiter) ClientIP(r *http.Request) string {
+	if l.trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			parts := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// 본문 검사 시 읽는 최대 크기
+const maxBodyPeek = 64 << 10
+
+// EmailFromBody JSON 본문의 email 필드 (소문자로 정규화)
+// 앞부분(maxBodyPeek)만 읽고, 핸들러가 전체 본문을 읽을 수 있도록 읽은 부분을 남은 본문 앞에 붙여 둔다.
+func EmailFromBody(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyPeek))
+	r.Body = peekedBody{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(payload.Email))
+}
+
+// peekedBody 미리 읽은 부분과 남은 본문을 이어 읽고, 닫을 때는 원래 본문을 닫는다
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// tooManyRequests 429 응답
+func tooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	w.Header().Set("Retry-After", fmt.Sprint(seconds))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": "Too many requests",
+	})
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// closeRecorder Close 호출 여부를 기록하는 본문
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestEmailFromBody(t *testing.T) {
+	body := `{"email":" Member@Example.com ","password":"Password123!"}`
+	r := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+
+	if got := EmailFromBody(r); got != "member@example.com" {
+		t.Errorf("EmailFromBody = %q, want member@example.com", got)
+	}
+	restored, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("read restored body: %v", err)
+	}
+	if string(restored) != body {
+		t.Errorf("restored body = %q, want %q", restored, body)
+	}
+}
+
+func TestEmailFromBodyKeepsLargeBody(t *testing.T) {
+	// maxBodyPeek보다 큰 본문도 핸들러는 끝까지 읽을 수 있어야 한다
+	body := `{"email":"member@example.com","note":"` + strings.Repeat("x", 2*maxBodyPeek) + `"}`
+	source := &closeRecorder{Reader: strings.NewReader(body)}
+	r := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+	r.Body = source
+
+	if got := EmailFromBody(r); got != "" {
+		t.Errorf("EmailFromBody = %q for a body beyond the peek limit, want empty", got)
+	}
+	restored, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("read restored body: %v", err)
+	}
+	if len(restored) != len(body) || string(restored) != body {
+		t.Errorf("restored body has %d bytes, want %d", len(restored), len(body))
+	}
+
+	if source.closed {
+		t.Error("original body was closed before the handler read it")
+	}
+	r.Body.Close()
+	if !source.closed {
+		t.Error("closing the restored body did not close the original body")
+	}
+}
+
+func TestEmailFromBodyInvalidJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader("email=member@example.com"))
+
+	if got := EmailFromBody(r); got != "" {
+		t.Errorf("EmailFromBody = %q for a form body, want empty", got)
+	}
+	if restored, _ := io.ReadAll(r.Body); string(restored) != "email=member@example.com" {
+		t.Errorf("restored body = %q", restored)
+	}
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 여러 인스턴스가 같은 버킷을 공유하도록 Redis에서 원자적으로 계산
+// KEYS[1] = 버킷 키, ARGV = rate(초당), burst, 현재 시각(ms), 만료(ms)
+// 반환: {허용 여부, 재시도까지 남은 ms}
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(burst, tokens + elapsed * rate / 1000)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ttl)
+return {allowed, retry}
+`)
+
+// 다른 용도의 키와 겹치지 않도록 붙이는 접두사
+const redisKeyPrefix = "ratelimit:"
+
+// RedisStore Redis 프로토콜 서버에 버킷 저장 (여러 인스턴스 공유)
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore RedisStore 생성자
+func NewRedisStore(addr, password string, db int) (*RedisStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client}, nil
+}
+
+// Take 버킷에서 토큰 하나 사용
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	now := time.Now().UnixMilli()
+	ttl := limit.refillTime().Milliseconds() + 1000
+
+	result, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		limit.Rate, limit.Burst, now, ttl,
+	).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// Close 연결 종료
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
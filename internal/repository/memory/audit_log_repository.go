@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// AuditLogRepository 메모리 감사 기록 저장소
+type AuditLogRepository struct {
+	mu      sync.RWMutex
+	entries []*models.AuditLog // 기록 순
+}
+
+// NewAuditLogRepository AuditLogRepository 생성자
+func NewAuditLogRepository() *AuditLogRepository {
+	return &AuditLogRepository{}
+}
+
+// CreateAuditLog 기록 추가
+func (r *AuditLogRepository) CreateAuditLog(ctx context.Context, entry *models.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.CreatedAt = time.Now()
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+
+	clone := *entry
+	r.entries = append(r.entries, &clone)
+	return nil
+}
+
+// ListAuditLogsByTarget 대상 사용자에 대한 기록 (최신 순, 최대 limit개)
+func (r *AuditLogRepository) ListAuditLogsByTarget(ctx context.Context, targetUserID primitive.ObjectID, limit int) ([]*models.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []*models.AuditLog{}
+	for i := len(r.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		if r.entries[i].TargetUserID == targetUserID {
+			clone := *r.entries[i]
+			entries = append(entries, &clone)
+		}
+	}
+	return entries, nil
+}
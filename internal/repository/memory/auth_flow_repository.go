@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+// AuthFlowRepository 메모리 인증 절차 상태 저장소
+type AuthFlowRepository struct {
+	mu    sync.Mutex
+	flows map[string]*models.AuthFlow
+}
+
+// NewAuthFlowRepository AuthFlowRepository 생성자
+func NewAuthFlowRepository() *AuthFlowRepository {
+	return &AuthFlowRepository{
+		flows: make(map[string]*models.AuthFlow),
+	}
+}
+
+// SaveFlow 인증 절차 상태 저장
+func (r *AuthFlowRepository) SaveFlow(ctx context.Context, flow *models.AuthFlow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 만료된 절차 정리
+	now := time.Now()
+	for id, existing := range r.flows {
+		if !existing.ExpiresAt.After(now) {
+			delete(r.flows, id)
+		}
+	}
+
+	if _, exists := r.flows[flow.ID]; exists {
+		return repository.ErrFlowExists
+	}
+
+	flow.CreatedAt = now
+	clone := *flow
+	clone.Data = append([]byte(nil), flow.Data...)
+	r.flows[flow.ID] = &clone
+	return nil
+}
+
+// ConsumeFlow 인증 절차 상태를 꺼내면서 삭제 (없거나 만료됐으면 nil)
+func (r *AuthFlowRepository) ConsumeFlow(ctx context.Context, id, kind string) (*models.AuthFlow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	flow, ok := r.flows[id]
+	if !ok || flow.Kind != kind || !flow.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+
+	delete(r.flows, id)
+	return flow, nil
+}
+
+// ReplaceFlow 같은 ID의 절차가 있으면 덮어쓰고 시도 횟수 초기화
+func (r *AuthFlowRepository) ReplaceFlow(ctx context.Context, flow *models.AuthFlow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	flow.CreatedAt = time.Now()
+	flow.Attempts = 0
+	clone := *flow
+	clone.Data = append([]byte(nil), flow.Data...)
+	r.flows[flow.ID] = &clone
+	return nil
+}
+
+// UseFlowAttempt 시도 횟수를 하나 올린 절차 반환 (없거나 만료됐거나 시도 횟수를 다 썼으면 nil)
+func (r *AuthFlowRepository) UseFlowAttempt(ctx context.Context, id, kind string, maxAttempts int) (*models.AuthFlow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	flow, ok := r.flows[id]
+	if !ok || flow.Kind != kind || !flow.ExpiresAt.After(time.Now()) || flow.Attempts >= maxAttempts {
+		return nil, nil
+	}
+
+	flow.Attempts++
+	clone := *flow
+	clone.Data = append([]byte(nil), flow.Data...)
+	return &clone, nil
+}
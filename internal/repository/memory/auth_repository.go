@@ -0,0 +1,466 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// AuthRepository 메모리 사용자 저장소 (개발/테스트용)
+// 저장된 값은 항상 복사해서 주고받으므로 호출자가 반환값을 수정해도 저장소에는 영향이 없다.
+type AuthRepository struct {
+	mu      sync.RWMutex
+	users   map[primitive.ObjectID]*models.User
+	byEmail map[string]primitive.ObjectID
+}
+
+// NewAuthRepository AuthRepository 생성자
+func NewAuthRepository() *AuthRepository {
+	return &AuthRepository{
+		users:   make(map[primitive.ObjectID]*models.User),
+		byEmail: make(map[string]primitive.ObjectID),
+	}
+}
+
+// CreateUser 새로운 사용자 생성
+func (r *AuthRepository) CreateUser(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byEmail[user.Email]; exists {
+		return errors.New("email already exists")
+	}
+
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.Status = models.UserStatusActive
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+
+	r.users[user.ID] = cloneUser(user)
+	r.byEmail[user.Email] = user.ID
+	return nil
+}
+
+// FindUserByEmail 이메일로 사용자 찾기
+func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil, nil
+	}
+	return cloneUser(r.users[id]), nil
+}
+
+// FindUserByID ID로 사용자 찾기
+func (r *AuthRepository) FindUserByID(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneUser(user), nil
+}
+
+// UpdateLastLogin 마지막 로그인 시간 업데이트
+func (r *AuthRepository) UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error {
+	return r.update(userID, func(user *models.User) {
+		now := time.Now()
+		user.LastLogin = &now
+	})
+}
+
+// SearchUsers 조건에 맞는 사용자 한 페이지 (최근 가입 순)와 전체 수
+func (r *AuthRepository) SearchUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	email := strings.ToLower(filter.Email)
+	matched := []*models.User{}
+	for _, user := range r.users {
+		if email != "" && !strings.Contains(strings.ToLower(user.Email), email) {
+			continue
+		}
+		if filter.Status != "" && user.AccountStatus() != filter.Status {
+			continue
+		}
+		if filter.CreatedAfter != nil && user.CreatedAt.Before(*filter.CreatedAfter) {
+			continue
+		}
+		if filter.CreatedBefore != nil && !user.CreatedAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+		matched = append(matched, user)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID.Hex() > matched[j].ID.Hex()
+	})
+
+	total := int64(len(matched))
+	users := []*models.User{}
+	for i := filter.Offset; i < len(matched) && len(users) < filter.Limit; i++ {
+		users = append(users, cloneUser(matched[i]))
+	}
+	return users, total, nil
+}
+
+// RevokeUserTokens 지정 시각 이전에 발급된 사용자의 모든 토큰 무효화
+func (r *AuthRepository) RevokeUserTokens(ctx context.Context, userID primitive.ObjectID, before time.Time) error {
+	return r.update(userID, func(user *models.User) {
+		user.TokensValidAfter = &before
+	})
+}
+
+// BumpTokenVersion 토큰 버전을 올려 기존에 발급된 모든 토큰 무효화
+func (r *AuthRepository) BumpTokenVersion(ctx context.Context, userID primitive.ObjectID) error {
+	return r.update(userID, func(user *models.User) {
+		user.TokenVersion++
+	})
+}
+
+// UpdateStatus from 상태인 사용자의 상태 변경 (active가 아니게 되면 토큰 버전을 올리고 메일 토큰 삭제)
+func (r *AuthRepository) UpdateStatus(ctx context.Context, userID primitive.ObjectID, from, to models.UserStatus, reason string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.AccountStatus() != from {
+		return nil, errors.New("user not found")
+	}
+
+	now := time.Now()
+	user.Status = to
+	user.StatusReason = reason
+	user.StatusChangedAt = &now
+	if to != models.UserStatusActive {
+		user.TokenVersion++
+		user.EmailVerifyTokenHash = ""
+		user.EmailVerifyExpiry = time.Time{}
+		user.ResetTokenHash = ""
+		user.ResetTokenExpiry = time.Time{}
+		user.MagicLinkTokenHash = ""
+		user.MagicLinkExpiry = time.Time{}
+	}
+	user.UpdatedAt = now
+	return cloneUser(user), nil
+}
+
+// SetRoles 역할 교체 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) SetRoles(ctx context.Context, userID primitive.ObjectID, roles []string) (*models.User, error) {
+	var updated *models.User
+	err := r.update(userID, func(user *models.User) {
+		user.Roles = append([]string(nil), roles...)
+		user.TokenVersion++
+		updated = cloneUser(user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// SetPermissions 직접 부여한 권한 교체 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) SetPermissions(ctx context.Context, userID primitive.ObjectID, permissions []string) (*models.User, error) {
+	var updated *models.User
+	err := r.update(userID, func(user *models.User) {
+		user.Permissions = append([]string(nil), permissions...)
+		user.TokenVersion++
+		updated = cloneUser(user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// UpdateEmailVerificationToken 이메일 인증 토큰 업데이트
+func (r *AuthRepository) UpdateEmailVerificationToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error {
+	return r.update(userID, func(user *models.User) {
+		user.EmailVerifyTokenHash = tokenHash
+		user.EmailVerifyExpiry = expiry
+	})
+}
+
+// VerifyEmail 이메일 인증 상태 업데이트
+func (r *AuthRepository) VerifyEmail(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	user := r.findLocked(func(u *models.User) bool {
+		return tokenHash != "" && u.EmailVerifyTokenHash == tokenHash && u.EmailVerifyExpiry.After(now)
+	})
+	if user == nil {
+		return errors.New("invalid or expired verification token")
+	}
+
+	user.EmailVerified = true
+	user.EmailVerifyTokenHash = ""
+	user.EmailVerifyExpiry = time.Time{}
+	user.UpdatedAt = now
+	return nil
+}
+
+// MarkEmailVerified 다른 방법(OTP, 매직 링크 등)으로 확인된 이메일을 인증 처리
+func (r *AuthRepository) MarkEmailVerified(ctx context.Context, userID primitive.ObjectID) error {
+	return r.update(userID, func(user *models.User) {
+		user.EmailVerified = true
+		user.EmailVerifyTokenHash = ""
+		user.EmailVerifyExpiry = time.Time{}
+	})
+}
+
+// UpdateResetToken 비밀번호 재설정 토큰 업데이트
+func (r *AuthRepository) UpdateResetToken(ctx context.Context, email string, tokenHash string, expiry time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byEmail[email]
+	if !ok {
+		return errors.New("user not found")
+	}
+
+	user := r.users[id]
+	user.ResetTokenHash = tokenHash
+	user.ResetTokenExpiry = expiry
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+// ResetPassword 비밀번호 재설정 (토큰 버전도 함께 올리고 로그인 잠금 해제)
+func (r *AuthRepository) ResetPassword(ctx context.Context, tokenHash, hashedPassword string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	user := r.findLocked(func(u *models.User) bool {
+		return tokenHash != "" && u.ResetTokenHash == tokenHash && u.ResetTokenExpiry.After(now)
+	})
+	if user == nil {
+		return nil, errors.New("invalid or expired reset token")
+	}
+
+	user.Password = hashedPassword
+	user.ResetTokenHash = ""
+	user.ResetTokenExpiry = time.Time{}
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+	user.TokenVersion++
+	user.UpdatedAt = now
+	return cloneUser(user), nil
+}
+
+// UpdatePassword 비밀번호 변경 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) UpdatePassword(ctx context.Context, userID primitive.ObjectID, hashedPassword string) (*models.User, error) {
+	var updated *models.User
+	err := r.update(userID, func(user *models.User) {
+		user.Password = hashedPassword
+		user.ResetTokenHash = ""
+		user.ResetTokenExpiry = time.Time{}
+		user.TokenVersion++
+		updated = cloneUser(user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// UpdateMagicLinkToken 매직 링크 토큰 업데이트
+func (r *AuthRepository) UpdateMagicLinkToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error {
+	return r.update(userID, func(user *models.User) {
+		user.MagicLinkTokenHash = tokenHash
+		user.MagicLinkExpiry = expiry
+	})
+}
+
+// ConsumeMagicLinkToken 매직 링크 토큰 사용 처리 (이메일 인증도 함께 처리)
+func (r *AuthRepository) ConsumeMagicLinkToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	user := r.findLocked(func(u *models.User) bool {
+		return tokenHash != "" && u.MagicLinkTokenHash == tokenHash && u.MagicLinkExpiry.After(now)
+	})
+	if user == nil {
+		return nil, errors.New("invalid or expired login link")
+	}
+
+	user.MagicLinkTokenHash = ""
+	user.MagicLinkExpiry = time.Time{}
+	user.EmailVerified = true
+	user.UpdatedAt = now
+	return cloneUser(user), nil
+}
+
+// SetTOTPSecret TOTP 등록 시작 (아직 활성화되지 않은 경우에만)
+func (r *AuthRepository) SetTOTPSecret(ctx context.Context, userID primitive.ObjectID, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.TOTPEnabled {
+		return errors.New("two-factor authentication is already enabled")
+	}
+
+	user.TOTPSecret = secret
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+// EnableTOTP TOTP 활성화 및 복구 코드 저장
+func (r *AuthRepository) EnableTOTP(ctx context.Context, userID primitive.ObjectID, lastStep int64, recoveryCodes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.TOTPSecret == "" || user.TOTPEnabled {
+		return errors.New("two-factor authentication enrollment not started")
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = lastStep
+	user.RecoveryCodes = append([]string(nil), recoveryCodes...)
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+// DisableTOTP TOTP 비활성화 및 비밀값/복구 코드 삭제
+func (r *AuthRepository) DisableTOTP(ctx context.Context, userID primitive.ObjectID) error {
+	return r.update(userID, func(user *models.User) {
+		user.TOTPEnabled = false
+		user.TOTPSecret = ""
+		user.TOTPLastStep = 0
+		user.RecoveryCodes = nil
+	})
+}
+
+// UseTOTPStep 시간 단계를 사용 처리 (이미 같은/이후 단계가 사용됐으면 false)
+func (r *AuthRepository) UseTOTPStep(ctx context.Context, userID primitive.ObjectID, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.TOTPLastStep >= step {
+		return false, nil
+	}
+
+	user.TOTPLastStep = step
+	return true, nil
+}
+
+// UseRecoveryCode 복구 코드 해시를 제거 (이미 사용된 코드면 false)
+func (r *AuthRepository) UseRecoveryCode(ctx context.Context, userID primitive.ObjectID, codeHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return false, nil
+	}
+
+	for i, hash := range user.RecoveryCodes {
+		if hash == codeHash {
+			user.RecoveryCodes = append(user.RecoveryCodes[:i:i], user.RecoveryCodes[i+1:]...)
+			user.UpdatedAt = time.Now()
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RecordFailedLogin 로그인 실패 횟수 증가 후 갱신된 사용자 반환
+func (r *AuthRepository) RecordFailedLogin(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	var updated *models.User
+	err := r.update(userID, func(user *models.User) {
+		user.FailedLoginAttempts++
+		updated = cloneUser(user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// LockUser 지정 시각까지 로그인 잠금
+func (r *AuthRepository) LockUser(ctx context.Context, userID primitive.ObjectID, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Mongo 구현과 같이 없는 사용자는 무시
+	if user, ok := r.users[userID]; ok {
+		user.LockedUntil = &until
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// ResetFailedLogins 로그인 실패 횟수 초기화 및 잠금 해제
+func (r *AuthRepository) ResetFailedLogins(ctx context.Context, userID primitive.ObjectID) error {
+	return r.update(userID, func(user *models.User) {
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
+	})
+}
+
+// update ID로 사용자를 찾아 수정 (없으면 "user not found")
+func (r *AuthRepository) update(userID primitive.ObjectID, fn func(user *models.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+
+	fn(user)
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+// findLocked 조건에 맞는 첫 사용자 (잠금을 잡은 상태에서 호출)
+func (r *AuthRepository) findLocked(match func(user *models.User) bool) *models.User {
+	for _, user := range r.users {
+		if match(user) {
+			return user
+		}
+	}
+	return nil
+}
+
+// cloneUser 사용자 깊은 복사
+func cloneUser(user *models.User) *models.User {
+	clone := *user
+	clone.LastLogin = cloneTime(user.LastLogin)
+	clone.TokensValidAfter = cloneTime(user.TokensValidAfter)
+	clone.LockedUntil = cloneTime(user.LockedUntil)
+	clone.StatusChangedAt = cloneTime(user.StatusChangedAt)
+	clone.RecoveryCodes = append([]string(nil), user.RecoveryCodes...)
+	clone.Roles = append([]string(nil), user.Roles...)
+	clone.Permissions = append([]string(nil), user.Permissions...)
+	return &clone
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// FederatedIdentityRepository 메모리 외부 로그인 계정 저장소
+type FederatedIdentityRepository struct {
+	mu         sync.RWMutex
+	identities map[string]*models.FederatedIdentity // provider + subject
+}
+
+// NewFederatedIdentityRepository FederatedIdentityRepository 생성자
+func NewFederatedIdentityRepository() *FederatedIdentityRepository {
+	return &FederatedIdentityRepository{
+		identities: make(map[string]*models.FederatedIdentity),
+	}
+}
+
+// CreateIdentity 외부 계정 연결 저장
+func (r *FederatedIdentityRepository) CreateIdentity(ctx context.Context, identity *models.FederatedIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := identityKey(identity.Provider, identity.Subject)
+	if _, exists := r.identities[key]; exists {
+		return errors.New("identity already linked")
+	}
+
+	identity.CreatedAt = time.Now()
+	if identity.ID.IsZero() {
+		identity.ID = primitive.NewObjectID()
+	}
+	clone := *identity
+	r.identities[key] = &clone
+	return nil
+}
+
+// FindIdentity 제공자와 subject로 연결 찾기
+func (r *FederatedIdentityRepository) FindIdentity(ctx context.Context, provider, subject string) (*models.FederatedIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	identity, ok := r.identities[identityKey(provider, subject)]
+	if !ok {
+		return nil, nil
+	}
+	clone := *identity
+	return &clone, nil
+}
+
+func identityKey(provider, subject string) string {
+	return provider + "\x00" + subject
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// OAuthClientRepository 메모리 OAuth 클라이언트 저장소
+type OAuthClientRepository struct {
+	mu      sync.RWMutex
+	clients map[primitive.ObjectID]*models.OAuthClient
+}
+
+// NewOAuthClientRepository OAuthClientRepository 생성자
+func NewOAuthClientRepository() *OAuthClientRepository {
+	return &OAuthClientRepository{
+		clients: make(map[primitive.ObjectID]*models.OAuthClient),
+	}
+}
+
+// CreateClient 클라이언트 저장
+func (r *OAuthClientRepository) CreateClient(ctx context.Context, client *models.OAuthClient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.clients {
+		if existing.ClientID == client.ClientID {
+			return errors.New("client already exists")
+		}
+	}
+
+	client.CreatedAt = time.Now()
+	if client.ID.IsZero() {
+		client.ID = primitive.NewObjectID()
+	}
+	r.clients[client.ID] = cloneOAuthClient(client)
+	return nil
+}
+
+// FindClientByClientID client_id로 클라이언트 찾기
+func (r *OAuthClientRepository) FindClientByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, client := range r.clients {
+		if client.ClientID == clientID {
+			return cloneOAuthClient(client), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListClientsByOwner 사용자가 등록한 클라이언트 목록 (등록 순)
+func (r *OAuthClientRepository) ListClientsByOwner(ctx context.Context, ownerID primitive.ObjectID) ([]*models.OAuthClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clients := []*models.OAuthClient{}
+	for _, client := range r.clients {
+		if client.OwnerID == ownerID {
+			clients = append(clients, cloneOAuthClient(client))
+		}
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].CreatedAt.Before(clients[j].CreatedAt)
+	})
+	return clients, nil
+}
+
+// DeleteClient 사용자의 클라이언트 삭제
+func (r *OAuthClientRepository) DeleteClient(ctx context.Context, ownerID, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, ok := r.clients[id]
+	if !ok || client.OwnerID != ownerID {
+		return errors.New("client not found")
+	}
+	delete(r.clients, id)
+	return nil
+}
+
+func cloneOAuthClient(client *models.OAuthClient) *models.OAuthClient {
+	clone := *client
+	clone.RedirectURIs = append([]string(nil), client.RedirectURIs...)
+	clone.Scopes = append([]string(nil), client.Scopes...)
+	return &clone
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// RefreshTokenRepository 메모리 리프레시 토큰 저장소
+type RefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[primitive.ObjectID]*models.RefreshToken
+	byHash map[string]primitive.ObjectID
+}
+
+// NewRefreshTokenRepository RefreshTokenRepository 생성자
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		tokens: make(map[primitive.ObjectID]*models.RefreshToken),
+		byHash: make(map[string]primitive.ObjectID),
+	}
+}
+
+// CreateRefreshToken 리프레시 토큰 저장
+func (r *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteExpired(time.Now())
+
+	if _, exists := r.byHash[token.TokenHash]; exists {
+		return errors.New("refresh token already exists")
+	}
+
+	token.CreatedAt = time.Now()
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+
+	clone := *token
+	r.tokens[token.ID] = &clone
+	r.byHash[token.TokenHash] = token.ID
+	return nil
+}
+
+// FindRefreshTokenByHash 토큰 해시로 리프레시 토큰 찾기
+func (r *RefreshTokenRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byHash[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	return cloneRefreshToken(r.tokens[id]), nil
+}
+
+// MarkRefreshTokenRotated 아직 사용되지 않은 토큰을 사용됨으로 표시
+func (r *RefreshTokenRepository) MarkRefreshTokenRotated(ctx context.Context, tokenID primitive.ObjectID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenID]
+	if !ok || token.RotatedAt != nil || token.RevokedAt != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	token.RotatedAt = &now
+	return true, nil
+}
+
+// RevokeRefreshTokenFamily 패밀리에 속한 모든 토큰 폐기
+func (r *RefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	r.revokeWhere(func(token *models.RefreshToken) bool {
+		return token.FamilyID == familyID
+	})
+	return nil
+}
+
+// RevokeUserRefreshTokens 사용자의 모든 리프레시 토큰 폐기
+func (r *RefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID primitive.ObjectID) error {
+	r.revokeWhere(func(token *models.RefreshToken) bool {
+		return token.UserID == userID
+	})
+	return nil
+}
+
+// RevokeClientRefreshTokens OAuth 클라이언트에 발급된 모든 리프레시 토큰 폐기
+func (r *RefreshTokenRepository) RevokeClientRefreshTokens(ctx context.Context, clientID string) error {
+	r.revokeWhere(func(token *models.RefreshToken) bool {
+		return token.ClientID == clientID
+	})
+	return nil
+}
+
+func (r *RefreshTokenRepository) revokeWhere(match func(token *models.RefreshToken) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.RevokedAt == nil && match(token) {
+			token.RevokedAt = &now
+		}
+	}
+}
+
+// deleteExpired 만료된 토큰 삭제 (Mongo TTL 인덱스와 같은 역할)
+func (r *RefreshTokenRepository) deleteExpired(now time.Time) {
+	for id, token := range r.tokens {
+		if !token.ExpiresAt.After(now) {
+			delete(r.tokens, id)
+			delete(r.byHash, token.TokenHash)
+		}
+	}
+}
+
+func cloneRefreshToken(token *models.RefreshToken) *models.RefreshToken {
+	clone := *token
+	clone.RotatedAt = cloneTime(token.RotatedAt)
+	clone.RevokedAt = cloneTime(token.RevokedAt)
+	return &clone
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// RevokedTokenRepository 메모리 jti 폐기 목록
+type RevokedTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]time.Time // jti -> 원래 토큰 만료 시각
+}
+
+// NewRevokedTokenRepository RevokedTokenRepository 생성자
+func NewRevokedTokenRepository() *RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		tokens: make(map[string]time.Time),
+	}
+}
+
+// RevokeToken 토큰을 폐기 목록에 추가 (이미 있으면 무시)
+func (r *RevokedTokenRepository) RevokeToken(ctx context.Context, token *models.RevokedToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 원래 토큰이 만료된 항목 정리
+	now := time.Now()
+	for jti, expiresAt := range r.tokens {
+		if !expiresAt.After(now) {
+			delete(r.tokens, jti)
+		}
+	}
+
+	token.RevokedAt = now
+	if _, exists := r.tokens[token.JTI]; !exists {
+		r.tokens[token.JTI] = token.ExpiresAt
+	}
+	return nil
+}
+
+// IsTokenRevoked 폐기된 토큰인지 확인
+func (r *RevokedTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.tokens[jti]
+	return ok, nil
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// ServiceClientRepository 메모리 서비스 클라이언트 저장소
+type ServiceClientRepository struct {
+	mu      sync.RWMutex
+	clients map[primitive.ObjectID]*models.ServiceClient
+}
+
+// NewServiceClientRepository ServiceClientRepository 생성자
+func NewServiceClientRepository() *ServiceClientRepository {
+	return &ServiceClientRepository{
+		clients: make(map[primitive.ObjectID]*models.ServiceClient),
+	}
+}
+
+// CreateServiceClient 클라이언트 저장
+func (r *ServiceClientRepository) CreateServiceClient(ctx context.Context, client *models.ServiceClient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.clients {
+		if existing.ClientID == client.ClientID {
+			return errors.New("client already exists")
+		}
+	}
+
+	client.CreatedAt = time.Now()
+	if client.ID.IsZero() {
+		client.ID = primitive.NewObjectID()
+	}
+	r.clients[client.ID] = cloneServiceClient(client)
+	return nil
+}
+
+// FindServiceClientByClientID client_id로 클라이언트 찾기
+func (r *ServiceClientRepository) FindServiceClientByClientID(ctx context.Context, clientID string) (*models.ServiceClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, client := range r.clients {
+		if client.ClientID == clientID {
+			return cloneServiceClient(client), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListServiceClients 전체 클라이언트 목록 (등록 순)
+func (r *ServiceClientRepository) ListServiceClients(ctx context.Context) ([]*models.ServiceClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clients := make([]*models.ServiceClient, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, cloneServiceClient(client))
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].CreatedAt.Before(clients[j].CreatedAt)
+	})
+	return clients, nil
+}
+
+// DeleteServiceClient 클라이언트 삭제
+func (r *ServiceClientRepository) DeleteServiceClient(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clients[id]; !ok {
+		return errors.New("client not found")
+	}
+	delete(r.clients, id)
+	return nil
+}
+
+func cloneServiceClient(client *models.ServiceClient) *models.ServiceClient {
+	clone := *client
+	clone.Scopes = append([]string(nil), client.Scopes...)
+	return &clone
+}
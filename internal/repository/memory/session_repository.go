@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// SessionRepository 메모리 세션 저장소
+type SessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]*models.Session
+}
+
+// NewSessionRepository SessionRepository 생성자
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions: make(map[string]*models.Session),
+	}
+}
+
+// CreateSession 세션 저장
+func (r *SessionRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteExpired(time.Now())
+
+	if _, exists := r.sessions[session.ID]; exists {
+		return errors.New("session already exists")
+	}
+
+	session.CreatedAt = time.Now()
+	r.sessions[session.ID] = cloneSession(session)
+	return nil
+}
+
+// FindSession ID로 세션 찾기
+func (r *SessionRepository) FindSession(ctx context.Context, id string) (*models.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok || !session.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	return cloneSession(session), nil
+}
+
+// ListActiveSessions 폐기되지 않고 만료되지 않은 세션 (최근 사용 순)
+func (r *SessionRepository) ListActiveSessions(ctx context.Context, userID primitive.ObjectID) ([]*models.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	sessions := []*models.Session{}
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			sessions = append(sessions, cloneSession(session))
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// TouchSession 토큰 갱신 시 마지막 사용 시각과 만료 시각 연장
+func (r *SessionRepository) TouchSession(ctx context.Context, id string, lastSeenAt, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return errors.New("session not found")
+	}
+	session.LastSeenAt = lastSeenAt
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+// RevokeSession 세션 폐기
+func (r *SessionRepository) RevokeSession(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if session, ok := r.sessions[id]; ok && session.RevokedAt == nil {
+		now := time.Now()
+		session.RevokedAt = &now
+	}
+	return nil
+}
+
+// RevokeUserSessions exceptID를 뺀 사용자의 모든 세션을 폐기하고 폐기한 세션 ID 반환
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID primitive.ObjectID, exceptID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	revoked := []string{}
+	for id, session := range r.sessions {
+		if session.UserID == userID && id != exceptID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			revoked = append(revoked, id)
+		}
+	}
+	return revoked, nil
+}
+
+// deleteExpired 만료된 세션 삭제 (Mongo TTL 인덱스와 같은 역할)
+func (r *SessionRepository) deleteExpired(now time.Time) {
+	for id, session := range r.sessions {
+		if !session.ExpiresAt.After(now) {
+			delete(r.sessions, id)
+		}
+	}
+}
+
+func cloneSession(session *models.Session) *models.Session {
+	clone := *session
+	clone.RevokedAt = cloneTime(session.RevokedAt)
+	return &clone
+}
@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+// NewStores 메모리 저장소 모음 생성 (프로세스가 끝나면 데이터도 사라진다)
+func NewStores() repository.Stores {
+	return repository.Stores{
+		Users:               NewAuthRepository(),
+		RefreshTokens:       NewRefreshTokenRepository(),
+		RevokedTokens:       NewRevokedTokenRepository(),
+		WebAuthnCredentials: NewWebAuthnCredentialRepository(),
+		AuthFlows:           NewAuthFlowRepository(),
+		OAuthClients:        NewOAuthClientRepository(),
+		ServiceClients:      NewServiceClientRepository(),
+		FederatedIdentities: NewFederatedIdentityRepository(),
+		Sessions:            NewSessionRepository(),
+		AuditLogs:           NewAuditLogRepository(),
+	}
+}
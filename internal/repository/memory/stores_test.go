@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/storetest"
+)
+
+func TestStores(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) repository.Stores {
+		return NewStores()
+	})
+}
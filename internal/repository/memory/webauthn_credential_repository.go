@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// WebAuthnCredentialRepository 메모리 패스키 저장소
+type WebAuthnCredentialRepository struct {
+	mu          sync.RWMutex
+	credentials map[primitive.ObjectID]*models.WebAuthnCredential
+}
+
+// NewWebAuthnCredentialRepository WebAuthnCredentialRepository 생성자
+func NewWebAuthnCredentialRepository() *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{
+		credentials: make(map[primitive.ObjectID]*models.WebAuthnCredential),
+	}
+}
+
+// CreateCredential 자격 증명 저장
+func (r *WebAuthnCredentialRepository) CreateCredential(ctx context.Context, credential *models.WebAuthnCredential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.credentials {
+		if string(existing.CredentialID) == string(credential.CredentialID) {
+			return errors.New("credential already registered")
+		}
+	}
+
+	credential.CreatedAt = time.Now()
+	if credential.ID.IsZero() {
+		credential.ID = primitive.NewObjectID()
+	}
+	r.credentials[credential.ID] = cloneCredential(credential)
+	return nil
+}
+
+// ListCredentialsByUser 사용자의 자격 증명 목록 (등록 순)
+func (r *WebAuthnCredentialRepository) ListCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*models.WebAuthnCredential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credentials := []*models.WebAuthnCredential{}
+	for _, credential := range r.credentials {
+		if credential.UserID == userID {
+			credentials = append(credentials, cloneCredential(credential))
+		}
+	}
+	sort.Slice(credentials, func(i, j int) bool {
+		return credentials[i].CreatedAt.Before(credentials[j].CreatedAt)
+	})
+	return credentials, nil
+}
+
+// CountCredentialsByUser 사용자의 자격 증명 수
+func (r *WebAuthnCredentialRepository) CountCredentialsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, credential := range r.credentials {
+		if credential.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// UpdateCredentialUsage 로그인 후 서명 카운터 등 갱신
+func (r *WebAuthnCredentialRepository) UpdateCredentialUsage(ctx context.Context, credential *models.WebAuthnCredential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.credentials[credential.ID]
+	if !ok {
+		return errors.New("credential not found")
+	}
+
+	now := time.Now()
+	stored.SignCount = credential.SignCount
+	stored.CloneWarning = credential.CloneWarning
+	stored.BackupState = credential.BackupState
+	stored.LastUsedAt = &now
+	credential.LastUsedAt = cloneTime(&now)
+	return nil
+}
+
+// DeleteCredential 사용자의 자격 증명 삭제
+func (r *WebAuthnCredentialRepository) DeleteCredential(ctx context.Context, userID, credentialID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	credential, ok := r.credentials[credentialID]
+	if !ok || credential.UserID != userID {
+		return errors.New("credential not found")
+	}
+	delete(r.credentials, credentialID)
+	return nil
+}
+
+func cloneCredential(credential *models.WebAuthnCredential) *models.WebAuthnCredential {
+	clone := *credential
+	clone.CredentialID = append([]byte(nil), credential.CredentialID...)
+	clone.PublicKey = append([]byte(nil), credential.PublicKey...)
+	clone.AAGUID = append([]byte(nil), credential.AAGUID...)
+	clone.Transports = append([]string(nil), credential.Transports...)
+	clone.LastUsedAt = cloneTime(credential.LastUsedAt)
+	return &clone
+}
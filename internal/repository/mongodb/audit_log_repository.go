@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type AuditLogRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditLogRepository AuditLogRepository 생성자
+func NewAuditLogRepository(db *mongo.Database) (*AuditLogRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("audit_logs")
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "target_user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLogRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateAuditLog 기록 추가
+func (r *AuditLogRepository) CreateAuditLog(ctx context.Context, entry *models.AuditLog) error {
+	entry.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = oid
+	}
+	return nil
+}
+
+// ListAuditLogsByTarget 대상 사용자에 대한 기록 (최신 순, 최대 limit개)
+func (r *AuditLogRepository) ListAuditLogsByTarget(ctx context.Context, targetUserID primitive.ObjectID, limit int) ([]*models.AuditLog, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"target_user_id": targetUserID},
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.AuditLog{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
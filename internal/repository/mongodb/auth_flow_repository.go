@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+type AuthFlowRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuthFlowRepository AuthFlowRepository 생성자
+func NewAuthFlowRepository(db *mongo.Database) (*AuthFlowRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("auth_flows")
+
+	// 만료된 절차 자동 삭제
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthFlowRepository{
+		collection: collection,
+	}, nil
+}
+
+// SaveFlow 인증 절차 상태 저장
+// TTL 인덱스는 만료된 문서를 1분 가까이 늦게 지우므로 같은 ID의 만료된 절차는 덮어쓴다.
+// 만료되지 않은 절차가 있으면 필터가 맞지 않아 upsert가 같은 _id로 삽입하려다 중복 키 에러가 난다.
+func (r *AuthFlowRepository) SaveFlow(ctx context.Context, flow *models.AuthFlow) error {
+	flow.CreatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.M{"_id": flow.ID, "expires_at": bson.M{"$lte": flow.CreatedAt}},
+		flow,
+		options.Replace().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrFlowExists
+	}
+	return err
+}
+
+// ConsumeFlow 인증 절차 상태를 꺼내면서 삭제 (없거나 만료됐으면 nil)
+func (r *AuthFlowRepository) ConsumeFlow(ctx context.Context, id, kind string) (*models.AuthFlow, error) {
+	var flow models.AuthFlow
+	err := r.collection.FindOneAndDelete(ctx, bson.M{
+		"_id":        id,
+		"kind":       kind,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}).Decode(&flow)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &flow, nil
+}
+
+// ReplaceFlow 같은 ID의 절차가 있으면 덮어쓰고 시도 횟수 초기화
+func (r *AuthFlowRepository) ReplaceFlow(ctx context.Context, flow *models.AuthFlow) error {
+	flow.CreatedAt = time.Now()
+	flow.Attempts = 0
+
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.M{"_id": flow.ID},
+		flow,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// UseFlowAttempt 시도 횟수를 하나 올린 절차 반환 (없거나 만료됐거나 시도 횟수를 다 썼으면 nil)
+func (r *AuthFlowRepository) UseFlowAttempt(ctx context.Context, id, kind string, maxAttempts int) (*models.AuthFlow, error) {
+	var flow models.AuthFlow
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"_id":        id,
+			"kind":       kind,
+			"expires_at": bson.M{"$gt": time.Now()},
+			"attempts":   bson.M{"$lt": maxAttempts},
+		},
+		bson.M{"$inc": bson.M{"attempts": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&flow)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &flow, nil
+}
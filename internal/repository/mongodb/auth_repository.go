@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,8 +19,8 @@ type AuthRepository struct {
 	collection *mongo.Collection
 }
 
-// NewAuthRepository AuthRepository 생성자
-func NewAuthRepository(mongoURI string) (*AuthRepository, error) {
+// NewAuthRepository AuthRepository 생성자 (database: 사용할 DB 이름)
+func NewAuthRepository(mongoURI, database string) (*AuthRepository, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -34,7 +35,7 @@ func NewAuthRepository(mongoURI string) (*AuthRepository, error) {
 		return nil, err
 	}
 
-	db := client.Database("prisma_market")
+	db := client.Database(database)
 	collection := db.Collection("auth_data")
 
 	// 이메일 unique 인덱스 생성
@@ -46,6 +47,26 @@ func NewAuthRepository(mongoURI string) (*AuthRepository, error) {
 		return nil, err
 	}
 
+	// 관리자 사용자 검색 (최근 가입 순)
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 이전 버전이 평문으로 저장한 재설정/인증 토큰 제거 (해시로만 조회하므로 더 이상 쓰이지 않음)
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"$or": bson.A{
+			bson.M{"reset_token": bson.M{"$exists": true}},
+			bson.M{"email_verify_token": bson.M{"$exists": true}},
+		}},
+		bson.M{"$unset": bson.M{"reset_token": "", "email_verify_token": ""}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AuthRepository{
 		db:         db,
 		collection: collection,
@@ -56,7 +77,7 @@ func NewAuthRepository(mongoURI string) (*AuthRepository, error) {
 func (r *AuthRepository) CreateUser(ctx context.Context, user *models.User) error {
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
-	user.Status = "active"
+	user.Status = models.UserStatusActive
 
 	result, err := r.collection.InsertOne(ctx, user)
 	if err != nil {
@@ -86,6 +107,19 @@ func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*mo
 	return &user, nil
 }
 
+// FindUserByID ID로 사용자 찾기
+func (r *AuthRepository) FindUserByID(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // UpdateLastLogin 마지막 로그인 시간 업데이트
 func (r *AuthRepository) UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error {
 	now := time.Now()
@@ -100,13 +134,170 @@ func (r *AuthRepository) UpdateLastLogin(ctx context.Context, userID primitive.O
 	return err
 }
 
+// SearchUsers 조건에 맞는 사용자 한 페이지 (최근 가입 순)와 전체 수
+func (r *AuthRepository) SearchUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int64, error) {
+	query := bson.M{}
+	if filter.Email != "" {
+		query["email"] = primitive.Regex{Pattern: regexp.QuoteMeta(filter.Email), Options: "i"}
+	}
+	if filter.Status == models.UserStatusActive {
+		// 상태가 저장되지 않은 이전 계정은 active로 본다
+		query["status"] = bson.M{"$in": bson.A{filter.Status, "", nil}}
+	} else if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	created := bson.M{}
+	if filter.CreatedAfter != nil {
+		created["$gte"] = *filter.CreatedAfter
+	}
+	if filter.CreatedBefore != nil {
+		created["$lt"] = *filter.CreatedBefore
+	}
+	if len(created) > 0 {
+		query["created_at"] = created
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := r.collection.Find(ctx, query,
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+			SetSkip(int64(filter.Offset)).
+			SetLimit(int64(filter.Limit)),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := []*models.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// RevokeUserTokens 지정 시각 이전에 발급된 사용자의 모든 토큰 무효화
+func (r *AuthRepository) RevokeUserTokens(ctx context.Context, userID primitive.ObjectID, before time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"tokens_valid_after": before,
+			"updated_at":         time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// BumpTokenVersion 토큰 버전을 올려 기존에 발급된 모든 토큰 무효화
+func (r *AuthRepository) BumpTokenVersion(ctx context.Context, userID primitive.ObjectID) error {
+	update := bson.M{
+		"$inc": bson.M{"token_version": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// UpdateStatus from 상태인 사용자의 상태 변경 (active가 아니게 되면 토큰 버전을 올리고 메일 토큰 삭제)
+func (r *AuthRepository) UpdateStatus(ctx context.Context, userID primitive.ObjectID, from, to models.UserStatus, reason string) (*models.User, error) {
+	now := time.Now()
+	set := bson.M{
+		"status":            to,
+		"status_reason":     reason,
+		"status_changed_at": now,
+		"updated_at":        now,
+	}
+	update := bson.M{"$set": set}
+	if to != models.UserStatusActive {
+		set["email_verify_token_hash"] = nil
+		set["email_verify_expiry"] = nil
+		set["reset_token_hash"] = nil
+		set["reset_token_expiry"] = nil
+		set["magic_link_token_hash"] = nil
+		set["magic_link_expiry"] = nil
+		update["$inc"] = bson.M{"token_version": 1}
+	}
+
+	// 상태가 저장되지 않은 이전 계정은 active로 본다
+	statusFilter := bson.M{"$in": bson.A{from}}
+	if from == models.UserStatusActive {
+		statusFilter = bson.M{"$in": bson.A{from, "", nil}}
+	}
+
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": userID, "status": statusFilter},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetRoles 역할 교체 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) SetRoles(ctx context.Context, userID primitive.ObjectID, roles []string) (*models.User, error) {
+	return r.updateAccess(ctx, userID, "roles", roles)
+}
+
+// SetPermissions 직접 부여한 권한 교체 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) SetPermissions(ctx context.Context, userID primitive.ObjectID, permissions []string) (*models.User, error) {
+	return r.updateAccess(ctx, userID, "permissions", permissions)
+}
+
+// updateAccess 역할 또는 권한 필드를 교체하고 토큰 버전을 올린 사용자 반환
+func (r *AuthRepository) updateAccess(ctx context.Context, userID primitive.ObjectID, field string, values []string) (*models.User, error) {
+	update := bson.M{
+		"$set": bson.M{
+			field:        values,
+			"updated_at": time.Now(),
+		},
+		"$inc": bson.M{"token_version": 1},
+	}
+
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": userID},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // 이메일 인증 토큰 업데이트
-func (r *AuthRepository) UpdateEmailVerificationToken(ctx context.Context, userID primitive.ObjectID, token string, expiry time.Time) error {
+func (r *AuthRepository) UpdateEmailVerificationToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error {
 	update := bson.M{
 		"$set": bson.M{
-			"email_verify_token":  token,
-			"email_verify_expiry": expiry,
-			"updated_at":          time.Now(),
+			"email_verify_token_hash": tokenHash,
+			"email_verify_expiry":     expiry,
+			"updated_at":              time.Now(),
 		},
 	}
 
@@ -121,20 +312,20 @@ func (r *AuthRepository) UpdateEmailVerificationToken(ctx context.Context, userI
 }
 
 // 이메일 인증 상태 업데이트
-func (r *AuthRepository) VerifyEmail(ctx context.Context, token string) error {
+func (r *AuthRepository) VerifyEmail(ctx context.Context, tokenHash string) error {
 	update := bson.M{
 		"$set": bson.M{
-			"email_verified":      true,
-			"email_verify_token":  nil,
-			"email_verify_expiry": nil,
-			"updated_at":          time.Now(),
+			"email_verified":          true,
+			"email_verify_token_hash": nil,
+			"email_verify_expiry":     nil,
+			"updated_at":              time.Now(),
 		},
 	}
 
 	result, err := r.collection.UpdateOne(ctx,
 		bson.M{
-			"email_verify_token":  token,
-			"email_verify_expiry": bson.M{"$gt": time.Now()},
+			"email_verify_token_hash": tokenHash,
+			"email_verify_expiry":     bson.M{"$gt": time.Now()},
 		},
 		update,
 	)
@@ -147,11 +338,32 @@ func (r *AuthRepository) VerifyEmail(ctx context.Context, token string) error {
 	return nil
 }
 
+// MarkEmailVerified 다른 방법(OTP, 매직 링크 등)으로 확인된 이메일을 인증 처리
+func (r *AuthRepository) MarkEmailVerified(ctx context.Context, userID primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"email_verified":          true,
+			"email_verify_token_hash": nil,
+			"email_verify_expiry":     nil,
+			"updated_at":              time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 // 비밀번호 재설정 토큰 업데이트
-func (r *AuthRepository) UpdateResetToken(ctx context.Context, email string, token string, expiry time.Time) error {
+func (r *AuthRepository) UpdateResetToken(ctx context.Context, email string, tokenHash string, expiry time.Time) error {
 	update := bson.M{
 		"$set": bson.M{
-			"reset_token":        token,
+			"reset_token_hash":   tokenHash,
 			"reset_token_expiry": expiry,
 			"updated_at":         time.Now(),
 		},
@@ -167,21 +379,155 @@ func (r *AuthRepository) UpdateResetToken(ctx context.Context, email string, tok
 	return nil
 }
 
-// 비밀번호 재설정
-func (r *AuthRepository) ResetPassword(ctx context.Context, token, hashedPassword string) error {
+// 비밀번호 재설정 (토큰 버전도 함께 올리고, 메일로 본인 확인이 됐으므로 로그인 잠금도 해제)
+func (r *AuthRepository) ResetPassword(ctx context.Context, tokenHash, hashedPassword string) (*models.User, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"password":              hashedPassword,
+			"reset_token_hash":      nil,
+			"reset_token_expiry":    nil,
+			"failed_login_attempts": 0,
+			"updated_at":            time.Now(),
+		},
+		"$unset": bson.M{"locked_until": ""},
+		"$inc":   bson.M{"token_version": 1},
+	}
+
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"reset_token_hash":   tokenHash,
+			"reset_token_expiry": bson.M{"$gt": time.Now()},
+		},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("invalid or expired reset token")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdatePassword 비밀번호 변경 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) UpdatePassword(ctx context.Context, userID primitive.ObjectID, hashedPassword string) (*models.User, error) {
 	update := bson.M{
 		"$set": bson.M{
 			"password":           hashedPassword,
-			"reset_token":        nil,
+			"reset_token_hash":   nil,
 			"reset_token_expiry": nil,
 			"updated_at":         time.Now(),
 		},
+		"$inc": bson.M{"token_version": 1},
+	}
+
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": userID},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateMagicLinkToken 매직 링크 토큰 업데이트
+func (r *AuthRepository) UpdateMagicLinkToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"magic_link_token_hash": tokenHash,
+			"magic_link_expiry":     expiry,
+			"updated_at":            time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// ConsumeMagicLinkToken 매직 링크 토큰 사용 처리 (링크를 받았으니 이메일 인증도 함께 처리)
+func (r *AuthRepository) ConsumeMagicLinkToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"magic_link_token_hash": nil,
+			"magic_link_expiry":     nil,
+			"email_verified":        true,
+			"updated_at":            time.Now(),
+		},
+	}
+
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"magic_link_token_hash": tokenHash,
+			"magic_link_expiry":     bson.M{"$gt": time.Now()},
+		},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("invalid or expired login link")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetTOTPSecret TOTP 등록 시작 (아직 활성화되지 않은 경우에만)
+func (r *AuthRepository) SetTOTPSecret(ctx context.Context, userID primitive.ObjectID, secret string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"totp_secret": secret,
+			"updated_at":  time.Now(),
+		},
 	}
 
 	result, err := r.collection.UpdateOne(ctx,
 		bson.M{
-			"reset_token":        token,
-			"reset_token_expiry": bson.M{"$gt": time.Now()},
+			"_id":          userID,
+			"totp_enabled": bson.M{"$ne": true},
+		},
+		update,
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("two-factor authentication is already enabled")
+	}
+	return nil
+}
+
+// EnableTOTP TOTP 활성화 및 복구 코드 저장
+func (r *AuthRepository) EnableTOTP(ctx context.Context, userID primitive.ObjectID, lastStep int64, recoveryCodes []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"totp_enabled":   true,
+			"totp_last_step": lastStep,
+			"recovery_codes": recoveryCodes,
+			"updated_at":     time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":          userID,
+			"totp_secret":  bson.M{"$exists": true, "$ne": ""},
+			"totp_enabled": bson.M{"$ne": true},
 		},
 		update,
 	)
@@ -189,11 +535,131 @@ func (r *AuthRepository) ResetPassword(ctx context.Context, token, hashedPasswor
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return errors.New("invalid or expired reset token")
+		return errors.New("two-factor authentication enrollment not started")
+	}
+	return nil
+}
+
+// DisableTOTP TOTP 비활성화 및 비밀값/복구 코드 삭제
+func (r *AuthRepository) DisableTOTP(ctx context.Context, userID primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"totp_enabled": false,
+			"updated_at":   time.Now(),
+		},
+		"$unset": bson.M{
+			"totp_secret":    "",
+			"totp_last_step": "",
+			"recovery_codes": "",
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// UseTOTPStep 시간 단계를 사용 처리 (이미 같은/이후 단계가 사용됐으면 false)
+func (r *AuthRepository) UseTOTPStep(ctx context.Context, userID primitive.ObjectID, step int64) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id": userID,
+			"$or": bson.A{
+				bson.M{"totp_last_step": bson.M{"$exists": false}},
+				bson.M{"totp_last_step": bson.M{"$lt": step}},
+			},
+		},
+		bson.M{
+			"$set": bson.M{"totp_last_step": step},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// UseRecoveryCode 복구 코드 해시를 제거 (이미 사용된 코드면 false)
+func (r *AuthRepository) UseRecoveryCode(ctx context.Context, userID primitive.ObjectID, codeHash string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":            userID,
+			"recovery_codes": codeHash,
+		},
+		bson.M{
+			"$pull": bson.M{"recovery_codes": codeHash},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// RecordFailedLogin 로그인 실패 횟수 증가 후 갱신된 사용자 반환
+func (r *AuthRepository) RecordFailedLogin(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": userID},
+		bson.M{
+			"$inc": bson.M{"failed_login_attempts": 1},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// LockUser 지정 시각까지 로그인 잠금
+func (r *AuthRepository) LockUser(ctx context.Context, userID primitive.ObjectID, until time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"locked_until": until,
+			"updated_at":   time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateByID(ctx, userID, update)
+	return err
+}
+
+// ResetFailedLogins 로그인 실패 횟수 초기화 및 잠금 해제
+func (r *AuthRepository) ResetFailedLogins(ctx context.Context, userID primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"failed_login_attempts": 0,
+			"updated_at":            time.Now(),
+		},
+		"$unset": bson.M{"locked_until": ""},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
 	}
 	return nil
 }
 
+// Database 같은 연결을 공유하는 다른 저장소 생성용
+func (r *AuthRepository) Database() *mongo.Database {
+	return r.db
+}
+
 // Close MongoDB 연결 종료
 func (r *AuthRepository) Close(ctx context.Context) error {
 	return r.db.Client().Disconnect(ctx)
@@ -0,0 +1,75 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type FederatedIdentityRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFederatedIdentityRepository FederatedIdentityRepository 생성자
+func NewFederatedIdentityRepository(db *mongo.Database) (*FederatedIdentityRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("federated_identities")
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// 제공자 계정 하나는 한 사용자에만 연결
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "subject", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FederatedIdentityRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateIdentity 외부 계정 연결 저장
+func (r *FederatedIdentityRepository) CreateIdentity(ctx context.Context, identity *models.FederatedIdentity) error {
+	identity.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, identity)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("identity already linked")
+		}
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		identity.ID = oid
+	}
+	return nil
+}
+
+// FindIdentity 제공자와 subject로 연결 찾기
+func (r *FederatedIdentityRepository) FindIdentity(ctx context.Context, provider, subject string) (*models.FederatedIdentity, error) {
+	var identity models.FederatedIdentity
+	err := r.collection.FindOne(ctx, bson.M{"provider": provider, "subject": subject}).Decode(&identity)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
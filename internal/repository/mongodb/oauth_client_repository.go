@@ -0,0 +1,107 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type OAuthClientRepository struct {
+	collection *mongo.Collection
+}
+
+// NewOAuthClientRepository OAuthClientRepository 생성자
+func NewOAuthClientRepository(db *mongo.Database) (*OAuthClientRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("oauth_clients")
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// client_id unique 인덱스
+		{
+			Keys:    bson.D{{Key: "client_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "owner_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuthClientRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateClient 클라이언트 저장
+func (r *OAuthClientRepository) CreateClient(ctx context.Context, client *models.OAuthClient) error {
+	client.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, client)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("client already exists")
+		}
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		client.ID = oid
+	}
+	return nil
+}
+
+// FindClientByClientID client_id로 클라이언트 찾기
+func (r *OAuthClientRepository) FindClientByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	var client models.OAuthClient
+	err := r.collection.FindOne(ctx, bson.M{"client_id": clientID}).Decode(&client)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+// ListClientsByOwner 사용자가 등록한 클라이언트 목록
+func (r *OAuthClientRepository) ListClientsByOwner(ctx context.Context, ownerID primitive.ObjectID) ([]*models.OAuthClient, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"owner_id": ownerID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := []*models.OAuthClient{}
+	if err := cursor.All(ctx, &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// DeleteClient 사용자의 클라이언트 삭제
+func (r *OAuthClientRepository) DeleteClient(ctx context.Context, ownerID, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{
+		"_id":      id,
+		"owner_id": ownerID,
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("client not found")
+	}
+	return nil
+}
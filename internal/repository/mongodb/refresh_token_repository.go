@@ -0,0 +1,148 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type RefreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRefreshTokenRepository RefreshTokenRepository 생성자
+func NewRefreshTokenRepository(db *mongo.Database) (*RefreshTokenRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("refresh_tokens")
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// 토큰 해시 unique 인덱스
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// 패밀리 단위 폐기용
+		{
+			Keys: bson.D{{Key: "family_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+		},
+		// OAuth 클라이언트 삭제 시 폐기용
+		{
+			Keys:    bson.D{{Key: "client_id", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+		// 만료된 토큰 자동 삭제
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshTokenRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateRefreshToken 리프레시 토큰 저장
+func (r *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	token.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		token.ID = oid
+	}
+
+	return nil
+}
+
+// FindRefreshTokenByHash 토큰 해시로 리프레시 토큰 찾기
+func (r *RefreshTokenRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenRotated 아직 사용되지 않은 토큰을 사용됨으로 표시
+// 동시에 같은 토큰으로 요청이 들어와도 한 번만 성공한다.
+func (r *RefreshTokenRepository) MarkRefreshTokenRotated(ctx context.Context, tokenID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":        tokenID,
+			"rotated_at": nil,
+			"revoked_at": nil,
+		},
+		bson.M{
+			"$set": bson.M{"rotated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// RevokeRefreshTokenFamily 패밀리에 속한 모든 토큰 폐기
+func (r *RefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{
+			"family_id":  familyID,
+			"revoked_at": nil,
+		},
+		bson.M{
+			"$set": bson.M{"revoked_at": time.Now()},
+		},
+	)
+	return err
+}
+
+// RevokeUserRefreshTokens 사용자의 모든 리프레시 토큰 폐기
+func (r *RefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{
+			"user_id":    userID,
+			"revoked_at": nil,
+		},
+		bson.M{
+			"$set": bson.M{"revoked_at": time.Now()},
+		},
+	)
+	return err
+}
+
+// RevokeClientRefreshTokens OAuth 클라이언트에 발급된 모든 리프레시 토큰 폐기
+func (r *RefreshTokenRepository) RevokeClientRefreshTokens(ctx context.Context, clientID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{
+			"client_id":  clientID,
+			"revoked_at": nil,
+		},
+		bson.M{
+			"$set": bson.M{"revoked_at": time.Now()},
+		},
+	)
+	return err
+}
@@ -0,0 +1,57 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type RevokedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRevokedTokenRepository RevokedTokenRepository 생성자
+func NewRevokedTokenRepository(db *mongo.Database) (*RevokedTokenRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("revoked_tokens")
+
+	// 원래 토큰이 만료되면 자동 삭제
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevokedTokenRepository{
+		collection: collection,
+	}, nil
+}
+
+// RevokeToken 토큰을 폐기 목록에 추가 (이미 있으면 무시)
+func (r *RevokedTokenRepository) RevokeToken(ctx context.Context, token *models.RevokedToken) error {
+	token.RevokedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, token)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// IsTokenRevoked 폐기된 토큰인지 확인
+func (r *RevokedTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": jti}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
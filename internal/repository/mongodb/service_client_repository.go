@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type ServiceClientRepository struct {
+	collection *mongo.Collection
+}
+
+// NewServiceClientRepository ServiceClientRepository 생성자
+func NewServiceClientRepository(db *mongo.Database) (*ServiceClientRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("service_clients")
+
+	// client_id unique 인덱스
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "client_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceClientRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateServiceClient 클라이언트 저장
+func (r *ServiceClientRepository) CreateServiceClient(ctx context.Context, client *models.ServiceClient) error {
+	client.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, client)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("client already exists")
+		}
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		client.ID = oid
+	}
+	return nil
+}
+
+// FindServiceClientByClientID client_id로 클라이언트 찾기
+func (r *ServiceClientRepository) FindServiceClientByClientID(ctx context.Context, clientID string) (*models.ServiceClient, error) {
+	var client models.ServiceClient
+	err := r.collection.FindOne(ctx, bson.M{"client_id": clientID}).Decode(&client)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+// ListServiceClients 전체 클라이언트 목록
+func (r *ServiceClientRepository) ListServiceClients(ctx context.Context) ([]*models.ServiceClient, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := []*models.ServiceClient{}
+	if err := cursor.All(ctx, &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// DeleteServiceClient 클라이언트 삭제
+func (r *ServiceClientRepository) DeleteServiceClient(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("client not found")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type SessionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSessionRepository SessionRepository 생성자
+func NewSessionRepository(db *mongo.Database) (*SessionRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("sessions")
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}},
+		},
+		// 만료된 세션 자동 삭제
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateSession 세션 저장
+func (r *SessionRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	session.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, session)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.New("session already exists")
+	}
+	return err
+}
+
+// FindSession ID로 세션 찾기
+func (r *SessionRepository) FindSession(ctx context.Context, id string) (*models.Session, error) {
+	var session models.Session
+	err := r.collection.FindOne(ctx, bson.M{
+		"_id":        id,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}).Decode(&session)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActiveSessions 폐기되지 않고 만료되지 않은 세션 (최근 사용 순)
+func (r *SessionRepository) ListActiveSessions(ctx context.Context, userID primitive.ObjectID) ([]*models.Session, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{
+			"user_id":    userID,
+			"revoked_at": nil,
+			"expires_at": bson.M{"$gt": time.Now()},
+		},
+		options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []*models.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TouchSession 토큰 갱신 시 마지막 사용 시각과 만료 시각 연장
+func (r *SessionRepository) TouchSession(ctx context.Context, id string, lastSeenAt, expiresAt time.Time) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"last_seen_at": lastSeenAt,
+				"expires_at":   expiresAt,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
+// RevokeSession 세션 폐기
+func (r *SessionRepository) RevokeSession(ctx context.Context, id string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":        id,
+			"revoked_at": nil,
+		},
+		bson.M{
+			"$set": bson.M{"revoked_at": time.Now()},
+		},
+	)
+	return err
+}
+
+// RevokeUserSessions exceptID를 뺀 사용자의 모든 세션을 폐기하고 폐기한 세션 ID 반환
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID primitive.ObjectID, exceptID string) ([]string, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": nil,
+		"_id":        bson.M{"$ne": exceptID},
+	}
+
+	ids, err := r.collection.Distinct(ctx, "_id", filter)
+	if err != nil {
+		return nil, err
+	}
+	revoked := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if s, ok := id.(string); ok {
+			revoked = append(revoked, s)
+		}
+	}
+	if len(revoked) == 0 {
+		return revoked, nil
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": revoked}, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
@@ -0,0 +1,47 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type SigningKeyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSigningKeyRepository SigningKeyRepository 생성자
+func NewSigningKeyRepository(db *mongo.Database) *SigningKeyRepository {
+	return &SigningKeyRepository{
+		collection: db.Collection("signing_keys"),
+	}
+}
+
+// ListKeys 저장된 모든 서명 키 조회
+func (r *SigningKeyRepository) ListKeys(ctx context.Context) ([]*models.SigningKeyRecord, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*models.SigningKeyRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SaveKey 서명 키 저장
+func (r *SigningKeyRepository) SaveKey(ctx context.Context, key *models.SigningKeyRecord) error {
+	_, err := r.collection.InsertOne(ctx, key)
+	return err
+}
+
+// DeleteKey 서명 키 삭제
+func (r *SigningKeyRepository) DeleteKey(ctx context.Context, kid string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": kid})
+	return err
+}
@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/storetest"
+)
+
+// MONGO_TEST_URI 테스트에 사용할 서버 (비어 있으면 건너뜀)
+// 실행마다 prisma_market_test_<랜덤> DB를 만들고 끝나면 삭제한다.
+func TestStores(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	users, err := NewAuthRepository(uri, "prisma_market_test_"+primitive.NewObjectID().Hex())
+	if err != nil {
+		t.Fatalf("connect mongo: %v", err)
+	}
+	db := users.Database()
+	t.Cleanup(func() {
+		ctx := context.Background()
+		if err := db.Drop(ctx); err != nil {
+			t.Errorf("drop test database %s: %v", db.Name(), err)
+		}
+		db.Client().Disconnect(ctx)
+	})
+
+	refreshTokens, err := NewRefreshTokenRepository(db)
+	if err != nil {
+		t.Fatalf("refresh tokens: %v", err)
+	}
+	revokedTokens, err := NewRevokedTokenRepository(db)
+	if err != nil {
+		t.Fatalf("revoked tokens: %v", err)
+	}
+	credentials, err := NewWebAuthnCredentialRepository(db)
+	if err != nil {
+		t.Fatalf("webauthn credentials: %v", err)
+	}
+	flows, err := NewAuthFlowRepository(db)
+	if err != nil {
+		t.Fatalf("auth flows: %v", err)
+	}
+	oauthClients, err := NewOAuthClientRepository(db)
+	if err != nil {
+		t.Fatalf("oauth clients: %v", err)
+	}
+	serviceClients, err := NewServiceClientRepository(db)
+	if err != nil {
+		t.Fatalf("service clients: %v", err)
+	}
+	identities, err := NewFederatedIdentityRepository(db)
+	if err != nil {
+		t.Fatalf("federated identities: %v", err)
+	}
+	sessions, err := NewSessionRepository(db)
+	if err != nil {
+		t.Fatalf("sessions: %v", err)
+	}
+	auditLogs, err := NewAuditLogRepository(db)
+	if err != nil {
+		t.Fatalf("audit logs: %v", err)
+	}
+
+	stores := repository.Stores{
+		Users:               users,
+		RefreshTokens:       refreshTokens,
+		RevokedTokens:       revokedTokens,
+		WebAuthnCredentials: credentials,
+		AuthFlows:           flows,
+		OAuthClients:        oauthClients,
+		ServiceClients:      serviceClients,
+		FederatedIdentities: identities,
+		Sessions:            sessions,
+		AuditLogs:           auditLogs,
+	}
+	storetest.Run(t, func(t *testing.T) repository.Stores {
+		return stores
+	})
+}
@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type WebAuthnCredentialRepository struct {
+	collection *mongo.Collection
+}
+
+// NewWebAuthnCredentialRepository WebAuthnCredentialRepository 생성자
+func NewWebAuthnCredentialRepository(db *mongo.Database) (*WebAuthnCredentialRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("webauthn_credentials")
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// 자격 증명 ID unique 인덱스
+		{
+			Keys:    bson.D{{Key: "credential_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnCredentialRepository{
+		collection: collection,
+	}, nil
+}
+
+// CreateCredential 자격 증명 저장
+func (r *WebAuthnCredentialRepository) CreateCredential(ctx context.Context, credential *models.WebAuthnCredential) error {
+	credential.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, credential)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("credential already registered")
+		}
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		credential.ID = oid
+	}
+	return nil
+}
+
+// ListCredentialsByUser 사용자의 자격 증명 목록
+func (r *WebAuthnCredentialRepository) ListCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*models.WebAuthnCredential, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := []*models.WebAuthnCredential{}
+	if err := cursor.All(ctx, &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// CountCredentialsByUser 사용자의 자격 증명 수
+func (r *WebAuthnCredentialRepository) CountCredentialsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+// UpdateCredentialUsage 로그인 후 서명 카운터 등 갱신
+func (r *WebAuthnCredentialRepository) UpdateCredentialUsage(ctx context.Context, credential *models.WebAuthnCredential) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"sign_count":    credential.SignCount,
+			"clone_warning": credential.CloneWarning,
+			"backup_state":  credential.BackupState,
+			"last_used_at":  now,
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, credential.ID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("credential not found")
+	}
+	credential.LastUsedAt = &now
+	return nil
+}
+
+// DeleteCredential 사용자의 자격 증명 삭제
+func (r *WebAuthnCredentialRepository) DeleteCredential(ctx context.Context, userID, credentialID primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{
+		"_id":     credentialID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("credential not found")
+	}
+	return nil
+}
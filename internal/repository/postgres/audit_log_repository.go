@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+const auditLogColumns = "id, actor_id, actor_email, action, target_user_id, detail, reason, ip, created_at"
+
+type AuditLogRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditLogRepository AuditLogRepository 생성자
+func NewAuditLogRepository(pool *pgxpool.Pool) *AuditLogRepository {
+	return &AuditLogRepository{pool: pool}
+}
+
+// CreateAuditLog 기록 추가
+func (r *AuditLogRepository) CreateAuditLog(ctx context.Context, entry *models.AuditLog) error {
+	entry.CreatedAt = time.Now()
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO audit_logs (`+auditLogColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		entry.ID.Hex(), entry.ActorID.Hex(), entry.ActorEmail, entry.Action, entry.TargetUserID.Hex(),
+		nullString(entry.Detail), nullString(entry.Reason), nullString(entry.IP), entry.CreatedAt,
+	)
+	return err
+}
+
+// ListAuditLogsByTarget 대상 사용자에 대한 기록 (최신 순, 최대 limit개)
+func (r *AuditLogRepository) ListAuditLogsByTarget(ctx context.Context, targetUserID primitive.ObjectID, limit int) ([]*models.AuditLog, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+auditLogColumns+`
+		FROM audit_logs
+		WHERE target_user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`,
+		targetUserID.Hex(), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditLog{}
+	for rows.Next() {
+		entry, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func scanAuditLog(row pgx.Row) (*models.AuditLog, error) {
+	var (
+		entry                 models.AuditLog
+		id, actorID, targetID string
+		detail, reason, ip    *string
+	)
+	err := row.Scan(&id, &actorID, &entry.ActorEmail, &entry.Action, &targetID, &detail, &reason, &ip, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ID = parseObjectID(&id)
+	entry.ActorID = parseObjectID(&actorID)
+	entry.TargetUserID = parseObjectID(&targetID)
+	entry.Detail = stringValue(detail)
+	entry.Reason = stringValue(reason)
+	entry.IP = stringValue(ip)
+	return &entry, nil
+}
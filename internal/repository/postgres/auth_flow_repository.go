@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+const flowColumns = "id, kind, user_id, data, attempts, expires_at, created_at"
+
+type AuthFlowRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuthFlowRepository AuthFlowRepository 생성자
+func NewAuthFlowRepository(pool *pgxpool.Pool) *AuthFlowRepository {
+	return &AuthFlowRepository{pool: pool}
+}
+
+// SaveFlow 인증 절차 상태 저장 (만료된 절차도 함께 정리)
+func (r *AuthFlowRepository) SaveFlow(ctx context.Context, flow *models.AuthFlow) error {
+	flow.CreatedAt = time.Now()
+
+	if _, err := r.pool.Exec(ctx, "DELETE FROM auth_flows WHERE expires_at <= now()"); err != nil {
+		return err
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO auth_flows (id, kind, user_id, data, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		flow.ID, flow.Kind, flowUserID(flow), flow.Data, flow.ExpiresAt, flow.CreatedAt,
+	)
+	if isUniqueViolation(err) {
+		return repository.ErrFlowExists
+	}
+	return err
+}
+
+// ConsumeFlow 인증 절차 상태를 꺼내면서 삭제 (없거나 만료됐으면 nil)
+func (r *AuthFlowRepository) ConsumeFlow(ctx context.Context, id, kind string) (*models.AuthFlow, error) {
+	return r.findOne(ctx, `
+		DELETE FROM auth_flows
+		WHERE id = $1 AND kind = $2 AND expires_at > now()
+		RETURNING `+flowColumns,
+		id, kind,
+	)
+}
+
+// ReplaceFlow 같은 ID의 절차가 있으면 덮어쓰고 시도 횟수 초기화
+func (r *AuthFlowRepository) ReplaceFlow(ctx context.Context, flow *models.AuthFlow) error {
+	flow.CreatedAt = time.Now()
+	flow.Attempts = 0
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO auth_flows (id, kind, user_id, data, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, 0, $5, $6)
+		ON CONFLICT (id) DO UPDATE
+		SET kind = EXCLUDED.kind, user_id = EXCLUDED.user_id, data = EXCLUDED.data,
+			attempts = 0, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at`,
+		flow.ID, flow.Kind, flowUserID(flow), flow.Data, flow.ExpiresAt, flow.CreatedAt,
+	)
+	return err
+}
+
+// UseFlowAttempt 시도 횟수를 하나 올린 절차 반환 (없거나 만료됐거나 시도 횟수를 다 썼으면 nil)
+func (r *AuthFlowRepository) UseFlowAttempt(ctx context.Context, id, kind string, maxAttempts int) (*models.AuthFlow, error) {
+	return r.findOne(ctx, `
+		UPDATE auth_flows SET attempts = attempts + 1
+		WHERE id = $1 AND kind = $2 AND expires_at > now() AND attempts < $3
+		RETURNING `+flowColumns,
+		id, kind, maxAttempts,
+	)
+}
+
+// findOne flowColumns를 반환하는 쿼리로 절차 하나 조회 (없으면 nil)
+func (r *AuthFlowRepository) findOne(ctx context.Context, sql string, args ...interface{}) (*models.AuthFlow, error) {
+	var (
+		flow   models.AuthFlow
+		userID *string
+	)
+	err := r.pool.QueryRow(ctx, sql, args...).
+		Scan(&flow.ID, &flow.Kind, &userID, &flow.Data, &flow.Attempts, &flow.ExpiresAt, &flow.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	flow.UserID = parseObjectID(userID)
+	return &flow, nil
+}
+
+// flowUserID 사용자 ID 컬럼 값 (없으면 NULL)
+func flowUserID(flow *models.AuthFlow) *string {
+	if flow.UserID.IsZero() {
+		return nil
+	}
+	hex := flow.UserID.Hex()
+	return &hex
+}
@@ -0,0 +1,479 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+const userColumns = `id, email, password, email_verified, email_verify_token_hash, email_verify_expiry,
+	reset_token_hash, reset_token_expiry, magic_link_token_hash, magic_link_expiry, created_at, updated_at, status, last_login,
+	status_reason, status_changed_at, tokens_valid_after, token_version, totp_secret, totp_enabled, totp_last_step,
+	recovery_codes, failed_login_attempts, locked_until, roles, permissions`
+
+// likeEscaper LIKE 패턴의 특수 문자를 그대로 검색하도록 이스케이프
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type AuthRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuthRepository AuthRepository 생성자
+func NewAuthRepository(pool *pgxpool.Pool) *AuthRepository {
+	return &AuthRepository{pool: pool}
+}
+
+// CreateUser 새로운 사용자 생성
+func (r *AuthRepository) CreateUser(ctx context.Context, user *models.User) error {
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.Status = models.UserStatusActive
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO users (id, email, password, email_verified, created_at, updated_at, status, roles, permissions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		user.ID.Hex(), user.Email, user.Password, user.EmailVerified,
+		user.CreatedAt, user.UpdatedAt, string(user.Status), user.Roles, user.Permissions,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("email already exists")
+		}
+		return err
+	}
+	return nil
+}
+
+// FindUserByEmail 이메일로 사용자 찾기
+func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE email = $1", email)
+}
+
+// FindUserByID ID로 사용자 찾기
+func (r *AuthRepository) FindUserByID(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	return r.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", userID.Hex())
+}
+
+// UpdateLastLogin 마지막 로그인 시간 업데이트
+func (r *AuthRepository) UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error {
+	now := time.Now()
+	_, err := r.pool.Exec(ctx,
+		"UPDATE users SET last_login = $2, updated_at = $2 WHERE id = $1",
+		userID.Hex(), now,
+	)
+	return err
+}
+
+// SearchUsers 조건에 맞는 사용자 한 페이지 (최근 가입 순)와 전체 수
+func (r *AuthRepository) SearchUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int64, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Email != "" {
+		where(`email ILIKE '%%' || $%d || '%%' ESCAPE '\'`, likeEscaper.Replace(filter.Email))
+	}
+	if filter.Status != "" {
+		where("status = $%d", string(filter.Status))
+	}
+	if filter.CreatedAfter != nil {
+		where("created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		where("created_at < $%d", *filter.CreatedBefore)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := r.pool.QueryRow(ctx, "SELECT count(*) FROM users"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+userColumns+" FROM users"+whereClause+
+			fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2),
+		append(args, filter.Limit, filter.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	return users, total, rows.Err()
+}
+
+// RevokeUserTokens 지정 시각 이전에 발급된 사용자의 모든 토큰 무효화
+func (r *AuthRepository) RevokeUserTokens(ctx context.Context, userID primitive.ObjectID, before time.Time) error {
+	return r.updateByID(ctx, userID,
+		"UPDATE users SET tokens_valid_after = $2, updated_at = now() WHERE id = $1",
+		before,
+	)
+}
+
+// BumpTokenVersion 토큰 버전을 올려 기존에 발급된 모든 토큰 무효화
+func (r *AuthRepository) BumpTokenVersion(ctx context.Context, userID primitive.ObjectID) error {
+	return r.updateByID(ctx, userID,
+		"UPDATE users SET token_version = token_version + 1, updated_at = now() WHERE id = $1",
+	)
+}
+
+// UpdateStatus from 상태인 사용자의 상태 변경 (active가 아니게 되면 토큰 버전을 올리고 메일 토큰 삭제)
+func (r *AuthRepository) UpdateStatus(ctx context.Context, userID primitive.ObjectID, from, to models.UserStatus, reason string) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users
+		SET status = $3, status_reason = $4, status_changed_at = now(), updated_at = now(),
+			token_version = token_version + CASE WHEN $3 = 'active' THEN 0 ELSE 1 END,
+			email_verify_token_hash = CASE WHEN $3 = 'active' THEN email_verify_token_hash END,
+			email_verify_expiry = CASE WHEN $3 = 'active' THEN email_verify_expiry END,
+			reset_token_hash = CASE WHEN $3 = 'active' THEN reset_token_hash END,
+			reset_token_expiry = CASE WHEN $3 = 'active' THEN reset_token_expiry END,
+			magic_link_token_hash = CASE WHEN $3 = 'active' THEN magic_link_token_hash END,
+			magic_link_expiry = CASE WHEN $3 = 'active' THEN magic_link_expiry END
+		WHERE id = $1 AND status = $2
+		RETURNING `+userColumns,
+		userID.Hex(), string(from), string(to), nullString(reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// SetRoles 역할 교체 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) SetRoles(ctx context.Context, userID primitive.ObjectID, roles []string) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users SET roles = $2, token_version = token_version + 1, updated_at = now()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		userID.Hex(), roles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// SetPermissions 직접 부여한 권한 교체 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) SetPermissions(ctx context.Context, userID primitive.ObjectID, permissions []string) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users SET permissions = $2, token_version = token_version + 1, updated_at = now()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		userID.Hex(), permissions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// UpdateEmailVerificationToken 이메일 인증 토큰 업데이트
+func (r *AuthRepository) UpdateEmailVerificationToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error {
+	return r.updateByID(ctx, userID,
+		"UPDATE users SET email_verify_token_hash = $2, email_verify_expiry = $3, updated_at = now() WHERE id = $1",
+		nullString(tokenHash), nullTime(expiry),
+	)
+}
+
+// VerifyEmail 이메일 인증 상태 업데이트
+func (r *AuthRepository) VerifyEmail(ctx context.Context, tokenHash string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE users
+		SET email_verified = TRUE, email_verify_token_hash = NULL, email_verify_expiry = NULL, updated_at = now()
+		WHERE email_verify_token_hash = $1 AND email_verify_expiry > now()`,
+		tokenHash,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("invalid or expired verification token")
+	}
+	return nil
+}
+
+// MarkEmailVerified 다른 방법(OTP, 매직 링크 등)으로 확인된 이메일을 인증 처리
+func (r *AuthRepository) MarkEmailVerified(ctx context.Context, userID primitive.ObjectID) error {
+	return r.updateByID(ctx, userID, `
+		UPDATE users
+		SET email_verified = TRUE, email_verify_token_hash = NULL, email_verify_expiry = NULL, updated_at = now()
+		WHERE id = $1`,
+	)
+}
+
+// UpdateResetToken 비밀번호 재설정 토큰 업데이트
+func (r *AuthRepository) UpdateResetToken(ctx context.Context, email string, tokenHash string, expiry time.Time) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE users SET reset_token_hash = $2, reset_token_expiry = $3, updated_at = now() WHERE email = $1",
+		email, nullString(tokenHash), nullTime(expiry),
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// ResetPassword 비밀번호 재설정 (토큰 버전도 함께 올리고 로그인 잠금 해제)
+func (r *AuthRepository) ResetPassword(ctx context.Context, tokenHash, hashedPassword string) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users
+		SET password = $2, reset_token_hash = NULL, reset_token_expiry = NULL,
+			failed_login_attempts = 0, locked_until = NULL,
+			token_version = token_version + 1, updated_at = now()
+		WHERE reset_token_hash = $1 AND reset_token_expiry > now()
+		RETURNING `+userColumns,
+		tokenHash, hashedPassword,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("invalid or expired reset token")
+	}
+	return user, nil
+}
+
+// UpdatePassword 비밀번호 변경 (토큰 버전도 함께 올린다)
+func (r *AuthRepository) UpdatePassword(ctx context.Context, userID primitive.ObjectID, hashedPassword string) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users
+		SET password = $2, reset_token_hash = NULL, reset_token_expiry = NULL,
+			token_version = token_version + 1, updated_at = now()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		userID.Hex(), hashedPassword,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// UpdateMagicLinkToken 매직 링크 토큰 업데이트
+func (r *AuthRepository) UpdateMagicLinkToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error {
+	return r.updateByID(ctx, userID,
+		"UPDATE users SET magic_link_token_hash = $2, magic_link_expiry = $3, updated_at = now() WHERE id = $1",
+		nullString(tokenHash), nullTime(expiry),
+	)
+}
+
+// ConsumeMagicLinkToken 매직 링크 토큰 사용 처리 (이메일 인증도 함께 처리)
+func (r *AuthRepository) ConsumeMagicLinkToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users
+		SET magic_link_token_hash = NULL, magic_link_expiry = NULL, email_verified = TRUE, updated_at = now()
+		WHERE magic_link_token_hash = $1 AND magic_link_expiry > now()
+		RETURNING `+userColumns,
+		tokenHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("invalid or expired login link")
+	}
+	return user, nil
+}
+
+// SetTOTPSecret TOTP 등록 시작 (아직 활성화되지 않은 경우에만)
+func (r *AuthRepository) SetTOTPSecret(ctx context.Context, userID primitive.ObjectID, secret string) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE users SET totp_secret = $2, updated_at = now() WHERE id = $1 AND NOT totp_enabled",
+		userID.Hex(), secret,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("two-factor authentication is already enabled")
+	}
+	return nil
+}
+
+// EnableTOTP TOTP 활성화 및 복구 코드 저장
+func (r *AuthRepository) EnableTOTP(ctx context.Context, userID primitive.ObjectID, lastStep int64, recoveryCodes []string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE users
+		SET totp_enabled = TRUE, totp_last_step = $2, recovery_codes = $3, updated_at = now()
+		WHERE id = $1 AND totp_secret IS NOT NULL AND totp_secret <> '' AND NOT totp_enabled`,
+		userID.Hex(), lastStep, recoveryCodes,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("two-factor authentication enrollment not started")
+	}
+	return nil
+}
+
+// DisableTOTP TOTP 비활성화 및 비밀값/복구 코드 삭제
+func (r *AuthRepository) DisableTOTP(ctx context.Context, userID primitive.ObjectID) error {
+	return r.updateByID(ctx, userID, `
+		UPDATE users
+		SET totp_enabled = FALSE, totp_secret = NULL, totp_last_step = NULL, recovery_codes = NULL, updated_at = now()
+		WHERE id = $1`,
+	)
+}
+
+// UseTOTPStep 시간 단계를 사용 처리 (이미 같은/이후 단계가 사용됐으면 false)
+func (r *AuthRepository) UseTOTPStep(ctx context.Context, userID primitive.ObjectID, step int64) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE users SET totp_last_step = $2
+		WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2)`,
+		userID.Hex(), step,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// UseRecoveryCode 복구 코드 해시를 제거 (이미 사용된 코드면 false)
+func (r *AuthRepository) UseRecoveryCode(ctx context.Context, userID primitive.ObjectID, codeHash string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE users SET recovery_codes = array_remove(recovery_codes, $2), updated_at = now()
+		WHERE id = $1 AND $2 = ANY(recovery_codes)`,
+		userID.Hex(), codeHash,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// RecordFailedLogin 로그인 실패 횟수 증가 후 갱신된 사용자 반환
+func (r *AuthRepository) RecordFailedLogin(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	user, err := r.findOne(ctx, `
+		UPDATE users SET failed_login_attempts = failed_login_attempts + 1, updated_at = now()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		userID.Hex(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// LockUser 지정 시각까지 로그인 잠금
+func (r *AuthRepository) LockUser(ctx context.Context, userID primitive.ObjectID, until time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		"UPDATE users SET locked_until = $2, updated_at = now() WHERE id = $1",
+		userID.Hex(), until,
+	)
+	return err
+}
+
+// ResetFailedLogins 로그인 실패 횟수 초기화 및 잠금 해제
+func (r *AuthRepository) ResetFailedLogins(ctx context.Context, userID primitive.ObjectID) error {
+	return r.updateByID(ctx, userID,
+		"UPDATE users SET failed_login_attempts = 0, locked_until = NULL, updated_at = now() WHERE id = $1",
+	)
+}
+
+// updateByID 첫 번째 인자가 사용자 ID인 UPDATE 실행 (없으면 "user not found")
+func (r *AuthRepository) updateByID(ctx context.Context, userID primitive.ObjectID, sql string, args ...interface{}) error {
+	result, err := r.pool.Exec(ctx, sql, append([]interface{}{userID.Hex()}, args...)...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// findOne userColumns를 반환하는 쿼리로 사용자 한 명 조회 (없으면 nil)
+func (r *AuthRepository) findOne(ctx context.Context, sql string, args ...interface{}) (*models.User, error) {
+	user, err := scanUser(r.pool.QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	var (
+		user                 models.User
+		id                   string
+		emailVerifyTokenHash *string
+		emailVerifyExpiry    *time.Time
+		resetTokenHash       *string
+		resetTokenExpiry     *time.Time
+		magicLinkTokenHash   *string
+		magicLinkExpiry      *time.Time
+		totpSecret           *string
+		totpLastStep         *int64
+		statusReason         *string
+	)
+
+	err := row.Scan(
+		&id, &user.Email, &user.Password, &user.EmailVerified, &emailVerifyTokenHash, &emailVerifyExpiry,
+		&resetTokenHash, &resetTokenExpiry, &magicLinkTokenHash, &magicLinkExpiry, &user.CreatedAt, &user.UpdatedAt, &user.Status, &user.LastLogin,
+		&statusReason, &user.StatusChangedAt, &user.TokensValidAfter, &user.TokenVersion, &totpSecret, &user.TOTPEnabled, &totpLastStep,
+		&user.RecoveryCodes, &user.FailedLoginAttempts, &user.LockedUntil, &user.Roles, &user.Permissions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.ID = parseObjectID(&id)
+	user.EmailVerifyTokenHash = stringValue(emailVerifyTokenHash)
+	user.EmailVerifyExpiry = timeValue(emailVerifyExpiry)
+	user.ResetTokenHash = stringValue(resetTokenHash)
+	user.ResetTokenExpiry = timeValue(resetTokenExpiry)
+	user.MagicLinkTokenHash = stringValue(magicLinkTokenHash)
+	user.MagicLinkExpiry = timeValue(magicLinkExpiry)
+	user.TOTPSecret = stringValue(totpSecret)
+	user.StatusReason = stringValue(statusReason)
+	if totpLastStep != nil {
+		user.TOTPLastStep = *totpLastStep
+	}
+	return &user, nil
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type FederatedIdentityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFederatedIdentityRepository FederatedIdentityRepository 생성자
+func NewFederatedIdentityRepository(pool *pgxpool.Pool) *FederatedIdentityRepository {
+	return &FederatedIdentityRepository{pool: pool}
+}
+
+// CreateIdentity 외부 계정 연결 저장
+func (r *FederatedIdentityRepository) CreateIdentity(ctx context.Context, identity *models.FederatedIdentity) error {
+	identity.CreatedAt = time.Now()
+	if identity.ID.IsZero() {
+		identity.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO federated_identities (id, provider, subject, user_id, email, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		identity.ID.Hex(), identity.Provider, identity.Subject, identity.UserID.Hex(),
+		identity.Email, identity.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("identity already linked")
+		}
+		return err
+	}
+	return nil
+}
+
+// FindIdentity 제공자와 subject로 연결 찾기
+func (r *FederatedIdentityRepository) FindIdentity(ctx context.Context, provider, subject string) (*models.FederatedIdentity, error) {
+	var (
+		identity models.FederatedIdentity
+		id       string
+		userID   string
+	)
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, provider, subject, user_id, email, created_at
+		FROM federated_identities WHERE provider = $1 AND subject = $2`,
+		provider, subject,
+	).Scan(&id, &identity.Provider, &identity.Subject, &userID, &identity.Email, &identity.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity.ID = parseObjectID(&id)
+	identity.UserID = parseObjectID(&userID)
+	return &identity, nil
+}
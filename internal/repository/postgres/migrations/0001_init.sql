@@ -0,0 +1,96 @@
+-- 사용자 (id는 Mongo와 같은 ObjectID hex 문자열)
+CREATE TABLE users (
+    id                    CHAR(24) PRIMARY KEY,
+    email                 TEXT NOT NULL,
+    password              TEXT NOT NULL,
+    email_verified        BOOLEAN NOT NULL DEFAULT FALSE,
+    email_verify_token    TEXT,
+    email_verify_expiry   TIMESTAMPTZ,
+    reset_token           TEXT,
+    reset_token_expiry    TIMESTAMPTZ,
+    created_at            TIMESTAMPTZ NOT NULL,
+    updated_at            TIMESTAMPTZ NOT NULL,
+    status                TEXT NOT NULL,
+    last_login            TIMESTAMPTZ,
+    tokens_valid_after    TIMESTAMPTZ,
+    token_version         INTEGER NOT NULL DEFAULT 0,
+    totp_secret           TEXT,
+    totp_enabled          BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_last_step        BIGINT,
+    recovery_codes        TEXT[],
+    failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until          TIMESTAMPTZ,
+    roles                 TEXT[],
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+
+CREATE INDEX users_email_verify_token_idx ON users (email_verify_token) WHERE email_verify_token IS NOT NULL;
+CREATE INDEX users_reset_token_idx ON users (reset_token) WHERE reset_token IS NOT NULL;
+
+-- 리프레시 토큰 (해시만 저장)
+CREATE TABLE refresh_tokens (
+    id         CHAR(24) PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    family_id  TEXT NOT NULL,
+    user_id    CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX refresh_tokens_user_id_idx ON refresh_tokens (user_id);
+CREATE INDEX refresh_tokens_expires_at_idx ON refresh_tokens (expires_at);
+
+-- 폐기된 액세스 토큰 (jti)
+CREATE TABLE revoked_tokens (
+    jti        TEXT PRIMARY KEY,
+    user_id    CHAR(24) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+
+-- 패스키
+CREATE TABLE webauthn_credentials (
+    id               CHAR(24) PRIMARY KEY,
+    user_id          CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name             TEXT NOT NULL,
+    credential_id    BYTEA NOT NULL UNIQUE,
+    public_key       BYTEA NOT NULL,
+    attestation_type TEXT NOT NULL,
+    transports       TEXT[],
+    aaguid           BYTEA,
+    sign_count       BIGINT NOT NULL DEFAULT 0,
+    clone_warning    BOOLEAN NOT NULL DEFAULT FALSE,
+    user_verified    BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_eligible  BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state     BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at       TIMESTAMPTZ NOT NULL,
+    last_used_at     TIMESTAMPTZ
+);
+
+CREATE INDEX webauthn_credentials_user_id_idx ON webauthn_credentials (user_id);
+
+-- 여러 요청에 걸친 인증 절차 상태
+CREATE TABLE auth_flows (
+    id         TEXT PRIMARY KEY,
+    kind       TEXT NOT NULL,
+    user_id    CHAR(24),
+    data       BYTEA,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX auth_flows_expires_at_idx ON auth_flows (expires_at);
+
+-- JWT 서명 키
+CREATE TABLE signing_keys (
+    id           TEXT PRIMARY KEY,
+    algorithm    TEXT NOT NULL,
+    private_key  TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,15 @@
+-- 재설정/인증 토큰은 SHA-256 해시만 저장한다.
+-- 기존 평문 토큰은 해시로 조회되지 않으므로 지우고, 사용자는 메일을 다시 요청하면 된다.
+DROP INDEX users_email_verify_token_idx;
+DROP INDEX users_reset_token_idx;
+
+ALTER TABLE users RENAME COLUMN email_verify_token TO email_verify_token_hash;
+ALTER TABLE users RENAME COLUMN reset_token TO reset_token_hash;
+
+UPDATE users
+SET email_verify_token_hash = NULL, email_verify_expiry = NULL,
+    reset_token_hash = NULL, reset_token_expiry = NULL
+WHERE email_verify_token_hash IS NOT NULL OR reset_token_hash IS NOT NULL;
+
+CREATE INDEX users_email_verify_token_hash_idx ON users (email_verify_token_hash) WHERE email_verify_token_hash IS NOT NULL;
+CREATE INDEX users_reset_token_hash_idx ON users (reset_token_hash) WHERE reset_token_hash IS NOT NULL;
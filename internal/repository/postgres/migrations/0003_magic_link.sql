@@ -0,0 +1,5 @@
+-- 매직 링크 로그인 토큰 (SHA-256 해시만 저장)
+ALTER TABLE users ADD COLUMN magic_link_token_hash TEXT;
+ALTER TABLE users ADD COLUMN magic_link_expiry TIMESTAMPTZ;
+
+CREATE INDEX users_magic_link_token_hash_idx ON users (magic_link_token_hash) WHERE magic_link_token_hash IS NOT NULL;
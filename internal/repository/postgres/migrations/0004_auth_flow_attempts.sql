@@ -0,0 +1,2 @@
+-- 이메일 OTP 검증 시도 횟수
+ALTER TABLE auth_flows ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
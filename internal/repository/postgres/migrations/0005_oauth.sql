@@ -0,0 +1,20 @@
+-- OAuth 2.0 클라이언트
+CREATE TABLE oauth_clients (
+    id            CHAR(24) PRIMARY KEY,
+    client_id     TEXT NOT NULL UNIQUE,
+    secret_hash   TEXT,
+    name          TEXT NOT NULL,
+    redirect_uris TEXT[] NOT NULL,
+    scopes        TEXT[] NOT NULL,
+    public        BOOLEAN NOT NULL DEFAULT FALSE,
+    owner_id      CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX oauth_clients_owner_id_idx ON oauth_clients (owner_id);
+
+-- OAuth 클라이언트에 발급된 리프레시 토큰
+ALTER TABLE refresh_tokens ADD COLUMN client_id TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN scope TEXT;
+
+CREATE INDEX refresh_tokens_client_id_idx ON refresh_tokens (client_id) WHERE client_id IS NOT NULL;
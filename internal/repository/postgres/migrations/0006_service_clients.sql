@@ -0,0 +1,9 @@
+-- 서비스 간 인증용 클라이언트 (client_credentials)
+CREATE TABLE service_clients (
+    id          CHAR(24) PRIMARY KEY,
+    client_id   TEXT NOT NULL UNIQUE,
+    secret_hash TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    scopes      TEXT[] NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,12 @@
+-- 외부 로그인 계정 연결 (google, kakao, naver 등)
+CREATE TABLE federated_identities (
+    id         CHAR(24) PRIMARY KEY,
+    provider   TEXT NOT NULL,
+    subject    TEXT NOT NULL,
+    user_id    CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email      TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX federated_identities_user_id_idx ON federated_identities (user_id);
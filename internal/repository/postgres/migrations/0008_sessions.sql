@@ -0,0 +1,14 @@
+-- 로그인 세션 (ID는 리프레시 토큰 패밀리 ID)
+CREATE TABLE sessions (
+    id           TEXT PRIMARY KEY,
+    user_id      CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_agent   TEXT NOT NULL,
+    ip           TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id, last_seen_at DESC);
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
@@ -0,0 +1,5 @@
+-- 계정 상태 변경 사유와 시각
+ALTER TABLE users
+    ADD COLUMN status_reason     TEXT,
+    ADD COLUMN status_changed_at TIMESTAMPTZ,
+    ADD CONSTRAINT users_status_check CHECK (status IN ('active', 'suspended', 'banned', 'deleted'));
@@ -0,0 +1,17 @@
+-- 관리자 작업 기록 (계정을 지워도 기록은 남도록 users를 참조하지 않음)
+CREATE TABLE audit_logs (
+    id             CHAR(24) PRIMARY KEY,
+    actor_id       CHAR(24) NOT NULL,
+    actor_email    TEXT NOT NULL,
+    action         TEXT NOT NULL,
+    target_user_id CHAR(24) NOT NULL,
+    detail         TEXT,
+    reason         TEXT,
+    ip             TEXT,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX audit_logs_target_user_id_idx ON audit_logs (target_user_id, created_at DESC);
+
+-- 관리자 사용자 검색 (최근 가입 순)
+CREATE INDEX users_created_at_idx ON users (created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- 역할 외에 직접 부여한 권한
+ALTER TABLE users ADD COLUMN permissions TEXT[];
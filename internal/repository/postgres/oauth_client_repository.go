@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+const oauthClientColumns = "id, client_id, secret_hash, name, redirect_uris, scopes, public, owner_id, created_at"
+
+type OAuthClientRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOAuthClientRepository OAuthClientRepository 생성자
+func NewOAuthClientRepository(pool *pgxpool.Pool) *OAuthClientRepository {
+	return &OAuthClientRepository{pool: pool}
+}
+
+// CreateClient 클라이언트 저장
+func (r *OAuthClientRepository) CreateClient(ctx context.Context, client *models.OAuthClient) error {
+	client.CreatedAt = time.Now()
+	if client.ID.IsZero() {
+		client.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO oauth_clients (`+oauthClientColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		client.ID.Hex(), client.ClientID, nullString(client.SecretHash), client.Name,
+		client.RedirectURIs, client.Scopes, client.Public, client.OwnerID.Hex(), client.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("client already exists")
+		}
+		return err
+	}
+	return nil
+}
+
+// FindClientByClientID client_id로 클라이언트 찾기
+func (r *OAuthClientRepository) FindClientByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	client, err := scanOAuthClient(r.pool.QueryRow(ctx,
+		"SELECT "+oauthClientColumns+" FROM oauth_clients WHERE client_id = $1",
+		clientID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return client, err
+}
+
+// ListClientsByOwner 사용자가 등록한 클라이언트 목록 (등록 순)
+func (r *OAuthClientRepository) ListClientsByOwner(ctx context.Context, ownerID primitive.ObjectID) ([]*models.OAuthClient, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+oauthClientColumns+" FROM oauth_clients WHERE owner_id = $1 ORDER BY created_at",
+		ownerID.Hex(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []*models.OAuthClient{}
+	for rows.Next() {
+		client, err := scanOAuthClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, rows.Err()
+}
+
+// DeleteClient 사용자의 클라이언트 삭제
+func (r *OAuthClientRepository) DeleteClient(ctx context.Context, ownerID, id primitive.ObjectID) error {
+	result, err := r.pool.Exec(ctx,
+		"DELETE FROM oauth_clients WHERE id = $1 AND owner_id = $2",
+		id.Hex(), ownerID.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("client not found")
+	}
+	return nil
+}
+
+func scanOAuthClient(row pgx.Row) (*models.OAuthClient, error) {
+	var (
+		client     models.OAuthClient
+		id         string
+		secretHash *string
+		ownerID    string
+	)
+	err := row.Scan(&id, &client.ClientID, &secretHash, &client.Name, &client.RedirectURIs,
+		&client.Scopes, &client.Public, &ownerID, &client.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ID = parseObjectID(&id)
+	client.SecretHash = stringValue(secretHash)
+	client.OwnerID = parseObjectID(&ownerID)
+	return &client, nil
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// 여러 인스턴스가 동시에 마이그레이션하지 않도록 잡는 advisory lock 키
+const migrationLockID = 7355608
+
+// Open PostgreSQL 연결 후 스키마 마이그레이션 적용
+func Open(ctx context.Context, url string) (*pgxpool.Pool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// 연결 테스트
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	if err := Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
+
+// NewStores PostgreSQL 저장소 모음 생성
+func NewStores(pool *pgxpool.Pool) repository.Stores {
+	return repository.Stores{
+		Users:               NewAuthRepository(pool),
+		RefreshTokens:       NewRefreshTokenRepository(pool),
+		RevokedTokens:       NewRevokedTokenRepository(pool),
+		WebAuthnCredentials: NewWebAuthnCredentialRepository(pool),
+		AuthFlows:           NewAuthFlowRepository(pool),
+		OAuthClients:        NewOAuthClientRepository(pool),
+		ServiceClients:      NewServiceClientRepository(pool),
+		FederatedIdentities: NewFederatedIdentityRepository(pool),
+		Sessions:            NewSessionRepository(pool),
+		AuditLogs:           NewAuditLogRepository(pool),
+	}
+}
+
+// Migrate 아직 적용되지 않은 migrations/*.sql을 파일 이름 순서대로 적용
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version := strings.TrimSuffix(strings.TrimPrefix(file, "migrations/"), ".sql")
+
+		var applied bool
+		if err := conn.QueryRow(ctx,
+			"SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version,
+		).Scan(&applied); err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+
+		sql, err := migrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, string(sql)); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+		log.Printf("applied database migration %s", version)
+	}
+	return nil
+}
+
+// isUniqueViolation unique 제약 위반 여부
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// parseObjectID 저장된 hex ID를 ObjectID로 변환
+func parseObjectID(hex *string) primitive.ObjectID {
+	if hex == nil {
+		return primitive.NilObjectID
+	}
+	id, _ := primitive.ObjectIDFromHex(*hex)
+	return id
+}
+
+// nullString 빈 문자열은 NULL로 저장
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullTime 0 시각은 NULL로 저장
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type RefreshTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRefreshTokenRepository RefreshTokenRepository 생성자
+func NewRefreshTokenRepository(pool *pgxpool.Pool) *RefreshTokenRepository {
+	return &RefreshTokenRepository{pool: pool}
+}
+
+// CreateRefreshToken 리프레시 토큰 저장 (만료된 토큰도 함께 정리)
+func (r *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	token.CreatedAt = time.Now()
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+
+	if _, err := r.pool.Exec(ctx, "DELETE FROM refresh_tokens WHERE expires_at <= now()"); err != nil {
+		return err
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO refresh_tokens (id, token_hash, family_id, user_id, client_id, scope, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		token.ID.Hex(), token.TokenHash, token.FamilyID, token.UserID.Hex(),
+		nullString(token.ClientID), nullString(token.Scope), token.ExpiresAt, token.CreatedAt,
+	)
+	return err
+}
+
+// FindRefreshTokenByHash 토큰 해시로 리프레시 토큰 찾기
+func (r *RefreshTokenRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var (
+		token    models.RefreshToken
+		id       string
+		userID   string
+		clientID *string
+		scope    *string
+	)
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, token_hash, family_id, user_id, client_id, scope, expires_at, created_at, rotated_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = $1`,
+		tokenHash,
+	).Scan(&id, &token.TokenHash, &token.FamilyID, &userID, &clientID, &scope,
+		&token.ExpiresAt, &token.CreatedAt, &token.RotatedAt, &token.RevokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	token.ID = parseObjectID(&id)
+	token.UserID = parseObjectID(&userID)
+	token.ClientID = stringValue(clientID)
+	token.Scope = stringValue(scope)
+	return &token, nil
+}
+
+// MarkRefreshTokenRotated 아직 사용되지 않은 토큰을 사용됨으로 표시
+// 동시에 같은 토큰으로 요청이 들어와도 한 번만 성공한다.
+func (r *RefreshTokenRepository) MarkRefreshTokenRotated(ctx context.Context, tokenID primitive.ObjectID) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE refresh_tokens SET rotated_at = now()
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL`,
+		tokenID.Hex(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// RevokeRefreshTokenFamily 패밀리에 속한 모든 토큰 폐기
+func (r *RefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := r.pool.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL",
+		familyID,
+	)
+	return err
+}
+
+// RevokeUserRefreshTokens 사용자의 모든 리프레시 토큰 폐기
+func (r *RefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.pool.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL",
+		userID.Hex(),
+	)
+	return err
+}
+
+// RevokeClientRefreshTokens OAuth 클라이언트에 발급된 모든 리프레시 토큰 폐기
+func (r *RefreshTokenRepository) RevokeClientRefreshTokens(ctx context.Context, clientID string) error {
+	_, err := r.pool.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = now() WHERE client_id = $1 AND revoked_at IS NULL",
+		clientID,
+	)
+	return err
+}
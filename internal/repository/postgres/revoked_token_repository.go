@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type RevokedTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRevokedTokenRepository RevokedTokenRepository 생성자
+func NewRevokedTokenRepository(pool *pgxpool.Pool) *RevokedTokenRepository {
+	return &RevokedTokenRepository{pool: pool}
+}
+
+// RevokeToken 토큰을 폐기 목록에 추가 (이미 있으면 무시, 원래 토큰이 만료된 항목은 정리)
+func (r *RevokedTokenRepository) RevokeToken(ctx context.Context, token *models.RevokedToken) error {
+	token.RevokedAt = time.Now()
+
+	if _, err := r.pool.Exec(ctx, "DELETE FROM revoked_tokens WHERE expires_at <= now()"); err != nil {
+		return err
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING`,
+		token.JTI, token.UserID.Hex(), token.ExpiresAt, token.RevokedAt,
+	)
+	return err
+}
+
+// IsTokenRevoked 폐기된 토큰인지 확인
+func (r *RevokedTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := r.pool.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)", jti,
+	).Scan(&revoked)
+	return revoked, err
+}
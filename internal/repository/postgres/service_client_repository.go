@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+const serviceClientColumns = "id, client_id, secret_hash, name, scopes, created_at"
+
+type ServiceClientRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewServiceClientRepository ServiceClientRepository 생성자
+func NewServiceClientRepository(pool *pgxpool.Pool) *ServiceClientRepository {
+	return &ServiceClientRepository{pool: pool}
+}
+
+// CreateServiceClient 클라이언트 저장
+func (r *ServiceClientRepository) CreateServiceClient(ctx context.Context, client *models.ServiceClient) error {
+	client.CreatedAt = time.Now()
+	if client.ID.IsZero() {
+		client.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO service_clients (`+serviceClientColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		client.ID.Hex(), client.ClientID, client.SecretHash, client.Name, client.Scopes, client.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("client already exists")
+		}
+		return err
+	}
+	return nil
+}
+
+// FindServiceClientByClientID client_id로 클라이언트 찾기
+func (r *ServiceClientRepository) FindServiceClientByClientID(ctx context.Context, clientID string) (*models.ServiceClient, error) {
+	client, err := scanServiceClient(r.pool.QueryRow(ctx,
+		"SELECT "+serviceClientColumns+" FROM service_clients WHERE client_id = $1",
+		clientID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return client, err
+}
+
+// ListServiceClients 전체 클라이언트 목록 (등록 순)
+func (r *ServiceClientRepository) ListServiceClients(ctx context.Context) ([]*models.ServiceClient, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+serviceClientColumns+" FROM service_clients ORDER BY created_at",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []*models.ServiceClient{}
+	for rows.Next() {
+		client, err := scanServiceClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, rows.Err()
+}
+
+// DeleteServiceClient 클라이언트 삭제
+func (r *ServiceClientRepository) DeleteServiceClient(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM service_clients WHERE id = $1", id.Hex())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("client not found")
+	}
+	return nil
+}
+
+func scanServiceClient(row pgx.Row) (*models.ServiceClient, error) {
+	var (
+		client models.ServiceClient
+		id     string
+	)
+	err := row.Scan(&id, &client.ClientID, &client.SecretHash, &client.Name, &client.Scopes, &client.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ID = parseObjectID(&id)
+	return &client, nil
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type SessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSessionRepository SessionRepository 생성자
+func NewSessionRepository(pool *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{pool: pool}
+}
+
+// CreateSession 세션 저장 (만료된 세션도 함께 정리)
+func (r *SessionRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	session.CreatedAt = time.Now()
+
+	if _, err := r.pool.Exec(ctx, "DELETE FROM sessions WHERE expires_at <= now()"); err != nil {
+		return err
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO sessions (id, user_id, user_agent, ip, created_at, last_seen_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		session.ID, session.UserID.Hex(), session.UserAgent, session.IP,
+		session.CreatedAt, session.LastSeenAt, session.ExpiresAt,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("session already exists")
+	}
+	return err
+}
+
+// FindSession ID로 세션 찾기
+func (r *SessionRepository) FindSession(ctx context.Context, id string) (*models.Session, error) {
+	session, err := scanSession(r.pool.QueryRow(ctx, `
+		SELECT id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions WHERE id = $1 AND expires_at > now()`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListActiveSessions 폐기되지 않고 만료되지 않은 세션 (최근 사용 순)
+func (r *SessionRepository) ListActiveSessions(ctx context.Context, userID primitive.ObjectID) ([]*models.Session, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+		ORDER BY last_seen_at DESC`,
+		userID.Hex(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// TouchSession 토큰 갱신 시 마지막 사용 시각과 만료 시각 연장
+func (r *SessionRepository) TouchSession(ctx context.Context, id string, lastSeenAt, expiresAt time.Time) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE sessions SET last_seen_at = $2, expires_at = $3 WHERE id = $1",
+		id, lastSeenAt, expiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
+// RevokeSession 세션 폐기
+func (r *SessionRepository) RevokeSession(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx,
+		"UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL",
+		id,
+	)
+	return err
+}
+
+// RevokeUserSessions exceptID를 뺀 사용자의 모든 세션을 폐기하고 폐기한 세션 ID 반환
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID primitive.ObjectID, exceptID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+		RETURNING id`,
+		userID.Hex(), exceptID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func scanSession(row pgx.Row) (*models.Session, error) {
+	var (
+		session models.Session
+		userID  string
+	)
+	if err := row.Scan(&session.ID, &userID, &session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RevokedAt); err != nil {
+		return nil, err
+	}
+	session.UserID = parseObjectID(&userID)
+	return &session, nil
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type SigningKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSigningKeyRepository SigningKeyRepository 생성자
+func NewSigningKeyRepository(pool *pgxpool.Pool) *SigningKeyRepository {
+	return &SigningKeyRepository{pool: pool}
+}
+
+// ListKeys 저장된 모든 서명 키 조회
+func (r *SigningKeyRepository) ListKeys(ctx context.Context) ([]*models.SigningKeyRecord, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT id, algorithm, private_key, created_at, activates_at FROM signing_keys",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*models.SigningKeyRecord
+	for rows.Next() {
+		var record models.SigningKeyRecord
+		if err := rows.Scan(&record.ID, &record.Algorithm, &record.PrivateKey, &record.CreatedAt, &record.ActivatesAt); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+	return records, rows.Err()
+}
+
+// SaveKey 서명 키 저장
+func (r *SigningKeyRepository) SaveKey(ctx context.Context, key *models.SigningKeyRecord) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO signing_keys (id, algorithm, private_key, created_at, activates_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		key.ID, key.Algorithm, key.PrivateKey, key.CreatedAt, key.ActivatesAt,
+	)
+	return err
+}
+
+// DeleteKey 서명 키 삭제
+func (r *SigningKeyRepository) DeleteKey(ctx context.Context, kid string) error {
+	_, err := r.pool.Exec(ctx, "DELETE FROM signing_keys WHERE id = $1", kid)
+	return err
+}
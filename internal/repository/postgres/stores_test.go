@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/storetest"
+)
+
+// POSTGRES_TEST_URL 테스트에 사용할 데이터베이스 (비어 있으면 건너뜀, 데이터를 지우지 않으므로 테스트 전용 DB 사용)
+func TestStores(t *testing.T) {
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL is not set")
+	}
+
+	pool, err := Open(context.Background(), url)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	storetest.Run(t, func(t *testing.T) repository.Stores {
+		return NewStores(pool)
+	})
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+type WebAuthnCredentialRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebAuthnCredentialRepository WebAuthnCredentialRepository 생성자
+func NewWebAuthnCredentialRepository(pool *pgxpool.Pool) *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{pool: pool}
+}
+
+// CreateCredential 자격 증명 저장
+func (r *WebAuthnCredentialRepository) CreateCredential(ctx context.Context, credential *models.WebAuthnCredential) error {
+	credential.CreatedAt = time.Now()
+	if credential.ID.IsZero() {
+		credential.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO webauthn_credentials (
+			id, user_id, name, credential_id, public_key, attestation_type, transports, aaguid,
+			sign_count, clone_warning, user_verified, backup_eligible, backup_state, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		credential.ID.Hex(), credential.UserID.Hex(), credential.Name, credential.CredentialID,
+		credential.PublicKey, credential.AttestationType, credential.Transports, credential.AAGUID,
+		int64(credential.SignCount), credential.CloneWarning, credential.UserVerified,
+		credential.BackupEligible, credential.BackupState, credential.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("credential already registered")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListCredentialsByUser 사용자의 자격 증명 목록 (등록 순)
+func (r *WebAuthnCredentialRepository) ListCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*models.WebAuthnCredential, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, name, credential_id, public_key, attestation_type, transports, aaguid,
+			sign_count, clone_warning, user_verified, backup_eligible, backup_state, created_at, last_used_at
+		FROM webauthn_credentials
+		WHERE user_id = $1
+		ORDER BY created_at`,
+		userID.Hex(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	credentials := []*models.WebAuthnCredential{}
+	for rows.Next() {
+		var (
+			credential models.WebAuthnCredential
+			id         string
+			owner      string
+			signCount  int64
+		)
+		if err := rows.Scan(
+			&id, &owner, &credential.Name, &credential.CredentialID, &credential.PublicKey,
+			&credential.AttestationType, &credential.Transports, &credential.AAGUID, &signCount,
+			&credential.CloneWarning, &credential.UserVerified, &credential.BackupEligible,
+			&credential.BackupState, &credential.CreatedAt, &credential.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		credential.ID = parseObjectID(&id)
+		credential.UserID = parseObjectID(&owner)
+		credential.SignCount = uint32(signCount)
+		credentials = append(credentials, &credential)
+	}
+	return credentials, rows.Err()
+}
+
+// CountCredentialsByUser 사용자의 자격 증명 수
+func (r *WebAuthnCredentialRepository) CountCredentialsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	var count int64
+	err := r.pool.QueryRow(ctx,
+		"SELECT count(*) FROM webauthn_credentials WHERE user_id = $1", userID.Hex(),
+	).Scan(&count)
+	return count, err
+}
+
+// UpdateCredentialUsage 로그인 후 서명 카운터 등 갱신
+func (r *WebAuthnCredentialRepository) UpdateCredentialUsage(ctx context.Context, credential *models.WebAuthnCredential) error {
+	now := time.Now()
+	result, err := r.pool.Exec(ctx, `
+		UPDATE webauthn_credentials
+		SET sign_count = $2, clone_warning = $3, backup_state = $4, last_used_at = $5
+		WHERE id = $1`,
+		credential.ID.Hex(), int64(credential.SignCount), credential.CloneWarning, credential.BackupState, now,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("credential not found")
+	}
+	credential.LastUsedAt = &now
+	return nil
+}
+
+// DeleteCredential 사용자의 자격 증명 삭제
+func (r *WebAuthnCredentialRepository) DeleteCredential(ctx context.Context, userID, credentialID primitive.ObjectID) error {
+	result, err := r.pool.Exec(ctx,
+		"DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2",
+		credentialID.Hex(), userID.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("credential not found")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// UserStore 사용자 저장소
+// 조회 메서드는 사용자가 없으면 nil, nil을 반환하고,
+// ID로 갱신하는 메서드는 사용자가 없으면 "user not found" 에러를 반환한다.
+type UserStore interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
+	FindUserByID(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
+	UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error
+
+	// SearchUsers 조건에 맞는 사용자 한 페이지 (최근 가입 순)와 조건에 맞는 전체 수
+	SearchUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int64, error)
+
+	// 토큰 무효화
+	RevokeUserTokens(ctx context.Context, userID primitive.ObjectID, before time.Time) error
+	BumpTokenVersion(ctx context.Context, userID primitive.ObjectID) error
+
+	// UpdateStatus from 상태인 사용자의 상태 변경 (from 상태가 아니면 "user not found")
+	// active가 아닌 상태로 바뀌면 토큰 버전을 올리고 메일로 보낸 토큰(인증, 재설정, 매직 링크)도 지운다.
+	UpdateStatus(ctx context.Context, userID primitive.ObjectID, from, to models.UserStatus, reason string) (*models.User, error)
+
+	// 역할과 직접 부여한 권한 (토큰 버전을 올려 이전 클레임이 담긴 액세스 토큰을 무효화)
+	SetRoles(ctx context.Context, userID primitive.ObjectID, roles []string) (*models.User, error)
+	SetPermissions(ctx context.Context, userID primitive.ObjectID, permissions []string) (*models.User, error)
+
+	// 이메일 인증 (토큰은 utils.HashToken으로 해시한 값만 주고받는다)
+	UpdateEmailVerificationToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error
+	VerifyEmail(ctx context.Context, tokenHash string) error
+	MarkEmailVerified(ctx context.Context, userID primitive.ObjectID) error
+
+	// 비밀번호 (재설정 토큰도 해시만 저장)
+	UpdateResetToken(ctx context.Context, email string, tokenHash string, expiry time.Time) error
+	ResetPassword(ctx context.Context, tokenHash, hashedPassword string) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID primitive.ObjectID, hashedPassword string) (*models.User, error)
+
+	// 매직 링크 로그인 (한 번 쓰면 삭제되고 이메일 인증도 함께 처리)
+	UpdateMagicLinkToken(ctx context.Context, userID primitive.ObjectID, tokenHash string, expiry time.Time) error
+	ConsumeMagicLinkToken(ctx context.Context, tokenHash string) (*models.User, error)
+
+	// TOTP 2단계 인증
+	SetTOTPSecret(ctx context.Context, userID primitive.ObjectID, secret string) error
+	EnableTOTP(ctx context.Context, userID primitive.ObjectID, lastStep int64, recoveryCodes []string) error
+	DisableTOTP(ctx context.Context, userID primitive.ObjectID) error
+	UseTOTPStep(ctx context.Context, userID primitive.ObjectID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID primitive.ObjectID, codeHash string) (bool, error)
+
+	// 로그인 실패 잠금
+	RecordFailedLogin(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
+	LockUser(ctx context.Context, userID primitive.ObjectID, until time.Time) error
+	ResetFailedLogins(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// RefreshTokenStore 리프레시 토큰 저장소
+type RefreshTokenStore interface {
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	MarkRefreshTokenRotated(ctx context.Context, tokenID primitive.ObjectID) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID primitive.ObjectID) error
+	RevokeClientRefreshTokens(ctx context.Context, clientID string) error
+}
+
+// RevokedTokenStore 폐기된 액세스 토큰(jti) 저장소
+type RevokedTokenStore interface {
+	RevokeToken(ctx context.Context, token *models.RevokedToken) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// WebAuthnCredentialStore 패스키 저장소
+type WebAuthnCredentialStore interface {
+	CreateCredential(ctx context.Context, credential *models.WebAuthnCredential) error
+	ListCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*models.WebAuthnCredential, error)
+	CountCredentialsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	UpdateCredentialUsage(ctx context.Context, credential *models.WebAuthnCredential) error
+	DeleteCredential(ctx context.Context, userID, credentialID primitive.ObjectID) error
+}
+
+// ErrFlowExists 같은 ID의 만료되지 않은 인증 절차가 이미 있어 저장하지 않음
+var ErrFlowExists = errors.New("auth flow already exists")
+
+// AuthFlowStore 여러 요청에 걸친 인증 절차 상태 저장소
+type AuthFlowStore interface {
+	// SaveFlow 새 절차 저장 (같은 ID의 절차가 만료되지 않았으면 ErrFlowExists, 만료됐으면 덮어씀)
+	SaveFlow(ctx context.Context, flow *models.AuthFlow) error
+	ConsumeFlow(ctx context.Context, id, kind string) (*models.AuthFlow, error)
+
+	// ReplaceFlow 같은 ID의 절차가 있으면 덮어쓰고 시도 횟수를 초기화
+	ReplaceFlow(ctx context.Context, flow *models.AuthFlow) error
+	// UseFlowAttempt 시도 횟수를 하나 올린 절차 반환 (없거나 만료됐거나 maxAttempts를 다 썼으면 nil)
+	UseFlowAttempt(ctx context.Context, id, kind string, maxAttempts int) (*models.AuthFlow, error)
+}
+
+// OAuthClientStore OAuth 클라이언트 저장소
+type OAuthClientStore interface {
+	CreateClient(ctx context.Context, client *models.OAuthClient) error
+	FindClientByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error)
+	ListClientsByOwner(ctx context.Context, ownerID primitive.ObjectID) ([]*models.OAuthClient, error)
+	DeleteClient(ctx context.Context, ownerID, id primitive.ObjectID) error
+}
+
+// ServiceClientStore 서비스 간 인증용 클라이언트 저장소
+type ServiceClientStore interface {
+	CreateServiceClient(ctx context.Context, client *models.ServiceClient) error
+	FindServiceClientByClientID(ctx context.Context, clientID string) (*models.ServiceClient, error)
+	ListServiceClients(ctx context.Context) ([]*models.ServiceClient, error)
+	DeleteServiceClient(ctx context.Context, id primitive.ObjectID) error
+}
+
+// FederatedIdentityStore 외부 로그인 계정 연결 저장소
+type FederatedIdentityStore interface {
+	CreateIdentity(ctx context.Context, identity *models.FederatedIdentity) error
+	FindIdentity(ctx context.Context, provider, subject string) (*models.FederatedIdentity, error)
+}
+
+// SessionStore 로그인 세션(기기) 저장소
+// 폐기 메서드는 없거나 이미 폐기된 세션이어도 에러를 반환하지 않는다.
+type SessionStore interface {
+	CreateSession(ctx context.Context, session *models.Session) error
+	FindSession(ctx context.Context, id string) (*models.Session, error)
+	// ListActiveSessions 폐기되지 않고 만료되지 않은 세션 (최근 사용 순)
+	ListActiveSessions(ctx context.Context, userID primitive.ObjectID) ([]*models.Session, error)
+	TouchSession(ctx context.Context, id string, lastSeenAt, expiresAt time.Time) error
+	RevokeSession(ctx context.Context, id string) error
+	// RevokeUserSessions exceptID를 뺀 사용자의 모든 세션을 폐기하고 폐기한 세션 ID 반환
+	RevokeUserSessions(ctx context.Context, userID primitive.ObjectID, exceptID string) ([]string, error)
+}
+
+// AuditLogStore 관리자 작업 기록 저장소 (기록은 수정하거나 지우지 않는다)
+type AuditLogStore interface {
+	CreateAuditLog(ctx context.Context, entry *models.AuditLog) error
+	// ListAuditLogsByTarget 대상 사용자에 대한 기록 (최신 순, 최대 limit개)
+	ListAuditLogsByTarget(ctx context.Context, targetUserID primitive.ObjectID, limit int) ([]*models.AuditLog, error)
+}
+
+// Stores AuthService가 사용하는 저장소 모음
+type Stores struct {
+	Users               UserStore
+	RefreshTokens       RefreshTokenStore
+	RevokedTokens       RevokedTokenStore
+	WebAuthnCredentials WebAuthnCredentialStore
+	AuthFlows           AuthFlowStore
+	OAuthClients        OAuthClientStore
+	ServiceClients      ServiceClientStore
+	FederatedIdentities FederatedIdentityStore
+	Sessions            SessionStore
+	AuditLogs           AuditLogStore
+}
@@ -0,0 +1,682 @@
+// Package storetest 모든 저장소 구현(memory, mongodb, postgres)이 지켜야 하는 공통 계약 테스트
+//
+// 각 구현 패키지의 테스트에서 Run에 저장소 모음을 만드는 함수를 넘겨 실행한다.
+// 실제 DB를 쓰는 구현도 있으므로 테스트마다 새 ID와 이메일을 사용하고 데이터를 지우지 않는다.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+// Run 저장소 계약 테스트 실행 (open은 테스트에 쓸 저장소 모음을 반환)
+func Run(t *testing.T, open func(t *testing.T) repository.Stores) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, stores repository.Stores)
+	}{
+		{"UsersUniqueEmail", testUsersUniqueEmail},
+		{"UsersNotFound", testUsersNotFound},
+		{"UsersEmailVerificationToken", testUsersEmailVerificationToken},
+		{"UsersResetToken", testUsersResetToken},
+		{"UsersMagicLinkToken", testUsersMagicLinkToken},
+		{"UsersSecondFactorSingleUse", testUsersSecondFactorSingleUse},
+		{"UsersStatus", testUsersStatus},
+		{"RefreshTokenRotation", testRefreshTokenRotation},
+		{"RefreshTokenFamilyRevocation", testRefreshTokenFamilyRevocation},
+		{"RevokedTokens", testRevokedTokens},
+		{"AuthFlowSingleUse", testAuthFlowSingleUse},
+		{"AuthFlowExpiry", testAuthFlowExpiry},
+		{"AuthFlowAttempts", testAuthFlowAttempts},
+		{"Sessions", testSessions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, open(t))
+		})
+	}
+}
+
+// uniqueEmail 실행마다 겹치지 않는 이메일
+func uniqueEmail(prefix string) string {
+	return prefix + "-" + primitive.NewObjectID().Hex() + "@example.com"
+}
+
+// createUser 새 사용자를 만들고 저장된 값을 반환
+func createUser(t *testing.T, stores repository.Stores) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Email:    uniqueEmail("user"),
+		Password: "hashed-password",
+		Roles:    []string{models.RoleBuyer},
+	}
+	if err := stores.Users.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return findUser(t, stores, user.ID)
+}
+
+// findUser ID로 사용자 조회 (없으면 실패)
+func findUser(t *testing.T, stores repository.Stores, id primitive.ObjectID) *models.User {
+	t.Helper()
+
+	user, err := stores.Users.FindUserByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindUserByID: %v", err)
+	}
+	if user == nil {
+		t.Fatalf("FindUserByID %s: user not found", id.Hex())
+	}
+	return user
+}
+
+func testUsersUniqueEmail(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	user := createUser(t, stores)
+
+	if user.AccountStatus() != models.UserStatusActive {
+		t.Errorf("new user status = %q, want %q", user.AccountStatus(), models.UserStatusActive)
+	}
+
+	byEmail, err := stores.Users.FindUserByEmail(ctx, user.Email)
+	if err != nil {
+		t.Fatalf("FindUserByEmail: %v", err)
+	}
+	if byEmail == nil || byEmail.ID != user.ID {
+		t.Fatalf("FindUserByEmail returned %v, want user %s", byEmail, user.ID.Hex())
+	}
+
+	duplicate := &models.User{Email: user.Email, Password: "other"}
+	if err := stores.Users.CreateUser(ctx, duplicate); err == nil {
+		t.Fatal("CreateUser with an existing email succeeded")
+	}
+}
+
+func testUsersNotFound(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	missing := primitive.NewObjectID()
+
+	user, err := stores.Users.FindUserByEmail(ctx, uniqueEmail("missing"))
+	if err != nil || user != nil {
+		t.Errorf("FindUserByEmail(missing) = %v, %v; want nil, nil", user, err)
+	}
+	user, err = stores.Users.FindUserByID(ctx, missing)
+	if err != nil || user != nil {
+		t.Errorf("FindUserByID(missing) = %v, %v; want nil, nil", user, err)
+	}
+
+	updates := map[string]func() error{
+		"UpdateLastLogin":   func() error { return stores.Users.UpdateLastLogin(ctx, missing) },
+		"BumpTokenVersion":  func() error { return stores.Users.BumpTokenVersion(ctx, missing) },
+		"MarkEmailVerified": func() error { return stores.Users.MarkEmailVerified(ctx, missing) },
+		"ResetFailedLogins": func() error { return stores.Users.ResetFailedLogins(ctx, missing) },
+		"UpdateResetToken": func() error {
+			return stores.Users.UpdateResetToken(ctx, uniqueEmail("missing"), "hash", time.Now().Add(time.Hour))
+		},
+		"UpdatePassword": func() error {
+			_, err := stores.Users.UpdatePassword(ctx, missing, "hashed")
+			return err
+		},
+		"SetRoles": func() error {
+			_, err := stores.Users.SetRoles(ctx, missing, []string{models.RoleSeller})
+			return err
+		},
+		"SetPermissions": func() error {
+			_, err := stores.Users.SetPermissions(ctx, missing, nil)
+			return err
+		},
+		"UpdateStatus": func() error {
+			_, err := stores.Users.UpdateStatus(ctx, missing, models.UserStatusActive, models.UserStatusSuspended, "")
+			return err
+		},
+	}
+	for name, update := range updates {
+		if err := update(); err == nil {
+			t.Errorf("%s on a missing user returned no error", name)
+		}
+	}
+
+	// 없는 사용자의 일회용 값은 항상 사용할 수 없다
+	if ok, err := stores.Users.UseTOTPStep(ctx, missing, 1); err != nil || ok {
+		t.Errorf("UseTOTPStep(missing) = %v, %v; want false, nil", ok, err)
+	}
+	if ok, err := stores.Users.UseRecoveryCode(ctx, missing, "hash"); err != nil || ok {
+		t.Errorf("UseRecoveryCode(missing) = %v, %v; want false, nil", ok, err)
+	}
+
+	token, err := stores.RefreshTokens.FindRefreshTokenByHash(ctx, "missing-"+missing.Hex())
+	if err != nil || token != nil {
+		t.Errorf("FindRefreshTokenByHash(missing) = %v, %v; want nil, nil", token, err)
+	}
+	if ok, err := stores.RefreshTokens.MarkRefreshTokenRotated(ctx, missing); err != nil || ok {
+		t.Errorf("MarkRefreshTokenRotated(missing) = %v, %v; want false, nil", ok, err)
+	}
+	session, err := stores.Sessions.FindSession(ctx, "missing-"+missing.Hex())
+	if err != nil || session != nil {
+		t.Errorf("FindSession(missing) = %v, %v; want nil, nil", session, err)
+	}
+	if err := stores.Sessions.RevokeSession(ctx, "missing-"+missing.Hex()); err != nil {
+		t.Errorf("RevokeSession(missing) = %v, want nil", err)
+	}
+}
+
+func testUsersEmailVerificationToken(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	user := createUser(t, stores)
+
+	expiredHash := "verify-expired-" + user.ID.Hex()
+	if err := stores.Users.UpdateEmailVerificationToken(ctx, user.ID, expiredHash, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("UpdateEmailVerificationToken: %v", err)
+	}
+	if err := stores.Users.VerifyEmail(ctx, expiredHash); err == nil {
+		t.Fatal("VerifyEmail accepted an expired token")
+	}
+
+	hash := "verify-" + user.ID.Hex()
+	if err := stores.Users.UpdateEmailVerificationToken(ctx, user.ID, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateEmailVerificationToken: %v", err)
+	}
+	if err := stores.Users.VerifyEmail(ctx, hash); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if !findUser(t, stores, user.ID).EmailVerified {
+		t.Error("email is not verified after VerifyEmail")
+	}
+	if err := stores.Users.VerifyEmail(ctx, hash); err == nil {
+		t.Error("VerifyEmail accepted the same token twice")
+	}
+	if err := stores.Users.VerifyEmail(ctx, ""); err == nil {
+		t.Error("VerifyEmail accepted an empty token")
+	}
+}
+
+func testUsersResetToken(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	user := createUser(t, stores)
+
+	expiredHash := "reset-expired-" + user.ID.Hex()
+	if err := stores.Users.UpdateResetToken(ctx, user.Email, expiredHash, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("UpdateResetToken: %v", err)
+	}
+	if _, err := stores.Users.ResetPassword(ctx, expiredHash, "new-hash"); err == nil {
+		t.Fatal("ResetPassword accepted an expired token")
+	}
+
+	// 재설정하면 로그인 잠금도 풀린다
+	if _, err := stores.Users.RecordFailedLogin(ctx, user.ID); err != nil {
+		t.Fatalf("RecordFailedLogin: %v", err)
+	}
+	if err := stores.Users.LockUser(ctx, user.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("LockUser: %v", err)
+	}
+
+	hash := "reset-" + user.ID.Hex()
+	if err := stores.Users.UpdateResetToken(ctx, user.Email, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateResetToken: %v", err)
+	}
+	updated, err := stores.Users.ResetPassword(ctx, hash, "new-hash")
+	if err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if updated.Password != "new-hash" {
+		t.Errorf("password = %q, want %q", updated.Password, "new-hash")
+	}
+	if updated.TokenVersion != user.TokenVersion+1 {
+		t.Errorf("token version = %d, want %d", updated.TokenVersion, user.TokenVersion+1)
+	}
+	if updated.FailedLoginAttempts != 0 || updated.LockedUntil != nil {
+		t.Errorf("lockout not cleared: attempts=%d locked_until=%v", updated.FailedLoginAttempts, updated.LockedUntil)
+	}
+	if _, err := stores.Users.ResetPassword(ctx, hash, "other-hash"); err == nil {
+		t.Error("ResetPassword accepted the same token twice")
+	}
+}
+
+func testUsersMagicLinkToken(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	user := createUser(t, stores)
+
+	expiredHash := "magic-expired-" + user.ID.Hex()
+	if err := stores.Users.UpdateMagicLinkToken(ctx, user.ID, expiredHash, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("UpdateMagicLinkToken: %v", err)
+	}
+	if _, err := stores.Users.ConsumeMagicLinkToken(ctx, expiredHash); err == nil {
+		t.Fatal("ConsumeMagicLinkToken accepted an expired token")
+	}
+
+	hash := "magic-" + user.ID.Hex()
+	if err := stores.Users.UpdateMagicLinkToken(ctx, user.ID, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateMagicLinkToken: %v", err)
+	}
+	consumed, err := stores.Users.ConsumeMagicLinkToken(ctx, hash)
+	if err != nil {
+		t.Fatalf("ConsumeMagicLinkToken: %v", err)
+	}
+	if consumed.ID != user.ID {
+		t.Errorf("ConsumeMagicLinkToken returned user %s, want %s", consumed.ID.Hex(), user.ID.Hex())
+	}
+	if !consumed.EmailVerified {
+		t.Error("ConsumeMagicLinkToken did not return the user with a verified email")
+	}
+	if _, err := stores.Users.ConsumeMagicLinkToken(ctx, hash); err == nil {
+		t.Error("ConsumeMagicLinkToken accepted the same token twice")
+	}
+}
+
+func testUsersSecondFactorSingleUse(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	user := createUser(t, stores)
+
+	if err := stores.Users.SetTOTPSecret(ctx, user.ID, "secret"); err != nil {
+		t.Fatalf("SetTOTPSecret: %v", err)
+	}
+	if err := stores.Users.EnableTOTP(ctx, user.ID, 100, []string{"code-a", "code-b"}); err != nil {
+		t.Fatalf("EnableTOTP: %v", err)
+	}
+	if err := stores.Users.SetTOTPSecret(ctx, user.ID, "other"); err == nil {
+		t.Error("SetTOTPSecret replaced the secret of an enabled user")
+	}
+
+	// 시간 단계는 이전에 쓴 단계보다 뒤일 때만 한 번 사용할 수 있다
+	steps := []struct {
+		step int64
+		want bool
+	}{
+		{100, false},
+		{99, false},
+		{101, true},
+		{101, false},
+		{103, true},
+	}
+	for _, s := range steps {
+		ok, err := stores.Users.UseTOTPStep(ctx, user.ID, s.step)
+		if err != nil {
+			t.Fatalf("UseTOTPStep(%d): %v", s.step, err)
+		}
+		if ok != s.want {
+			t.Errorf("UseTOTPStep(%d) = %v, want %v", s.step, ok, s.want)
+		}
+	}
+
+	codes := []struct {
+		hash string
+		want bool
+	}{
+		{"code-a", true},
+		{"code-a", false},
+		{"code-c", false},
+		{"code-b", true},
+	}
+	for _, c := range codes {
+		ok, err := stores.Users.UseRecoveryCode(ctx, user.ID, c.hash)
+		if err != nil {
+			t.Fatalf("UseRecoveryCode(%s): %v", c.hash, err)
+		}
+		if ok != c.want {
+			t.Errorf("UseRecoveryCode(%s) = %v, want %v", c.hash, ok, c.want)
+		}
+	}
+	if remaining := findUser(t, stores, user.ID).RecoveryCodes; len(remaining) != 0 {
+		t.Errorf("recovery codes left after use: %v", remaining)
+	}
+}
+
+func testUsersStatus(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	user := createUser(t, stores)
+
+	hash := "magic-status-" + user.ID.Hex()
+	if err := stores.Users.UpdateMagicLinkToken(ctx, user.ID, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateMagicLinkToken: %v", err)
+	}
+
+	suspended, err := stores.Users.UpdateStatus(ctx, user.ID, models.UserStatusActive, models.UserStatusSuspended, "review")
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if suspended.AccountStatus() != models.UserStatusSuspended || suspended.StatusReason != "review" {
+		t.Errorf("status = %q (%q), want %q (review)", suspended.AccountStatus(), suspended.StatusReason, models.UserStatusSuspended)
+	}
+	if suspended.TokenVersion != user.TokenVersion+1 {
+		t.Errorf("token version = %d, want %d", suspended.TokenVersion, user.TokenVersion+1)
+	}
+
+	// 정지하면 메일로 보낸 토큰도 쓸 수 없다
+	if _, err := stores.Users.ConsumeMagicLinkToken(ctx, hash); err == nil {
+		t.Error("magic link token still usable after suspension")
+	}
+
+	// 현재 상태가 from과 다르면 바꾸지 않는다
+	if _, err := stores.Users.UpdateStatus(ctx, user.ID, models.UserStatusActive, models.UserStatusBanned, ""); err == nil {
+		t.Error("UpdateStatus changed a user whose status did not match from")
+	}
+	if status := findUser(t, stores, user.ID).AccountStatus(); status != models.UserStatusSuspended {
+		t.Errorf("status = %q after rejected update, want %q", status, models.UserStatusSuspended)
+	}
+}
+
+// createRefreshToken 새 리프레시 토큰 저장
+func createRefreshToken(t *testing.T, stores repository.Stores, userID primitive.ObjectID, familyID string) *models.RefreshToken {
+	t.Helper()
+
+	token := &models.RefreshToken{
+		TokenHash: "refresh-" + primitive.NewObjectID().Hex(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := stores.RefreshTokens.CreateRefreshToken(context.Background(), token); err != nil {
+		t.Fatalf("CreateRefreshToken: %v", err)
+	}
+	return token
+}
+
+// findRefreshToken 해시로 리프레시 토큰 조회 (없으면 실패)
+func findRefreshToken(t *testing.T, stores repository.Stores, hash string) *models.RefreshToken {
+	t.Helper()
+
+	token, err := stores.RefreshTokens.FindRefreshTokenByHash(context.Background(), hash)
+	if err != nil {
+		t.Fatalf("FindRefreshTokenByHash: %v", err)
+	}
+	if token == nil {
+		t.Fatalf("FindRefreshTokenByHash %s: token not found", hash)
+	}
+	return token
+}
+
+func testRefreshTokenRotation(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	token := createRefreshToken(t, stores, userID, "family-"+userID.Hex())
+
+	found := findRefreshToken(t, stores, token.TokenHash)
+	if found.ID != token.ID || found.UserID != userID || found.FamilyID != token.FamilyID {
+		t.Errorf("FindRefreshTokenByHash returned %+v, want token %s", found, token.ID.Hex())
+	}
+	if found.RotatedAt != nil || found.RevokedAt != nil {
+		t.Error("new refresh token is already rotated or revoked")
+	}
+
+	duplicate := &models.RefreshToken{
+		TokenHash: token.TokenHash,
+		FamilyID:  token.FamilyID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := stores.RefreshTokens.CreateRefreshToken(ctx, duplicate); err == nil {
+		t.Error("CreateRefreshToken accepted a duplicate token hash")
+	}
+
+	// 회전은 한 번만 성공한다 (재사용 감지의 기준)
+	ok, err := stores.RefreshTokens.MarkRefreshTokenRotated(ctx, token.ID)
+	if err != nil || !ok {
+		t.Fatalf("first MarkRefreshTokenRotated = %v, %v; want true, nil", ok, err)
+	}
+	ok, err = stores.RefreshTokens.MarkRefreshTokenRotated(ctx, token.ID)
+	if err != nil || ok {
+		t.Errorf("second MarkRefreshTokenRotated = %v, %v; want false, nil", ok, err)
+	}
+	if findRefreshToken(t, stores, token.TokenHash).RotatedAt == nil {
+		t.Error("rotated token has no rotated_at")
+	}
+}
+
+func testRefreshTokenFamilyRevocation(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	family := "family-" + primitive.NewObjectID().Hex()
+	other := "family-" + primitive.NewObjectID().Hex()
+
+	first := createRefreshToken(t, stores, userID, family)
+	second := createRefreshToken(t, stores, userID, family)
+	unrelated := createRefreshToken(t, stores, userID, other)
+
+	if err := stores.RefreshTokens.RevokeRefreshTokenFamily(ctx, family); err != nil {
+		t.Fatalf("RevokeRefreshTokenFamily: %v", err)
+	}
+	for _, token := range []*models.RefreshToken{first, second} {
+		if findRefreshToken(t, stores, token.TokenHash).RevokedAt == nil {
+			t.Errorf("token %s in the revoked family is not revoked", token.ID.Hex())
+		}
+	}
+	if findRefreshToken(t, stores, unrelated.TokenHash).RevokedAt != nil {
+		t.Error("token in another family was revoked")
+	}
+
+	// 폐기된 토큰은 회전할 수 없다
+	if ok, err := stores.RefreshTokens.MarkRefreshTokenRotated(ctx, second.ID); err != nil || ok {
+		t.Errorf("MarkRefreshTokenRotated on a revoked token = %v, %v; want false, nil", ok, err)
+	}
+	// 이미 폐기된 패밀리를 다시 폐기해도 에러가 아니다
+	if err := stores.RefreshTokens.RevokeRefreshTokenFamily(ctx, family); err != nil {
+		t.Errorf("revoking a revoked family again: %v", err)
+	}
+
+	if err := stores.RefreshTokens.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		t.Fatalf("RevokeUserRefreshTokens: %v", err)
+	}
+	if findRefreshToken(t, stores, unrelated.TokenHash).RevokedAt == nil {
+		t.Error("RevokeUserRefreshTokens left a token of the user active")
+	}
+}
+
+func testRevokedTokens(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	jti := "jti-" + primitive.NewObjectID().Hex()
+
+	revoked, err := stores.RevokedTokens.IsTokenRevoked(ctx, jti)
+	if err != nil || revoked {
+		t.Fatalf("IsTokenRevoked before revoke = %v, %v; want false, nil", revoked, err)
+	}
+
+	token := &models.RevokedToken{JTI: jti, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := stores.RevokedTokens.RevokeToken(ctx, token); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if err := stores.RevokedTokens.RevokeToken(ctx, &models.RevokedToken{JTI: jti, ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Errorf("revoking the same jti again: %v", err)
+	}
+
+	revoked, err = stores.RevokedTokens.IsTokenRevoked(ctx, jti)
+	if err != nil || !revoked {
+		t.Errorf("IsTokenRevoked after revoke = %v, %v; want true, nil", revoked, err)
+	}
+}
+
+// newFlow 한 시간 동안 유효한 새 인증 절차
+func newFlow(kind string) *models.AuthFlow {
+	return &models.AuthFlow{
+		ID:        "flow-" + primitive.NewObjectID().Hex(),
+		Kind:      kind,
+		UserID:    primitive.NewObjectID(),
+		Data:      []byte("state"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func testAuthFlowSingleUse(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	flow := newFlow("test_single_use")
+
+	if err := stores.AuthFlows.SaveFlow(ctx, flow); err != nil {
+		t.Fatalf("SaveFlow: %v", err)
+	}
+	duplicate := *flow
+	if err := stores.AuthFlows.SaveFlow(ctx, &duplicate); !errors.Is(err, repository.ErrFlowExists) {
+		t.Errorf("SaveFlow with an existing flow ID = %v, want %v", err, repository.ErrFlowExists)
+	}
+
+	// 종류가 다르면 꺼낼 수 없고 절차도 그대로 남는다
+	if got, err := stores.AuthFlows.ConsumeFlow(ctx, flow.ID, "other_kind"); err != nil || got != nil {
+		t.Errorf("ConsumeFlow with another kind = %v, %v; want nil, nil", got, err)
+	}
+
+	got, err := stores.AuthFlows.ConsumeFlow(ctx, flow.ID, flow.Kind)
+	if err != nil {
+		t.Fatalf("ConsumeFlow: %v", err)
+	}
+	if got == nil {
+		t.Fatal("ConsumeFlow returned nil for a saved flow")
+	}
+	if got.UserID != flow.UserID || string(got.Data) != "state" {
+		t.Errorf("ConsumeFlow returned %+v, want user %s with data %q", got, flow.UserID.Hex(), "state")
+	}
+
+	if got, err := stores.AuthFlows.ConsumeFlow(ctx, flow.ID, flow.Kind); err != nil || got != nil {
+		t.Errorf("second ConsumeFlow = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func testAuthFlowExpiry(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	flow := newFlow("test_expiry")
+	flow.ExpiresAt = time.Now().Add(-time.Second)
+
+	if err := stores.AuthFlows.ReplaceFlow(ctx, flow); err != nil {
+		t.Fatalf("ReplaceFlow: %v", err)
+	}
+	if got, err := stores.AuthFlows.UseFlowAttempt(ctx, flow.ID, flow.Kind, 5); err != nil || got != nil {
+		t.Errorf("UseFlowAttempt on an expired flow = %v, %v; want nil, nil", got, err)
+	}
+	if got, err := stores.AuthFlows.ConsumeFlow(ctx, flow.ID, flow.Kind); err != nil || got != nil {
+		t.Errorf("ConsumeFlow on an expired flow = %v, %v; want nil, nil", got, err)
+	}
+
+	// 만료된 절차가 아직 지워지지 않았어도 같은 ID로 새 절차를 저장할 수 있다
+	renewed := newFlow(flow.Kind)
+	renewed.ID = flow.ID
+	renewed.Data = []byte("renewed")
+	if err := stores.AuthFlows.SaveFlow(ctx, renewed); err != nil {
+		t.Fatalf("SaveFlow over an expired flow: %v", err)
+	}
+	if got, err := stores.AuthFlows.ConsumeFlow(ctx, flow.ID, flow.Kind); err != nil || got == nil || string(got.Data) != "renewed" {
+		t.Errorf("ConsumeFlow after SaveFlow over an expired flow = %+v, %v; want the renewed flow", got, err)
+	}
+
+	expiredSession := &models.Session{
+		ID:         "session-" + primitive.NewObjectID().Hex(),
+		UserID:     primitive.NewObjectID(),
+		LastSeenAt: time.Now().Add(-time.Hour),
+		ExpiresAt:  time.Now().Add(-time.Second),
+	}
+	if err := stores.Sessions.CreateSession(ctx, expiredSession); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if got, err := stores.Sessions.FindSession(ctx, expiredSession.ID); err != nil || got != nil {
+		t.Errorf("FindSession on an expired session = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func testAuthFlowAttempts(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	flow := newFlow("test_attempts")
+	const maxAttempts = 3
+
+	if err := stores.AuthFlows.ReplaceFlow(ctx, flow); err != nil {
+		t.Fatalf("ReplaceFlow: %v", err)
+	}
+	for i := 1; i <= maxAttempts; i++ {
+		got, err := stores.AuthFlows.UseFlowAttempt(ctx, flow.ID, flow.Kind, maxAttempts)
+		if err != nil {
+			t.Fatalf("UseFlowAttempt %d: %v", i, err)
+		}
+		if got == nil || got.Attempts != i {
+			t.Fatalf("UseFlowAttempt %d returned %+v, want attempts %d", i, got, i)
+		}
+	}
+	if got, err := stores.AuthFlows.UseFlowAttempt(ctx, flow.ID, flow.Kind, maxAttempts); err != nil || got != nil {
+		t.Errorf("UseFlowAttempt after max attempts = %v, %v; want nil, nil", got, err)
+	}
+	if got, err := stores.AuthFlows.UseFlowAttempt(ctx, flow.ID, "other_kind", maxAttempts); err != nil || got != nil {
+		t.Errorf("UseFlowAttempt with another kind = %v, %v; want nil, nil", got, err)
+	}
+
+	// 다시 발급하면 시도 횟수가 초기화된다
+	flow.Data = []byte("new-state")
+	if err := stores.AuthFlows.ReplaceFlow(ctx, flow); err != nil {
+		t.Fatalf("ReplaceFlow: %v", err)
+	}
+	got, err := stores.AuthFlows.UseFlowAttempt(ctx, flow.ID, flow.Kind, maxAttempts)
+	if err != nil {
+		t.Fatalf("UseFlowAttempt after replace: %v", err)
+	}
+	if got == nil || got.Attempts != 1 || string(got.Data) != "new-state" {
+		t.Errorf("UseFlowAttempt after replace returned %+v, want attempts 1 with new data", got)
+	}
+}
+
+func testSessions(t *testing.T, stores repository.Stores) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	now := time.Now()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		session := &models.Session{
+			ID:         "session-" + primitive.NewObjectID().Hex(),
+			UserID:     userID,
+			UserAgent:  "test",
+			LastSeenAt: now.Add(time.Duration(i) * time.Minute),
+			ExpiresAt:  now.Add(time.Hour),
+		}
+		if err := stores.Sessions.CreateSession(ctx, session); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+		ids = append(ids, session.ID)
+	}
+	if err := stores.Sessions.CreateSession(ctx, &models.Session{ID: ids[0], UserID: userID, ExpiresAt: now.Add(time.Hour)}); err == nil {
+		t.Error("CreateSession accepted an existing session ID")
+	}
+
+	active, err := stores.Sessions.ListActiveSessions(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListActiveSessions: %v", err)
+	}
+	if len(active) != 3 || active[0].ID != ids[2] {
+		t.Fatalf("ListActiveSessions returned %d sessions (first %v), want 3 most recent first", len(active), active)
+	}
+
+	if err := stores.Sessions.TouchSession(ctx, ids[0], now.Add(time.Hour), now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("TouchSession: %v", err)
+	}
+	if err := stores.Sessions.TouchSession(ctx, "missing-"+userID.Hex(), now, now.Add(time.Hour)); err == nil {
+		t.Error("TouchSession on a missing session returned no error")
+	}
+
+	revoked, err := stores.Sessions.RevokeUserSessions(ctx, userID, ids[0])
+	if err != nil {
+		t.Fatalf("RevokeUserSessions: %v", err)
+	}
+	if len(revoked) != 2 {
+		t.Errorf("RevokeUserSessions revoked %v, want the 2 other sessions", revoked)
+	}
+	active, err = stores.Sessions.ListActiveSessions(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListActiveSessions: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != ids[0] {
+		t.Errorf("active sessions after revoke = %v, want only %s", active, ids[0])
+	}
+
+	// 폐기된 세션도 조회는 되지만 폐기 시각이 남는다
+	if err := stores.Sessions.RevokeSession(ctx, ids[0]); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	session, err := stores.Sessions.FindSession(ctx, ids[0])
+	if err != nil || session == nil {
+		t.Fatalf("FindSession after revoke = %v, %v", session, err)
+	}
+	if session.RevokedAt == nil {
+		t.Error("revoked session has no revoked_at")
+	}
+}
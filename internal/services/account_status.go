@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// 계정 상태 때문에 거부된 요청
+// 비밀번호, 메일로 받은 코드, 토큰 등으로 계정 소유를 확인한 뒤에만 반환한다.
+var (
+	ErrAccountSuspended = errors.New("account is suspended")
+	ErrAccountBanned    = errors.New("account has been banned")
+	ErrAccountDeleted   = errors.New("account has been deleted")
+)
+
+// accountStatusError active가 아닌 계정의 상태별 에러
+func accountStatusError(user *models.User) error {
+	switch user.AccountStatus() {
+	case models.UserStatusActive:
+		return nil
+	case models.UserStatusSuspended:
+		return ErrAccountSuspended
+	case models.UserStatusBanned:
+		return ErrAccountBanned
+	default:
+		return ErrAccountDeleted
+	}
+}
+
+// IsAccountStatusError 계정 상태 때문에 거부된 에러인지 확인
+func IsAccountStatusError(err error) bool {
+	return errors.Is(err, ErrAccountSuspended) || errors.Is(err, ErrAccountBanned) || errors.Is(err, ErrAccountDeleted)
+}
+
+// skipInactiveAccount 메일을 보내는 요청에서 active가 아닌 계정은 발송하지 않음
+// 요청한 사람이 계정 소유자인지 알 수 없으므로 응답은 계정이 없을 때와 같게 유지한다.
+func skipInactiveAccount(user *models.User, action string) bool {
+	if err := accountStatusError(user); err != nil {
+		log.Printf("skipped %s for %s account %s", action, user.AccountStatus(), user.ID.Hex())
+		return true
+	}
+	return false
+}
+
+// UpdateUserStatus 관리자가 계정 상태 변경
+// active가 아니게 되면 모든 세션과 토큰이 무효가 된다.
+func (s *AuthService) UpdateUserStatus(ctx context.Context, claims *utils.JWTClaim, userID string, req *models.UpdateUserStatusRequest) (*models.User, error) {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.changeUserStatus(ctx, admin, user, req.Status, req.Reason)
+}
+
+// changeUserStatus 상태 전이를 확인하고 변경한 뒤 감사 기록을 남김
+func (s *AuthService) changeUserStatus(ctx context.Context, admin, user *models.User, status models.UserStatus, reason string) (*models.User, error) {
+	if user.ID == admin.ID {
+		return nil, errors.New("cannot change your own account status")
+	}
+
+	if !status.Valid() {
+		return nil, errors.New("invalid status")
+	}
+	reason, err := adminReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	current := user.AccountStatus()
+	if !current.CanTransitionTo(status) {
+		return nil, errors.New("cannot change status from " + string(current) + " to " + string(status))
+	}
+
+	// 조회 후 다른 요청이 상태를 바꿨으면 "user not found"
+	updated, err := s.repo.UpdateStatus(ctx, user.ID, current, status, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != models.UserStatusActive {
+		if err := s.revokeUserSessions(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.recordAudit(ctx, admin, statusAuditAction(status), updated, string(current)+" -> "+string(status), reason)
+	return updated, nil
+}
+
+// statusAuditAction 바뀐 상태에 해당하는 감사 기록 작업
+func statusAuditAction(status models.UserStatus) string {
+	switch status {
+	case models.UserStatusSuspended:
+		return models.AuditActionSuspendUser
+	case models.UserStatusBanned:
+		return models.AuditActionBanUser
+	case models.UserStatusDeleted:
+		return models.AuditActionDeleteUser
+	default:
+		return models.AuditActionReactivateUser
+	}
+}
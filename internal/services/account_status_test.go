@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+func TestUserStatusTransitions(t *testing.T) {
+	statuses := []models.UserStatus{models.UserStatusActive, models.UserStatusSuspended, models.UserStatusBanned, models.UserStatusDeleted}
+
+	// deleted는 되돌릴 수 없고, 같은 상태로의 변경은 허용하지 않는다
+	allowed := map[models.UserStatus][]models.UserStatus{
+		models.UserStatusActive:    {models.UserStatusSuspended, models.UserStatusBanned, models.UserStatusDeleted},
+		models.UserStatusSuspended: {models.UserStatusActive, models.UserStatusBanned, models.UserStatusDeleted},
+		models.UserStatusBanned:    {models.UserStatusActive, models.UserStatusDeleted},
+		models.UserStatusDeleted:   nil,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, next := range allowed[from] {
+				want = want || next == to
+			}
+
+			t.Run(string(from)+" to "+string(to), func(t *testing.T) {
+				if got := from.CanTransitionTo(to); got != want {
+					t.Errorf("CanTransitionTo = %v, want %v", got, want)
+				}
+
+				ts := newTestService(t)
+				admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+				user := ts.createUser(t, "member@example.com", "Password123!", true)
+				if from != models.UserStatusActive {
+					if _, err := ts.stores.Users.UpdateStatus(context.Background(), user.ID, models.UserStatusActive, from, "setup"); err != nil {
+						t.Fatalf("set %s: %v", from, err)
+					}
+				}
+
+				updated, err := ts.UpdateUserStatus(context.Background(), admin, user.ID.Hex(), &models.UpdateUserStatusRequest{Status: to, Reason: "test"})
+				if want {
+					if err != nil {
+						t.Fatalf("update status: %v", err)
+					}
+					if updated.AccountStatus() != to || ts.storedUser(t, user.ID).AccountStatus() != to {
+						t.Errorf("status = %s, want %s", ts.storedUser(t, user.ID).AccountStatus(), to)
+					}
+					return
+				}
+				if err == nil {
+					t.Fatalf("update status = %+v, want error", updated)
+				}
+				if got := ts.storedUser(t, user.ID).AccountStatus(); got != from {
+					t.Errorf("status after rejected change = %s, want %s", got, from)
+				}
+			})
+		}
+	}
+}
+
+func TestUpdateUserStatusValidation(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+
+	tests := map[string]struct {
+		userID string
+		req    models.UpdateUserStatusRequest
+	}{
+		"unknown status":  {user.ID.Hex(), models.UpdateUserStatusRequest{Status: "frozen", Reason: "test"}},
+		"missing reason":  {user.ID.Hex(), models.UpdateUserStatusRequest{Status: models.UserStatusSuspended, Reason: "  "}},
+		"own account":     {admin.UserID, models.UpdateUserStatusRequest{Status: models.UserStatusSuspended, Reason: "test"}},
+		"unknown user id": {"not-an-id", models.UpdateUserStatusRequest{Status: models.UserStatusSuspended, Reason: "test"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ts.UpdateUserStatus(context.Background(), admin, tt.userID, &tt.req); err == nil {
+				t.Error("status change was accepted")
+			}
+		})
+	}
+	if got := ts.storedUser(t, user.ID).AccountStatus(); got != models.UserStatusActive {
+		t.Errorf("status = %s, want active", got)
+	}
+
+	// users:write 권한이 없으면 거부
+	ts.createUser(t, "other@example.com", "Password123!", true)
+	member := ts.claims(t, ts.login(t, "other@example.com", "Password123!"))
+	req := &models.UpdateUserStatusRequest{Status: models.UserStatusSuspended, Reason: "test"}
+	if _, err := ts.UpdateUserStatus(context.Background(), member, user.ID.Hex(), req); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("update by member = %v, want %v", err, ErrPermissionDenied)
+	}
+}
+
+func TestSuspendedUserTokensRejected(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	resp := ts.login(t, "member@example.com", "Password123!")
+
+	// 세션 폐기와 별개로 토큰 검증과 재발급에서 계정 상태를 확인한다
+	if _, err := ts.stores.Users.UpdateStatus(context.Background(), user.ID, models.UserStatusActive, models.UserStatusSuspended, "test"); err != nil {
+		t.Fatalf("suspend: %v", err)
+	}
+
+	if _, err := ts.ValidateToken(context.Background(), resp.Token); !errors.Is(err, ErrAccountSuspended) {
+		t.Errorf("validate token = %v, want %v", err, ErrAccountSuspended)
+	}
+	if _, err := ts.refresh(resp.RefreshToken); !errors.Is(err, ErrAccountSuspended) {
+		t.Errorf("refresh = %v, want %v", err, ErrAccountSuspended)
+	}
+	if _, err := ts.LoginUser(context.Background(), &models.LoginRequest{Email: user.Email, Password: "Password123!"}); !errors.Is(err, ErrAccountSuspended) {
+		t.Errorf("login = %v, want %v", err, ErrAccountSuspended)
+	}
+}
+
+func TestSuspendUserRevokesSessions(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	resp := ts.login(t, "member@example.com", "Password123!")
+
+	req := &models.UpdateUserStatusRequest{Status: models.UserStatusSuspended, Reason: "chargeback"}
+	if _, err := ts.UpdateUserStatus(context.Background(), admin, user.ID.Hex(), req); err != nil {
+		t.Fatalf("suspend: %v", err)
+	}
+	if _, err := ts.ValidateToken(context.Background(), resp.Token); err == nil {
+		t.Error("access token was accepted after suspension")
+	}
+	if _, err := ts.refresh(resp.RefreshToken); err == nil {
+		t.Error("refresh token was accepted after suspension")
+	}
+
+	// 다시 활성화해도 정지 전에 발급된 리프레시 토큰은 폐기된 상태
+	req = &models.UpdateUserStatusRequest{Status: models.UserStatusActive, Reason: "resolved"}
+	if _, err := ts.UpdateUserStatus(context.Background(), admin, user.ID.Hex(), req); err != nil {
+		t.Fatalf("reactivate: %v", err)
+	}
+	if _, err := ts.refresh(resp.RefreshToken); err == nil {
+		t.Error("refresh token issued before suspension was accepted after reactivation")
+	}
+	ts.login(t, "member@example.com", "Password123!")
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// 관리자 사용자 검색 페이지 크기
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+// maxAdminReasonLength 관리자 작업 사유 최대 길이
+const maxAdminReasonLength = 500
+
+// auditLogLimit 사용자별로 조회하는 최근 감사 기록 수
+const auditLogLimit = 100
+
+// ListUsers 관리자: 이메일, 상태, 가입 시각으로 사용자 검색 (최근 가입 순)
+func (s *AuthService) ListUsers(ctx context.Context, claims *utils.JWTClaim, req *models.ListUsersRequest) (*models.UserListResponse, error) {
+	if err := s.requirePermission(ctx, claims, models.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+	if page < 1 {
+		return nil, errors.New("invalid page")
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultUserPageSize
+	}
+	if pageSize < 1 || pageSize > maxUserPageSize {
+		return nil, errors.New("page_size must be between 1 and 100")
+	}
+	if req.Status != "" && !req.Status.Valid() {
+		return nil, errors.New("invalid status")
+	}
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && !req.CreatedBefore.After(*req.CreatedAfter) {
+		return nil, errors.New("created_before must be after created_after")
+	}
+
+	users, total, err := s.repo.SearchUsers(ctx, models.UserFilter{
+		Email:         strings.TrimSpace(req.Email),
+		Status:        req.Status,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		Offset:        (page - 1) * pageSize,
+		Limit:         pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserListResponse{
+		Users:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// GetUser 관리자: 사용자 한 명 조회
+func (s *AuthService) GetUser(ctx context.Context, claims *utils.JWTClaim, userID string) (*models.User, error) {
+	if err := s.requirePermission(ctx, claims, models.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	return s.findUserByHex(ctx, userID)
+}
+
+// SuspendUser 관리자: 계정 일시 정지 (사유 필요)
+func (s *AuthService) SuspendUser(ctx context.Context, claims *utils.JWTClaim, userID, reason string) (*models.User, error) {
+	return s.UpdateUserStatus(ctx, claims, userID, &models.UpdateUserStatusRequest{
+		Status: models.UserStatusSuspended,
+		Reason: reason,
+	})
+}
+
+// UnsuspendUser 관리자: 일시 정지 해제 (차단된 계정은 상태 변경으로만 해제)
+func (s *AuthService) UnsuspendUser(ctx context.Context, claims *utils.JWTClaim, userID, reason string) (*models.User, error) {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AccountStatus() != models.UserStatusSuspended {
+		return nil, errors.New("account is not suspended")
+	}
+	return s.changeUserStatus(ctx, admin, user, models.UserStatusActive, reason)
+}
+
+// DeleteUser 관리자: 계정 삭제 (기록을 위해 deleted 상태로만 바꾸고 되돌릴 수 없다)
+func (s *AuthService) DeleteUser(ctx context.Context, claims *utils.JWTClaim, userID, reason string) (*models.User, error) {
+	return s.UpdateUserStatus(ctx, claims, userID, &models.UpdateUserStatusRequest{
+		Status: models.UserStatusDeleted,
+		Reason: reason,
+	})
+}
+
+// ForcePasswordReset 관리자: 비밀번호 강제 재설정
+// 기존 비밀번호를 쓸 수 없게 바꾸고 모든 세션을 끊은 뒤 재설정 링크를 메일로 보낸다.
+func (s *AuthService) ForcePasswordReset(ctx context.Context, claims *utils.JWTClaim, userID, reason string) error {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionUsersWrite)
+	if err != nil {
+		return err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if accountStatusError(user) != nil {
+		return errors.New("account is not active")
+	}
+	reason, err = adminReason(reason)
+	if err != nil {
+		return err
+	}
+
+	// 아무도 모르는 임의의 비밀번호로 교체 (토큰 버전도 올라간다)
+	hashedPassword, err := utils.HashPassword(utils.GenerateRandomToken(32))
+	if err != nil {
+		return err
+	}
+	if _, err := s.repo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+	if err := s.revokeUserSessions(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, admin, models.AuditActionForcePasswordReset, user, "", reason)
+	return s.sendPasswordResetLink(ctx, user)
+}
+
+// VerifyUserEmail 관리자: 이메일을 인증된 것으로 표시
+func (s *AuthService) VerifyUserEmail(ctx context.Context, claims *utils.JWTClaim, userID, reason string) error {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionUsersWrite)
+	if err != nil {
+		return err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return errors.New("email is already verified")
+	}
+	reason, err = adminReason(reason)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, user.ID); err != nil {
+		return err
+	}
+	s.recordAudit(ctx, admin, models.AuditActionVerifyEmail, user, "", reason)
+	return nil
+}
+
+// ListUserAuditLogs 관리자: 사용자 계정에 대한 최근 관리자 작업 기록
+func (s *AuthService) ListUserAuditLogs(ctx context.Context, claims *utils.JWTClaim, userID string) ([]*models.AuditLog, error) {
+	if err := s.requirePermission(ctx, claims, models.PermissionAuditLogsRead); err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.auditRepo.ListAuditLogsByTarget(ctx, user.ID, auditLogLimit)
+}
+
+// findUserByHex hex ID로 사용자 찾기 (형식이 틀리거나 없으면 "user not found")
+func (s *AuthService) findUserByHex(ctx context.Context, userID string) (*models.User, error) {
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	user, err := s.repo.FindUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// adminReason 관리자 작업 사유 정리 및 확인 (감사 기록에 남기므로 비어 있으면 안 된다)
+func adminReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", errors.New("reason is required")
+	}
+	if len(reason) > maxAdminReasonLength {
+		return "", errors.New("reason is too long")
+	}
+	return reason, nil
+}
+
+// recordAudit 관리자 작업 기록 (admin이 nil이면 설정에 따른 시스템 작업)
+// 작업은 이미 끝났으므로 기록에 실패해도 요청은 실패시키지 않고 로그로 남긴다.
+func (s *AuthService) recordAudit(ctx context.Context, admin *models.User, action string, target *models.User, detail, reason string) {
+	entry := &models.AuditLog{
+		ActorEmail:   models.AuditActorSystem,
+		Action:       action,
+		TargetUserID: target.ID,
+		Detail:       detail,
+		Reason:       reason,
+		IP:           clientInfoFrom(ctx).IP,
+	}
+	if admin != nil {
+		entry.ActorID = admin.ID
+		entry.ActorEmail = admin.Email
+	}
+	if err := s.auditRepo.CreateAuditLog(ctx, entry); err != nil {
+		log.Printf("failed to record audit log %s by %s on %s: %v", action, entry.ActorEmail, target.ID.Hex(), err)
+	}
+}
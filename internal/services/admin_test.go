@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// userEmails 검색 결과의 이메일 (결과 순서)
+func userEmails(users []*models.User) []string {
+	emails := make([]string, len(users))
+	for i, user := range users {
+		emails[i] = user.Email
+	}
+	return emails
+}
+
+func TestListUsersPagination(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	for i := 1; i <= 4; i++ {
+		ts.createUser(t, fmt.Sprintf("member%d@example.com", i), "Password123!", true)
+	}
+
+	// 최근 가입 순으로 나누어 반환하고 전체 개수는 페이지와 관계없이 같다
+	var pages [][]string
+	for page := 1; page <= 3; page++ {
+		resp, err := ts.ListUsers(context.Background(), admin, &models.ListUsersRequest{Page: page, PageSize: 2})
+		if err != nil {
+			t.Fatalf("list page %d: %v", page, err)
+		}
+		if resp.Total != 5 || resp.Page != page || resp.PageSize != 2 {
+			t.Errorf("page %d = total %d, page %d, page size %d", page, resp.Total, resp.Page, resp.PageSize)
+		}
+		pages = append(pages, userEmails(resp.Users))
+	}
+	want := "[[member4@example.com member3@example.com] [member2@example.com member1@example.com] [admin@example.com]]"
+	if got := fmt.Sprint(pages); got != want {
+		t.Errorf("pages = %s, want %s", got, want)
+	}
+
+	// 마지막 페이지 다음은 빈 목록
+	resp, err := ts.ListUsers(context.Background(), admin, &models.ListUsersRequest{Page: 4, PageSize: 2})
+	if err != nil {
+		t.Fatalf("list past the last page: %v", err)
+	}
+	if len(resp.Users) != 0 || resp.Total != 5 {
+		t.Errorf("page past the end = %v, total %d", userEmails(resp.Users), resp.Total)
+	}
+
+	// 기본 페이지 크기
+	resp, err = ts.ListUsers(context.Background(), admin, &models.ListUsersRequest{})
+	if err != nil {
+		t.Fatalf("list with defaults: %v", err)
+	}
+	if resp.Page != 1 || resp.PageSize != defaultUserPageSize || len(resp.Users) != 5 {
+		t.Errorf("defaults = page %d, page size %d, %d users", resp.Page, resp.PageSize, len(resp.Users))
+	}
+}
+
+func TestListUsersFilters(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	ts.createUser(t, "alice@shop.example.com", "Password123!", true)
+	time.Sleep(10 * time.Millisecond)
+	after := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	bob := ts.createUser(t, "bob@shop.example.com", "Password123!", true)
+	ts.createUser(t, "carol@example.com", "Password123!", true)
+	time.Sleep(10 * time.Millisecond)
+	before := time.Now()
+
+	if _, err := ts.stores.Users.UpdateStatus(context.Background(), bob.ID, models.UserStatusActive, models.UserStatusSuspended, "test"); err != nil {
+		t.Fatalf("suspend bob: %v", err)
+	}
+
+	tests := map[string]struct {
+		req  models.ListUsersRequest
+		want string
+	}{
+		"email substring":    {models.ListUsersRequest{Email: " SHOP.example "}, "[bob@shop.example.com alice@shop.example.com]"},
+		"status":             {models.ListUsersRequest{Status: models.UserStatusSuspended}, "[bob@shop.example.com]"},
+		"created after":      {models.ListUsersRequest{CreatedAfter: &after}, "[carol@example.com bob@shop.example.com]"},
+		"created range":      {models.ListUsersRequest{CreatedAfter: &after, CreatedBefore: &before, Email: "example.com"}, "[carol@example.com bob@shop.example.com]"},
+		"combined":           {models.ListUsersRequest{Email: "shop", Status: models.UserStatusActive}, "[alice@shop.example.com]"},
+		"no match":           {models.ListUsersRequest{Email: "nobody"}, "[]"},
+		"status and created": {models.ListUsersRequest{Status: models.UserStatusActive, CreatedAfter: &after}, "[carol@example.com]"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := ts.ListUsers(context.Background(), admin, &tt.req)
+			if err != nil {
+				t.Fatalf("list users: %v", err)
+			}
+			if got := fmt.Sprint(userEmails(resp.Users)); got != tt.want {
+				t.Errorf("users = %s, want %s", got, tt.want)
+			}
+			if resp.Total != int64(len(resp.Users)) {
+				t.Errorf("total = %d, want %d", resp.Total, len(resp.Users))
+			}
+		})
+	}
+}
+
+func TestListUsersRejectsInvalidRequest(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := map[string]models.ListUsersRequest{
+		"negative page":      {Page: -1},
+		"negative page size": {PageSize: -1},
+		"page size too big":  {PageSize: maxUserPageSize + 1},
+		"unknown status":     {Status: "frozen"},
+		"empty range":        {CreatedAfter: &now, CreatedBefore: &earlier},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ts.ListUsers(context.Background(), admin, &req); err == nil {
+				t.Error("invalid request was accepted")
+			}
+		})
+	}
+}
+
+func TestAdminActionsRequirePermission(t *testing.T) {
+	ts := newTestService(t)
+	target := ts.createUser(t, "target@example.com", "Password123!", false)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	member := ts.claims(t, ts.login(t, "member@example.com", "Password123!"))
+	ctx := context.Background()
+	id := target.ID.Hex()
+
+	actions := map[string]func() error{
+		"list users": func() error {
+			_, err := ts.ListUsers(ctx, member, &models.ListUsersRequest{})
+			return err
+		},
+		"get user": func() error {
+			_, err := ts.GetUser(ctx, member, id)
+			return err
+		},
+		"suspend": func() error {
+			_, err := ts.SuspendUser(ctx, member, id, "test")
+			return err
+		},
+		"unsuspend": func() error {
+			_, err := ts.UnsuspendUser(ctx, member, id, "test")
+			return err
+		},
+		"delete": func() error {
+			_, err := ts.DeleteUser(ctx, member, id, "test")
+			return err
+		},
+		"force password reset": func() error { return ts.ForcePasswordReset(ctx, member, id, "test") },
+		"verify email":         func() error { return ts.VerifyUserEmail(ctx, member, id, "test") },
+		"unlock":               func() error { return ts.UnlockUser(ctx, member, id) },
+		"audit log": func() error {
+			_, err := ts.ListUserAuditLogs(ctx, member, id)
+			return err
+		},
+	}
+	for name, action := range actions {
+		t.Run(name, func(t *testing.T) {
+			if err := action(); !errors.Is(err, ErrPermissionDenied) {
+				t.Errorf("error = %v, want %v", err, ErrPermissionDenied)
+			}
+		})
+	}
+
+	stored := ts.storedUser(t, target.ID)
+	if stored.AccountStatus() != models.UserStatusActive || stored.EmailVerified || stored.Password != target.Password {
+		t.Errorf("target was changed by a member: %+v", stored)
+	}
+	if logs, _ := ts.stores.AuditLogs.ListAuditLogsByTarget(ctx, target.ID, 10); len(logs) != 0 {
+		t.Errorf("audit logs = %d, want 0", len(logs))
+	}
+}
+
+func TestAdminReason(t *testing.T) {
+	tests := map[string]struct {
+		reason string
+		want   string
+		ok     bool
+	}{
+		"trimmed":    {"  chargeback  ", "chargeback", true},
+		"max length": {strings.Repeat("a", maxAdminReasonLength), strings.Repeat("a", maxAdminReasonLength), true},
+		"empty":      {"", "", false},
+		"blank":      {" \t\n", "", false},
+		"too long":   {strings.Repeat("a", maxAdminReasonLength+1), "", false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := adminReason(tt.reason)
+			if (err == nil) != tt.ok || got != tt.want {
+				t.Errorf("adminReason = %q, %v; want %q, ok %v", got, err, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAdminActionsRequireReason(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	target := ts.createUser(t, "target@example.com", "Password123!", false)
+	ctx := context.Background()
+	id := target.ID.Hex()
+
+	actions := map[string]func() error{
+		"suspend": func() error {
+			_, err := ts.SuspendUser(ctx, admin, id, " ")
+			return err
+		},
+		"delete": func() error {
+			_, err := ts.DeleteUser(ctx, admin, id, "")
+			return err
+		},
+		"force password reset": func() error { return ts.ForcePasswordReset(ctx, admin, id, "") },
+		"verify email":         func() error { return ts.VerifyUserEmail(ctx, admin, id, "") },
+	}
+	for name, action := range actions {
+		t.Run(name, func(t *testing.T) {
+			if err := action(); err == nil || err.Error() != "reason is required" {
+				t.Errorf("error = %v, want reason is required", err)
+			}
+		})
+	}
+	if logs, _ := ts.stores.AuditLogs.ListAuditLogsByTarget(ctx, target.ID, 10); len(logs) != 0 {
+		t.Errorf("audit logs = %d, want 0", len(logs))
+	}
+}
+
+func TestAdminActionsWriteOneAuditRecord(t *testing.T) {
+	tests := map[string]struct {
+		setup  func(ts *testService, target *models.User) error
+		action func(ts *testService, admin *utils.JWTClaim, id string) error
+		want   string
+		detail string
+	}{
+		"suspend": {
+			action: func(ts *testService, admin *utils.JWTClaim, id string) error {
+				_, err := ts.SuspendUser(context.Background(), admin, id, "chargeback")
+				return err
+			},
+			want:   models.AuditActionSuspendUser,
+			detail: "active -> suspended",
+		},
+		"unsuspend": {
+			setup: func(ts *testService, target *models.User) error {
+				_, err := ts.stores.Users.UpdateStatus(context.Background(), target.ID, models.UserStatusActive, models.UserStatusSuspended, "setup")
+				return err
+			},
+			action: func(ts *testService, admin *utils.JWTClaim, id string) error {
+				_, err := ts.UnsuspendUser(context.Background(), admin, id, "chargeback")
+				return err
+			},
+			want:   models.AuditActionReactivateUser,
+			detail: "suspended -> active",
+		},
+		"ban": {
+			action: func(ts *testService, admin *utils.JWTClaim, id string) error {
+				_, err := ts.UpdateUserStatus(context.Background(), admin, id, &models.UpdateUserStatusRequest{Status: models.UserStatusBanned, Reason: "chargeback"})
+				return err
+			},
+			want:   models.AuditActionBanUser,
+			detail: "active -> banned",
+		},
+		"delete": {
+			action: func(ts *testService, admin *utils.JWTClaim, id string) error {
+				_, err := ts.DeleteUser(context.Background(), admin, id, "chargeback")
+				return err
+			},
+			want:   models.AuditActionDeleteUser,
+			detail: "active -> deleted",
+		},
+		"force password reset": {
+			action: func(ts *testService, admin *utils.JWTClaim, id string) error {
+				return ts.ForcePasswordReset(context.Background(), admin, id, "chargeback")
+			},
+			want: models.AuditActionForcePasswordReset,
+		},
+		"verify email": {
+			action: func(ts *testService, admin *utils.JWTClaim, id string) error {
+				return ts.VerifyUserEmail(context.Background(), admin, id, "chargeback")
+			},
+			want: models.AuditActionVerifyEmail,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := newTestService(t)
+			admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+			target := ts.createUser(t, "target@example.com", "Password123!", false)
+			if tt.setup != nil {
+				if err := tt.setup(ts, target); err != nil {
+					t.Fatalf("setup: %v", err)
+				}
+			}
+
+			if err := tt.action(ts, admin, target.ID.Hex()); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+
+			logs, err := ts.stores.AuditLogs.ListAuditLogsByTarget(context.Background(), target.ID, 10)
+			if err != nil {
+				t.Fatalf("list audit logs: %v", err)
+			}
+			if len(logs) != 1 {
+				t.Fatalf("audit logs = %d, want 1", len(logs))
+			}
+			entry := logs[0]
+			if entry.ActorID.Hex() != admin.UserID || entry.ActorEmail != "admin@example.com" || entry.TargetUserID != target.ID {
+				t.Errorf("audit log actor %s (%s), target %s; want admin %s and target %s",
+					entry.ActorID.Hex(), entry.ActorEmail, entry.TargetUserID.Hex(), admin.UserID, target.ID.Hex())
+			}
+			if entry.Action != tt.want || entry.Detail != tt.detail || entry.Reason != "chargeback" {
+				t.Errorf("audit log = %+v, want action %s, detail %q, reason chargeback", entry, tt.want, tt.detail)
+			}
+		})
+	}
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	target := ts.createUser(t, "target@example.com", "Password123!", true)
+	resp := ts.login(t, "target@example.com", "Password123!")
+
+	if err := ts.ForcePasswordReset(context.Background(), admin, target.ID.Hex(), "account takeover report"); err != nil {
+		t.Fatalf("force password reset: %v", err)
+	}
+
+	// 기존 비밀번호와 토큰은 쓸 수 없고 재설정 메일이 발송된다
+	if _, err := ts.LoginUser(context.Background(), &models.LoginRequest{Email: target.Email, Password: "Password123!"}); err == nil {
+		t.Error("old password still signs in")
+	}
+	ts.requireRevoked(t, resp)
+	if subjects := ts.mail.subjects(); len(subjects) != 1 || !strings.Contains(strings.ToLower(subjects[0]), "reset") {
+		t.Errorf("sent %v, want a password reset email", subjects)
+	}
+	if ts.storedUser(t, target.ID).ResetTokenHash == "" {
+		t.Error("no reset token was stored")
+	}
+}
+
+func TestVerifyUserEmail(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.claims(t, ts.loginAdmin(t, "admin@example.com"))
+	target := ts.createUser(t, "target@example.com", "Password123!", false)
+
+	if err := ts.VerifyUserEmail(context.Background(), admin, target.ID.Hex(), "confirmed by phone"); err != nil {
+		t.Fatalf("verify email: %v", err)
+	}
+	if !ts.storedUser(t, target.ID).EmailVerified {
+		t.Error("email was not marked verified")
+	}
+
+	// 이미 인증된 이메일은 다시 처리하지 않고 기록도 남기지 않는다
+	if err := ts.VerifyUserEmail(context.Background(), admin, target.ID.Hex(), "confirmed by phone"); err == nil {
+		t.Error("already verified email was verified again")
+	}
+	if logs, _ := ts.stores.AuditLogs.ListAuditLogsByTarget(context.Background(), target.ID, 10); len(logs) != 1 {
+		t.Errorf("audit logs = %d, want 1", len(logs))
+	}
+}
@@ -4,28 +4,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/go-webauthn/webauthn/webauthn"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
-	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/mongodb"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/email"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/federation"
 	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
 )
 
 type AuthService struct {
-	repo      *mongodb.AuthRepository
-	jwtSecret string
-	jwtExpiry int
-	config    *config.Config // WebAppURL 등의 설정을 위해 필요
+	repo              repository.UserStore
+	refreshRepo       repository.RefreshTokenStore
+	revokedRepo       repository.RevokedTokenStore
+	credentialRepo    repository.WebAuthnCredentialStore
+	flowRepo          repository.AuthFlowStore
+	clientRepo        repository.OAuthClientStore
+	serviceClientRepo repository.ServiceClientStore
+	identityRepo      repository.FederatedIdentityStore
+	sessionRepo       repository.SessionStore
+	auditRepo         repository.AuditLogStore
+	emailService      *email.EmailService
+	webAuthn          *webauthn.WebAuthn
+	federated         map[string]*federation.Provider
+	keys              TokenKeys
+	accessTTL         time.Duration
+	refreshTTL        time.Duration
+	config            *config.Config // WebAppURL 등의 설정을 위해 필요
 }
 
 // NewAuthService AuthService 생성자
-func NewAuthService(repo *mongodb.AuthRepository, config *config.Config) *AuthService {
+func NewAuthService(stores repository.Stores, emailService *email.EmailService, webAuthn *webauthn.WebAuthn, keys TokenKeys, federated map[string]*federation.Provider, config *config.Config) *AuthService {
 	return &AuthService{
-		repo:      repo,
-		jwtSecret: config.JWTSecret,
-		jwtExpiry: config.JWTExpires,
-		config:    config,
+		repo:              stores.Users,
+		refreshRepo:       stores.RefreshTokens,
+		revokedRepo:       stores.RevokedTokens,
+		credentialRepo:    stores.WebAuthnCredentials,
+		flowRepo:          stores.AuthFlows,
+		clientRepo:        stores.OAuthClients,
+		serviceClientRepo: stores.ServiceClients,
+		identityRepo:      stores.FederatedIdentities,
+		sessionRepo:       stores.Sessions,
+		auditRepo:         stores.AuditLogs,
+		emailService:      emailService,
+		webAuthn:          webAuthn,
+		federated:         federated,
+		keys:              keys,
+		accessTTL:         config.AccessTokenLifetime(),
+		refreshTTL:        time.Duration(config.RefreshTokenTTL) * time.Hour,
+		config:            config,
 	}
 }
 
@@ -46,6 +78,7 @@ func (s *AuthService) RegisterUser(ctx context.Context, req *models.RegisterRequ
 	user := &models.User{
 		Email:    req.Email,
 		Password: hashedPassword,
+		Roles:    []string{models.RoleBuyer},
 	}
 
 	return s.repo.CreateUser(ctx, user)
@@ -59,32 +92,201 @@ func (s *AuthService) LoginUser(ctx context.Context, req *models.LoginRequest) (
 		return nil, err
 	}
 	if user == nil {
+		// 계정이 없어도 같은 시간이 걸리도록 비교는 수행
+		checkDummyPassword(req.Password)
 		return nil, errors.New("invalid email or password")
 	}
 
-	// 비밀번호 확인
-	if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+	// 비밀번호 확인 (잠긴 계정도 같은 응답과 시간을 유지하기 위해 비교는 수행)
+	passwordErr := utils.CheckPassword(req.Password, user.Password)
+	if user.IsLocked(time.Now()) {
+		return nil, errors.New("invalid email or password")
+	}
+	if passwordErr != nil {
+		go s.recordFailedLogin(context.WithoutCancel(ctx), user.ID)
 		return nil, errors.New("invalid email or password")
 	}
 
+	// 비밀번호를 확인한 뒤에만 정지, 차단 여부를 알려준다 (삭제된 계정은 없는 계정과 같은 응답)
+	if err := accountStatusError(user); err != nil {
+		if errors.Is(err, ErrAccountDeleted) {
+			return nil, errors.New("invalid email or password")
+		}
+		return nil, err
+	}
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		if err := s.repo.ResetFailedLogins(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 미인증 이메일이면 정책과 관계없이 인증 메일을 다시 보낸다
+	verificationSent := false
+	if !user.EmailVerified {
+		verificationSent = s.offerVerificationEmail(ctx, user)
+		if err := s.checkEmailVerified(user); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2단계 인증 수단(TOTP, 패스키)이 있으면 챌린지 반환
+	methods, err := s.secondFactorMethods(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *models.LoginResponse
+	if len(methods) > 0 {
+		response, err = s.mfaChallenge(ctx, user, methods)
+	} else {
+		response, err = s.finishLogin(ctx, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.VerificationSent = verificationSent
+	return response, nil
+}
+
+// finishLogin 인증이 끝난 사용자에게 새 토큰 패밀리로 토큰 발급
+func (s *AuthService) finishLogin(ctx context.Context, user *models.User) (*models.LoginResponse, error) {
+	if err := accountStatusError(user); err != nil {
+		return nil, err
+	}
+
 	// 마지막 로그인 시간 업데이트
 	if err := s.repo.UpdateLastLogin(ctx, user.ID); err != nil {
 		// 로깅만 하고 계속 진행
 		// TODO: 로깅 추가
 	}
 
+	return s.issueTokens(ctx, user, utils.GenerateRandomToken(16))
+}
+
+// RefreshToken 리프레시 토큰 회전
+// 이미 회전된 토큰이 다시 사용되면 탈취로 보고 패밀리 전체를 폐기한다.
+func (s *AuthService) RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.LoginResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, errors.New("refresh token is required")
+	}
+
+	// OAuth 클라이언트에 발급된 토큰은 /oauth/token으로만 회전
+	token, user, err := s.rotateRefreshToken(ctx, req.RefreshToken, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, token.FamilyID)
+}
+
+// rotateRefreshToken 리프레시 토큰을 사용 처리하고 토큰과 사용자 반환
+// clientID가 다른 토큰(자체 로그인 토큰은 "")은 사용 처리하지 않고 거부한다.
+func (s *AuthService) rotateRefreshToken(ctx context.Context, refreshToken, clientID string) (*models.RefreshToken, *models.User, error) {
+	token, err := s.refreshRepo.FindRefreshTokenByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, nil, err
+	}
+	if token == nil || token.ClientID != clientID || token.RevokedAt != nil || time.Now().After(token.ExpiresAt) {
+		return nil, nil, errors.New("invalid or expired refresh token")
+	}
+
+	// 재사용 감지
+	if token.RotatedAt != nil {
+		if err := s.refreshRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("refresh token reuse detected")
+	}
+
+	// 동시 요청 중 하나만 회전에 성공
+	rotated, err := s.refreshRepo.MarkRefreshTokenRotated(ctx, token.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rotated {
+		if err := s.refreshRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("refresh token reuse detected")
+	}
+
+	user, err := s.repo.FindUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, errors.New("invalid or expired refresh token")
+	}
+	if err := accountStatusError(user); err != nil {
+		return nil, nil, err
+	}
+
+	return token, user, nil
+}
+
+// issueTokens 액세스 토큰과 리프레시 토큰 발급 (패밀리 ID가 곧 세션 ID)
+// 이메일 인증 정책은 발급할 때마다 현재 인증 여부로 적용한다.
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, familyID string) (*models.LoginResponse, error) {
+	if err := s.checkEmailVerified(user); err != nil {
+		return nil, err
+	}
+	if err := s.recordSession(ctx, user, familyID); err != nil {
+		return nil, err
+	}
+
 	// JWT 토큰 생성
-	token, err := utils.GenerateJWT(user.ID.Hex(), user.Email, s.jwtSecret, s.jwtExpiry)
+	emailVerified := user.EmailVerified
+	claims := &utils.JWTClaim{
+		UserID:        user.ID.Hex(),
+		Email:         user.Email,
+		TokenVersion:  user.TokenVersion,
+		TokenUse:      utils.TokenUseAccess,
+		SessionID:     familyID,
+		EmailVerified: &emailVerified,
+	}
+	// 제한된 토큰에는 역할과 권한을 넣지 않아 다른 서비스에서도 쓸 수 없게 한다
+	if !emailVerified && s.config.EmailVerificationPolicy == config.EmailVerificationRestrict {
+		claims.Scope = models.ScopeUnverified
+	} else {
+		claims.Roles = user.AccountRoles()
+		claims.Permissions = user.EffectivePermissions()
+	}
+	accessToken, err := utils.GenerateJWT(claims, s.keys.SigningKey(), s.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.createRefreshToken(ctx, &models.RefreshToken{
+		FamilyID: familyID,
+		UserID:   user.ID,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.LoginResponse{
-		Token:     token,
-		ExpiresIn: s.jwtExpiry * 3600, // 시간을 초로 변환
+		Token:            accessToken,
+		ExpiresIn:        int(s.accessTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int(s.refreshTTL.Seconds()),
 	}, nil
 }
 
+// createRefreshToken 리프레시 토큰 생성 후 원문 반환 (저장은 해시만, 원문은 응답으로만 전달)
+func (s *AuthService) createRefreshToken(ctx context.Context, token *models.RefreshToken) (string, error) {
+	refreshToken := utils.GenerateRandomToken(32)
+	if refreshToken == "" {
+		return "", errors.New("failed to generate refresh token")
+	}
+
+	token.TokenHash = utils.HashToken(refreshToken)
+	token.ExpiresAt = time.Now().Add(s.refreshTTL)
+	if err := s.refreshRepo.CreateRefreshToken(ctx, token); err != nil {
+		return "", err
+	}
+	return refreshToken, nil
+}
+
 func (s *AuthService) InitiatePasswordReset(ctx context.Context, req *models.ForgotPasswordRequest) error {
 	// 사용자 조회
 	user, err := s.repo.FindUserByEmail(ctx, req.Email)
@@ -94,22 +296,44 @@ func (s *AuthService) InitiatePasswordReset(ctx context.Context, req *models.For
 	if user == nil {
 		return nil // 보안을 위해 사용자가 없어도 성공으로 처리
 	}
+	if skipInactiveAccount(user, "password reset") {
+		return nil
+	}
+
+	if req.Mode == models.DeliveryModeOTP {
+		return s.sendEmailOTP(ctx, user, flowEmailOTPResetPassword, "Your Prisma Market password reset code", "reset your password")
+	}
 
+	return s.sendPasswordResetLink(ctx, user)
+}
+
+// sendPasswordResetLink 재설정 토큰을 만들어 링크 메일 발송
+func (s *AuthService) sendPasswordResetLink(ctx context.Context, user *models.User) error {
 	// 재설정 토큰 생성
 	token := utils.GenerateRandomToken(32)
+	if token == "" {
+		return errors.New("failed to generate reset token")
+	}
 	expiry := time.Now().Add(1 * time.Hour)
 
-	// 토큰 저장
-	if err := s.repo.UpdateResetToken(ctx, user.Email, token, expiry); err != nil {
+	// 토큰은 해시만 저장하고 원문은 메일로만 전달
+	if err := s.repo.UpdateResetToken(ctx, user.Email, utils.HashToken(token), expiry); err != nil {
 		return err
 	}
 
 	// 이메일 발송
 	resetURL := fmt.Sprintf("%s/reset-password", s.config.WebAppURL)
-	return s.emailService.SendPasswordResetEmail(user.Email, token, resetURL)
+	return s.emailService.SendPasswordResetEmail(ctx, user.Email, token, resetURL)
 }
 
+// ResetPassword 메일로 받은 링크 토큰 또는 이메일과 숫자 코드로 비밀번호 재설정
 func (s *AuthService) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
+	if req.Code == "" {
+		if err := utils.ValidatePasswordResetToken(req.Token); err != nil {
+			return errors.New("invalid or expired reset token")
+		}
+	}
+
 	// 비밀번호 유효성 검사
 	if err := utils.ValidatePassword(req.NewPassword); err != nil {
 		return err
@@ -121,41 +345,239 @@ func (s *AuthService) ResetPassword(ctx context.Context, req *models.ResetPasswo
 		return err
 	}
 
-	// 비밀번호 업데이트
-	return s.repo.ResetPassword(ctx, req.Token, hashedPassword)
+	// 비밀번호 업데이트 (토큰 버전이 올라가 기존 액세스 토큰은 무효)
+	var user *models.User
+	if req.Code != "" {
+		user, err = s.resetPasswordWithCode(ctx, req, hashedPassword)
+	} else {
+		user, err = s.repo.ResetPassword(ctx, utils.HashToken(req.Token), hashedPassword)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 기존 세션과 리프레시 토큰도 폐기
+	return s.revokeUserSessions(ctx, user.ID)
+}
+
+// ChangePassword 로그인한 사용자의 비밀번호 변경
+// 다른 기기의 세션은 모두 끊고, 요청한 클라이언트에는 새 토큰을 발급한다.
+func (s *AuthService) ChangePassword(ctx context.Context, claims *utils.JWTClaim, req *models.ChangePasswordRequest) (*models.LoginResponse, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// 현재 비밀번호 확인
+	if err := utils.CheckPassword(req.CurrentPassword, user.Password); err != nil {
+		return nil, errors.New("current password is incorrect")
+	}
+
+	// 새 비밀번호 유효성 검사
+	if err := utils.ValidatePassword(req.NewPassword); err != nil {
+		return nil, err
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return nil, errors.New("new password must be different from the current password")
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// 비밀번호 변경 (토큰 버전이 올라가 기존 액세스 토큰은 무효)
+	user, err = s.repo.UpdatePassword(ctx, user.ID, hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.revokeUserSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	// 알림 메일 실패는 변경 결과에 영향을 주지 않음
+	resetURL := fmt.Sprintf("%s/forgot-password", s.config.WebAppURL)
+	if err := s.emailService.SendPasswordChangedEmail(ctx, user.Email, resetURL); err != nil {
+		log.Printf("failed to send password changed email: %v", err)
+	}
+
+	return s.issueTokens(ctx, user, utils.GenerateRandomToken(16))
 }
 
-func (s *AuthService) SendVerificationEmail(ctx context.Context, email string) error {
+func (s *AuthService) SendVerificationEmail(ctx context.Context, req *models.ResendVerificationRequest) error {
 	// 사용자 조회
-	user, err := s.repo.FindUserByEmail(ctx, email)
+	user, err := s.repo.FindUserByEmail(ctx, req.Email)
 	if err != nil {
 		return err
 	}
 	if user == nil {
 		return errors.New("user not found")
 	}
+	if skipInactiveAccount(user, "verification email") {
+		return nil
+	}
 
 	// 이미 인증된 경우
 	if user.EmailVerified {
 		return errors.New("email already verified")
 	}
 
+	if req.Mode == models.DeliveryModeOTP {
+		return s.sendEmailOTP(ctx, user, flowEmailOTPVerifyEmail, "Your Prisma Market verification code", "verify your email address")
+	}
+
+	// 직접 요청한 경우에도 로그인할 때 바로 다시 보내지 않도록 대기 시간 시작
+	if err := s.flowRepo.ReplaceFlow(ctx, s.verificationCooldownFlow(user)); err != nil {
+		return err
+	}
+	return s.sendVerificationLink(ctx, user)
+}
+
+// sendVerificationLink 인증 링크를 새로 발급해 메일로 발송 (이전 링크는 무효)
+func (s *AuthService) sendVerificationLink(ctx context.Context, user *models.User) error {
 	// 인증 토큰 생성
 	token := utils.GenerateRandomToken(32)
+	if token == "" {
+		return errors.New("failed to generate verification token")
+	}
 	expiry := time.Now().Add(24 * time.Hour)
 
-	// 토큰 저장
-	if err := s.repo.UpdateEmailVerificationToken(ctx, user.ID, token, expiry); err != nil {
+	// 토큰은 해시만 저장하고 원문은 메일로만 전달
+	if err := s.repo.UpdateEmailVerificationToken(ctx, user.ID, utils.HashToken(token), expiry); err != nil {
 		return err
 	}
 
 	// 이메일 발송
 	verifyURL := fmt.Sprintf("%s/verify-email", s.config.WebAppURL)
-	return s.emailService.SendVerificationEmail(user.Email, token, verifyURL)
+	return s.emailService.SendVerificationEmail(ctx, user.Email, token, verifyURL)
 }
 
 func (s *AuthService) VerifyEmail(ctx context.Context, req *models.VerifyEmailRequest) error {
-	return s.repo.VerifyEmail(ctx, req.Token)
+	if req.Code != "" {
+		return s.verifyEmailWithCode(ctx, req)
+	}
+
+	if err := utils.ValidateVerificationToken(req.Token); err != nil {
+		return errors.New("invalid or expired verification token")
+	}
+	return s.repo.VerifyEmail(ctx, utils.HashToken(req.Token))
+}
+
+// Logout 현재 액세스 토큰과 세션 폐기 (리프레시 토큰이 있으면 해당 패밀리도 폐기)
+func (s *AuthService) Logout(ctx context.Context, claims *utils.JWTClaim, req *models.LogoutRequest) error {
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+	if claims.SessionID != "" {
+		if err := s.endSession(ctx, claims.SessionID); err != nil {
+			return err
+		}
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	token, err := s.refreshRepo.FindRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+	// 다른 사용자의 토큰은 건드리지 않는다
+	if token == nil || token.UserID.Hex() != claims.UserID {
+		return nil
+	}
+	return s.endSession(ctx, token.FamilyID)
+}
+
+// LogoutAll 사용자의 모든 토큰 폐기
+func (s *AuthService) LogoutAll(ctx context.Context, claims *utils.JWTClaim) error {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return errors.New("invalid token")
+	}
+
+	if err := s.repo.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		return err
+	}
+	if err := s.revokeUserSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	// 같은 초에 발급된 현재 토큰도 확실히 폐기
+	return s.revokeAccessToken(ctx, claims)
+}
+
+// ValidateToken 액세스 토큰 검증
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*utils.JWTClaim, error) {
+	return utils.ValidateJWT(ctx, tokenString, s.keys, s)
+}
+
+// ValidateClaims 액세스 토큰의 폐기 여부 확인 (utils.ClaimsValidator)
+func (s *AuthService) ValidateClaims(ctx context.Context, claims *utils.JWTClaim) error {
+	// MFA 챌린지 토큰 등 다른 용도의 토큰 거부
+	if claims.TokenUse != "" && claims.TokenUse != utils.TokenUseAccess && claims.TokenUse != utils.TokenUseService {
+		return errors.New("invalid token type")
+	}
+
+	if claims.ID != "" {
+		revoked, err := s.revokedRepo.IsTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return errors.New("token has been revoked")
+		}
+	}
+
+	// 서비스 토큰은 사용자 대신 클라이언트 확인
+	if claims.TokenUse == utils.TokenUseService {
+		return s.validateServiceClaims(ctx, claims)
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return err
+	}
+	if err := accountStatusError(user); err != nil {
+		return err
+	}
+
+	// 비밀번호 변경 등으로 보안 스탬프가 바뀐 경우
+	if claims.TokenVersion != user.TokenVersion {
+		return errors.New("token has been revoked")
+	}
+
+	// JWT iat는 초 단위이므로 무효화 시각도 초 단위로 비교
+	if user.TokensValidAfter != nil && claims.IssuedAt != nil &&
+		claims.IssuedAt.Time.Before(user.TokensValidAfter.Truncate(time.Second)) {
+		return errors.New("token has been revoked")
+	}
+
+	// 원격 로그아웃된 세션
+	return s.validateSession(ctx, claims)
+}
+
+// revokeAccessToken 액세스 토큰을 만료 시각까지 폐기 목록에 추가
+func (s *AuthService) revokeAccessToken(ctx context.Context, claims *utils.JWTClaim) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return errors.New("invalid token")
+	}
+
+	return s.revokedRepo.RevokeToken(ctx, &models.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+}
+
+// JWKS 토큰 검증용 공개키 목록
+func (s *AuthService) JWKS() *utils.JWKS {
+	return s.keys.JWKS()
 }
 
 // 입력값 검증 함수
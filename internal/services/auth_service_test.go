@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// refresh 리프레시 토큰 회전
+func (ts *testService) refresh(refreshToken string) (*models.LoginResponse, error) {
+	return ts.RefreshToken(context.Background(), &models.RefreshTokenRequest{RefreshToken: refreshToken})
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	login := ts.login(t, "member@example.com", "Password123!")
+
+	refreshed, err := ts.refresh(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Fatalf("refresh token was not rotated: %q", refreshed.RefreshToken)
+	}
+
+	// 저장소에는 해시만 남는다
+	stored, err := ts.stores.RefreshTokens.FindRefreshTokenByHash(context.Background(), utils.HashToken(login.RefreshToken))
+	if err != nil || stored == nil {
+		t.Fatalf("find rotated token: %+v, %v", stored, err)
+	}
+	if stored.TokenHash == login.RefreshToken || stored.RotatedAt == nil {
+		t.Errorf("rotated token = %+v", stored)
+	}
+
+	// 회전해도 같은 패밀리를 이어간다
+	next, err := ts.stores.RefreshTokens.FindRefreshTokenByHash(context.Background(), utils.HashToken(refreshed.RefreshToken))
+	if err != nil || next == nil {
+		t.Fatalf("find new token: %+v, %v", next, err)
+	}
+	if next.FamilyID != stored.FamilyID {
+		t.Errorf("family after refresh = %q, want %q", next.FamilyID, stored.FamilyID)
+	}
+
+	if _, err := ts.refresh(refreshed.RefreshToken); err != nil {
+		t.Errorf("refresh with the rotated token: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	login := ts.login(t, "member@example.com", "Password123!")
+	other := ts.login(t, "member@example.com", "Password123!")
+
+	refreshed, err := ts.refresh(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	// 이미 회전된 토큰이 다시 오면 탈취로 보고 패밀리 전체를 폐기
+	if _, err := ts.refresh(login.RefreshToken); err == nil || err.Error() != "refresh token reuse detected" {
+		t.Fatalf("reused refresh token = %v, want reuse detected", err)
+	}
+	if _, err := ts.refresh(refreshed.RefreshToken); err == nil {
+		t.Error("latest token of the revoked family still refreshes")
+	}
+
+	// 다른 로그인 세션은 영향받지 않는다
+	if _, err := ts.refresh(other.RefreshToken); err != nil {
+		t.Errorf("refresh in another family: %v", err)
+	}
+}
+
+func TestRefreshTokenConcurrentRotation(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	login := ts.login(t, "member@example.com", "Password123!")
+
+	const workers = 8
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ts.refresh(login.RefreshToken); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("%d concurrent refreshes succeeded, want exactly 1", succeeded)
+	}
+}
+
+func TestRefreshTokenRejectsInvalidTokens(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	ctx := context.Background()
+
+	// 만료된 토큰과 OAuth 클라이언트에 발급된 토큰은 /auth/refresh로 회전할 수 없다
+	tokens := map[string]*models.RefreshToken{
+		"expired":      {FamilyID: "family-expired", UserID: user.ID, ExpiresAt: time.Now().Add(-time.Minute)},
+		"oauth client": {FamilyID: "family-client", UserID: user.ID, ClientID: "client-1", ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			raw := utils.GenerateRandomToken(32)
+			token.TokenHash = utils.HashToken(raw)
+			if err := ts.stores.RefreshTokens.CreateRefreshToken(ctx, token); err != nil {
+				t.Fatalf("create refresh token: %v", err)
+			}
+
+			if _, err := ts.refresh(raw); err == nil || err.Error() != "invalid or expired refresh token" {
+				t.Errorf("refresh = %v, want invalid or expired refresh token", err)
+			}
+			stored, _ := ts.stores.RefreshTokens.FindRefreshTokenByHash(ctx, token.TokenHash)
+			if stored == nil || stored.RotatedAt != nil {
+				t.Errorf("rejected token was marked rotated: %+v", stored)
+			}
+		})
+	}
+
+	if _, err := ts.refresh("unknown-token"); err == nil {
+		t.Error("unknown refresh token was accepted")
+	}
+}
+
+// requireRevoked 토큰 버전이 바뀌어 액세스 토큰과 리프레시 토큰을 모두 쓸 수 없는지 확인
+func (ts *testService) requireRevoked(t *testing.T, resp *models.LoginResponse) {
+	t.Helper()
+
+	if _, err := ts.ValidateToken(context.Background(), resp.Token); err == nil || err.Error() != "token has been revoked" {
+		t.Errorf("old access token = %v, want token has been revoked", err)
+	}
+	if _, err := ts.refresh(resp.RefreshToken); err == nil {
+		t.Error("old refresh token still refreshes")
+	}
+}
+
+func TestPasswordResetInvalidatesTokens(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	first := ts.login(t, "member@example.com", "Password123!")
+	second := ts.login(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	token := utils.GenerateRandomToken(32)
+	if err := ts.stores.Users.UpdateResetToken(ctx, user.Email, utils.HashToken(token), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("update reset token: %v", err)
+	}
+	if err := ts.ResetPassword(ctx, &models.ResetPasswordRequest{Token: token, NewPassword: "NewPassword456!"}); err != nil {
+		t.Fatalf("reset password: %v", err)
+	}
+
+	stored, err := ts.stores.Users.FindUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if stored.TokenVersion != user.TokenVersion+1 {
+		t.Errorf("token version = %d, want %d", stored.TokenVersion, user.TokenVersion+1)
+	}
+	ts.requireRevoked(t, first)
+	ts.requireRevoked(t, second)
+
+	// 새 비밀번호로 받은 토큰은 새 버전으로 유효
+	resp := ts.login(t, "member@example.com", "NewPassword456!")
+	if claims := ts.claims(t, resp); claims.TokenVersion != stored.TokenVersion {
+		t.Errorf("new token version = %d, want %d", claims.TokenVersion, stored.TokenVersion)
+	}
+}
+
+func TestChangePasswordInvalidatesOtherTokens(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	current := ts.login(t, "member@example.com", "Password123!")
+	other := ts.login(t, "member@example.com", "Password123!")
+
+	resp, err := ts.ChangePassword(context.Background(), ts.claims(t, current), &models.ChangePasswordRequest{
+		CurrentPassword: "Password123!",
+		NewPassword:     "NewPassword456!",
+	})
+	if err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+
+	// 변경을 요청한 기기를 포함해 이전 토큰은 모두 무효, 응답으로 받은 새 토큰만 유효
+	ts.requireRevoked(t, current)
+	ts.requireRevoked(t, other)
+	if _, err := ts.ValidateToken(context.Background(), resp.Token); err != nil {
+		t.Errorf("new access token: %v", err)
+	}
+	if _, err := ts.refresh(resp.RefreshToken); err != nil {
+		t.Errorf("new refresh token: %v", err)
+	}
+}
+
+func TestTokenVersionRejectsStaleClaims(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	login := ts.login(t, "member@example.com", "Password123!")
+
+	// 비밀번호와 관계없이 버전만 올라가도 이전 토큰은 무효
+	if err := ts.stores.Users.BumpTokenVersion(context.Background(), user.ID); err != nil {
+		t.Fatalf("bump token version: %v", err)
+	}
+	if _, err := ts.ValidateToken(context.Background(), login.Token); err == nil || err.Error() != "token has been revoked" {
+		t.Errorf("stale access token = %v, want token has been revoked", err)
+	}
+
+	// 리프레시하면 현재 버전으로 다시 발급된다
+	refreshed, err := ts.refresh(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if claims := ts.claims(t, refreshed); claims.TokenVersion != user.TokenVersion+1 {
+		t.Errorf("refreshed token version = %d, want %d", claims.TokenVersion, user.TokenVersion+1)
+	}
+}
+
+// recordingRevokedTokens 폐기 목록에 추가된 항목을 기록하는 RevokedTokenStore
+type recordingRevokedTokens struct {
+	repository.RevokedTokenStore
+	mu      sync.Mutex
+	revoked []*models.RevokedToken
+}
+
+func (r *recordingRevokedTokens) RevokeToken(ctx context.Context, token *models.RevokedToken) error {
+	r.mu.Lock()
+	r.revoked = append(r.revoked, token)
+	r.mu.Unlock()
+	return r.RevokedTokenStore.RevokeToken(ctx, token)
+}
+
+func TestLogout(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	current := ts.login(t, "member@example.com", "Password123!")
+	other := ts.login(t, "member@example.com", "Password123!")
+	claims := ts.claims(t, current)
+	ctx := context.Background()
+
+	recorder := &recordingRevokedTokens{RevokedTokenStore: ts.revokedRepo}
+	ts.revokedRepo = recorder
+
+	if err := ts.Logout(ctx, claims, &models.LogoutRequest{RefreshToken: current.RefreshToken}); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+
+	// jti는 원래 만료 시각까지 폐기 목록에 남는다
+	if len(recorder.revoked) != 1 {
+		t.Fatalf("revoked %d access tokens, want 1", len(recorder.revoked))
+	}
+	if entry := recorder.revoked[0]; entry.JTI != claims.ID || !entry.ExpiresAt.Equal(claims.ExpiresAt.Time) {
+		t.Errorf("revoked entry = %+v, want jti %s until %v", entry, claims.ID, claims.ExpiresAt.Time)
+	}
+	if revoked, _ := ts.stores.RevokedTokens.IsTokenRevoked(ctx, claims.ID); !revoked {
+		t.Error("jti is not in the denylist")
+	}
+	if _, err := ts.ValidateToken(ctx, current.Token); err == nil {
+		t.Error("logged out access token is still valid")
+	}
+
+	// 리프레시 토큰 패밀리도 폐기되고, 다른 로그인은 그대로
+	if _, err := ts.refresh(current.RefreshToken); err == nil {
+		t.Error("logged out refresh token still refreshes")
+	}
+	if _, err := ts.ValidateToken(ctx, other.Token); err != nil {
+		t.Errorf("access token of another login: %v", err)
+	}
+	if _, err := ts.refresh(other.RefreshToken); err != nil {
+		t.Errorf("refresh token of another login: %v", err)
+	}
+}
+
+func TestLogoutIgnoresAnotherUsersRefreshToken(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	ts.createUser(t, "victim@example.com", "Password123!", true)
+	attacker := ts.login(t, "member@example.com", "Password123!")
+	victim := ts.login(t, "victim@example.com", "Password123!")
+
+	err := ts.Logout(context.Background(), ts.claims(t, attacker), &models.LogoutRequest{RefreshToken: victim.RefreshToken})
+	if err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	if _, err := ts.refresh(victim.RefreshToken); err != nil {
+		t.Errorf("another user's refresh token was revoked: %v", err)
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	first := ts.login(t, "member@example.com", "Password123!")
+	second := ts.login(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	// 세션 없이 발급된 토큰도 TokensValidAfter로 거부되는지 확인
+	// (JWT iat는 초 단위라 다음 초로 넘어간 뒤 로그아웃)
+	sessionless, err := utils.GenerateJWT(&utils.JWTClaim{
+		UserID:       user.ID.Hex(),
+		Email:        user.Email,
+		TokenVersion: user.TokenVersion,
+	}, ts.keys.SigningKey(), time.Hour)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	if _, err := ts.ValidateToken(ctx, sessionless); err != nil {
+		t.Fatalf("token before logout: %v", err)
+	}
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+
+	if err := ts.LogoutAll(ctx, ts.claims(t, first)); err != nil {
+		t.Fatalf("logout all: %v", err)
+	}
+
+	stored := ts.storedUser(t, user.ID)
+	if stored.TokensValidAfter == nil || time.Since(*stored.TokensValidAfter) > time.Minute {
+		t.Fatalf("tokens valid after = %v", stored.TokensValidAfter)
+	}
+	for name, token := range map[string]string{"current": first.Token, "other login": second.Token, "sessionless": sessionless} {
+		if _, err := ts.ValidateToken(ctx, token); err == nil || err.Error() != "token has been revoked" {
+			t.Errorf("%s access token = %v, want token has been revoked", name, err)
+		}
+	}
+	for name, token := range map[string]string{"current": first.RefreshToken, "other login": second.RefreshToken} {
+		if _, err := ts.refresh(token); err == nil {
+			t.Errorf("%s refresh token still refreshes", name)
+		}
+	}
+
+	// 이후 로그인은 정상
+	if _, err := ts.ValidateToken(ctx, ts.login(t, "member@example.com", "Password123!").Token); err != nil {
+		t.Errorf("login after logout all: %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+type EmailService struct {
+	sender Sender
+	from   string
+}
+
+// NewEmailService EmailService 생성자
+func NewEmailService(sender Sender, from string) *EmailService {
+	return &EmailService{
+		sender: sender,
+		from:   from,
+	}
+}
+
+var passwordResetTemplate = template.Must(template.New("password_reset").Parse(
+	`Hello,
+
+We received a request to reset the password for your Prisma Market account.
+Open the link below to choose a new password:
+
+{{.Link}}
+
+This link expires in 1 hour. If you did not request a password reset,
+you can safely ignore this email.
+`))
+
+var verificationTemplate = template.Must(template.New("verification").Parse(
+	`Hello,
+
+Please confirm your email address for your Prisma Market account
+by opening the link below:
+
+{{.Link}}
+
+This link expires in 24 hours.
+`))
+
+var passwordChangedTemplate = template.Must(template.New("password_changed").Parse(
+	`Hello,
+
+The password for your Prisma Market account ({{.Email}}) was just changed,
+and you have been signed out on all other devices.
+
+If you did not make this change, reset your password immediately:
+
+{{.ResetURL}}
+`))
+
+var magicLinkTemplate = template.Must(template.New("magic_link").Parse(
+	`Hello,
+
+Open the link below to sign in to your Prisma Market account:
+
+{{.Link}}
+
+This link expires in {{.Minutes}} minutes and can only be used once.
+If you did not request it, you can safely ignore this email.
+`))
+
+var codeTemplate = template.Must(template.New("code").Parse(
+	`Hello,
+
+Your Prisma Market code to {{.Action}} is:
+
+{{.Code}}
+
+This code expires in {{.Minutes}} minutes. Never share it with anyone.
+If you did not request it, you can safely ignore this email.
+`))
+
+var accountLockedTemplate = template.Must(template.New("account_locked").Parse(
+	`Hello,
+
+Your Prisma Market account ({{.Email}}) has been temporarily locked
+after too many failed sign-in attempts. You can try again after
+{{.Until}}.
+
+If this wasn't you, we recommend resetting your password:
+
+{{.ResetURL}}
+`))
+
+// SendPasswordResetEmail 비밀번호 재설정 메일 발송
+func (s *EmailService) SendPasswordResetEmail(ctx context.Context, to, token, resetURL string) error {
+	return s.send(ctx, to, "Reset your Prisma Market password", passwordResetTemplate, map[string]string{
+		"Link": tokenLink(resetURL, token),
+	})
+}
+
+// SendVerificationEmail 이메일 인증 메일 발송
+func (s *EmailService) SendVerificationEmail(ctx context.Context, to, token, verifyURL string) error {
+	return s.send(ctx, to, "Verify your Prisma Market email address", verificationTemplate, map[string]string{
+		"Link": tokenLink(verifyURL, token),
+	})
+}
+
+// SendMagicLinkEmail 매직 링크 로그인 메일 발송
+func (s *EmailService) SendMagicLinkEmail(ctx context.Context, to, token, loginURL string, ttl time.Duration) error {
+	return s.send(ctx, to, "Sign in to Prisma Market", magicLinkTemplate, map[string]string{
+		"Link":    tokenLink(loginURL, token),
+		"Minutes": strconv.Itoa(int(ttl.Minutes())),
+	})
+}
+
+// SendCodeEmail 숫자 코드(OTP) 메일 발송 (action은 "sign in" 처럼 코드의 용도)
+func (s *EmailService) SendCodeEmail(ctx context.Context, to, subject, action, code string, ttl time.Duration) error {
+	return s.send(ctx, to, subject, codeTemplate, map[string]string{
+		"Action":  action,
+		"Code":    code,
+		"Minutes": strconv.Itoa(int(ttl.Minutes())),
+	})
+}
+
+// SendPasswordChangedEmail 비밀번호 변경 알림 메일 발송
+func (s *EmailService) SendPasswordChangedEmail(ctx context.Context, to, resetURL string) error {
+	return s.send(ctx, to, "Your Prisma Market password was changed", passwordChangedTemplate, map[string]string{
+		"Email":    to,
+		"ResetURL": resetURL,
+	})
+}
+
+// SendAccountLockedEmail 로그인 잠금 알림 메일 발송
+func (s *EmailService) SendAccountLockedEmail(ctx context.Context, to string, until time.Time, resetURL string) error {
+	return s.send(ctx, to, "Your Prisma Market account has been locked", accountLockedTemplate, map[string]string{
+		"Email":    to,
+		"Until":    until.UTC().Format("2006-01-02 15:04 MST"),
+		"ResetURL": resetURL,
+	})
+}
+
+// send 템플릿을 렌더링해서 발송
+func (s *EmailService) send(ctx context.Context, to, subject string, tmpl *template.Template, data interface{}) error {
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return s.sender.Send(ctx, &Message{
+		From:    s.from,
+		To:      []string{to},
+		Subject: subject,
+		Body:    body.String(),
+	})
+}
+
+// tokenLink 기본 URL에 token 쿼리 파라미터 추가
+func tokenLink(baseURL, token string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,74 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MailboxSender 메일을 실제로 보내지 않고 .eml 파일로 저장 (로컬 개발/테스트용)
+// 수신자별로 <dir>/<수신자 주소>/ 아래에 저장된다.
+type MailboxSender struct {
+	dir string
+}
+
+// NewMailboxSender MailboxSender 생성자
+func NewMailboxSender(dir string) (*MailboxSender, error) {
+	if dir == "" {
+		dir = "./mailbox"
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &MailboxSender{dir: dir}, nil
+}
+
+// Send 메시지를 수신자별 .eml 파일로 저장
+func (s *MailboxSender) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	for _, to := range msg.To {
+		rcpt, err := envelopeAddress(to)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Join(s.dir, mailboxName(rcpt))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+
+		b := make([]byte, 4)
+		rand.Read(b)
+		name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), hex.EncodeToString(b))
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Dir 메일박스 루트 경로
+func (s *MailboxSender) Dir() string {
+	return s.dir
+}
+
+// mailboxName 주소를 디렉터리 이름으로 사용할 수 있게 정리
+func mailboxName(addr string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(addr))
+}
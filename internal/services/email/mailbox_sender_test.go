@@ -0,0 +1,69 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMailboxSender(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewMailboxSender(dir)
+	if err != nil {
+		t.Fatalf("NewMailboxSender: %v", err)
+	}
+
+	msg := &Message{
+		From:    "Prisma Market <no-reply@example.com>",
+		To:      []string{"Member@Example.com", "Other <other@example.com>"},
+		Subject: "비밀번호 재설정",
+		Body:    "https://example.com/reset?token=abc",
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	// 수신자마다 소문자 주소 디렉터리에 .eml 하나씩
+	for _, rcpt := range []string{"member@example.com", "other@example.com"} {
+		files, err := filepath.Glob(filepath.Join(dir, rcpt, "*.eml"))
+		if err != nil || len(files) != 1 {
+			t.Fatalf("%s mailbox = %v, %v; want one .eml file", rcpt, files, err)
+		}
+		data, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatalf("read %s: %v", files[0], err)
+		}
+
+		parsed, body := parseMessage(t, data)
+		if got := parsed.Header.Get("To"); got != "Member@Example.com, Other <other@example.com>" {
+			t.Errorf("To = %q", got)
+		}
+		if body != msg.Body {
+			t.Errorf("body = %q, want %q", body, msg.Body)
+		}
+	}
+}
+
+func TestMailboxSenderRejectsInvalidMessage(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewMailboxSender(dir)
+	if err != nil {
+		t.Fatalf("NewMailboxSender: %v", err)
+	}
+
+	tests := map[string]*Message{
+		"header injection": {From: "no-reply@example.com", To: []string{"member@example.com\r\nBcc: evil@example.com"}, Subject: "Hello"},
+		"invalid address":  {From: "no-reply@example.com", To: []string{"not an address"}, Subject: "Hello"},
+	}
+	for name, msg := range tests {
+		if err := sender.Send(context.Background(), msg); err == nil {
+			t.Errorf("%s: Send succeeded", name)
+		}
+	}
+
+	// 경로 구분자가 들어간 주소도 메일박스 밖에 쓰지 않는다
+	if got := mailboxName(`"a/../b"@example.com`); filepath.Base(got) != got {
+		t.Errorf("mailboxName = %q escapes the mailbox directory", got)
+	}
+}
@@ -0,0 +1,106 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+)
+
+// Message 발송할 이메일 메시지 (text/plain)
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender 이메일 발송 인터페이스
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender 설정에 맞는 Sender 생성
+func NewSender(cfg *config.Config) (Sender, error) {
+	switch cfg.EmailSender {
+	case "", "smtp":
+		return NewSMTPSender(
+			cfg.SMTPHost,
+			cfg.SMTPPort,
+			cfg.SMTPUsername,
+			cfg.SMTPPassword,
+			SMTPSecurity(cfg.SMTPSecurity),
+		)
+	case "mailbox":
+		return NewMailboxSender(cfg.MailboxDir)
+	default:
+		return nil, fmt.Errorf("unknown email sender: %s", cfg.EmailSender)
+	}
+}
+
+// Bytes RFC 5322 형식의 메시지로 직렬화
+func (m *Message) Bytes() ([]byte, error) {
+	if len(m.To) == 0 {
+		return nil, errors.New("email has no recipients")
+	}
+
+	// 헤더 인젝션 방지
+	for _, v := range append([]string{m.From, m.Subject}, m.To...) {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("invalid email header value")
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: %s\r\n", messageID(m.From))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(m.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// messageID 발신 도메인 기반 Message-ID 생성
+func messageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 {
+			domain = addr.Address[at+1:]
+		}
+	}
+
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(b), domain)
+}
+
+// envelopeAddress 헤더 주소에서 SMTP envelope 주소 추출
+func envelopeAddress(addr string) (string, error) {
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid email address %q: %w", addr, err)
+	}
+	return parsed.Address, nil
+}
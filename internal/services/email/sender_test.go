@@ -0,0 +1,166 @@
+package email
+
+import (
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+)
+
+// parseMessage 직렬화한 메시지를 다시 읽어 헤더와 디코딩한 본문 반환
+func parseMessage(t *testing.T, data []byte) (*mail.Message, string) {
+	t.Helper()
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return parsed, string(body)
+}
+
+func TestMessageBytesRejectsHeaderInjection(t *testing.T) {
+	tests := map[string]*Message{
+		"CR in To":        {From: "no-reply@example.com", To: []string{"member@example.com\rBcc: evil@example.com"}, Subject: "Hello"},
+		"LF in To":        {From: "no-reply@example.com", To: []string{"member@example.com", "other@example.com\nBcc: evil@example.com"}, Subject: "Hello"},
+		"CRLF in Subject": {From: "no-reply@example.com", To: []string{"member@example.com"}, Subject: "Hello\r\nBcc: evil@example.com"},
+		"LF in From":      {From: "no-reply@example.com\nBcc: evil@example.com", To: []string{"member@example.com"}, Subject: "Hello"},
+		"no recipients":   {From: "no-reply@example.com", Subject: "Hello"},
+	}
+	for name, msg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if data, err := msg.Bytes(); err == nil {
+				t.Errorf("Bytes accepted the message:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestMessageBytesEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+	}{
+		{"ascii", "Verify your email", "Click the link below.\nhttps://example.com/verify?token=abc"},
+		{"utf-8", "이메일 인증 안내", "아래 링크를 눌러 주세요.\n감사합니다."},
+		{"long line", "Reset your password", strings.Repeat("a=b ", 60)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &Message{
+				From:    "Prisma Market <no-reply@example.com>",
+				To:      []string{"member@example.com"},
+				Subject: tt.subject,
+				Body:    tt.body,
+			}
+			data, err := msg.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes: %v", err)
+			}
+
+			// 줄바꿈은 CRLF, 본문은 quoted-printable 제한(76자) 안의 7비트 문자만 사용
+			raw := string(data)
+			if strings.Contains(strings.ReplaceAll(raw, "\r\n", ""), "\n") {
+				t.Errorf("bare LF in message:\n%s", raw)
+			}
+			_, encoded, _ := strings.Cut(raw, "\r\n\r\n")
+			for _, line := range strings.Split(encoded, "\r\n") {
+				if len(line) > 76 {
+					t.Errorf("body line longer than 76 characters: %q", line)
+				}
+			}
+			for _, c := range data {
+				if c >= 0x80 {
+					t.Fatalf("raw 8-bit byte in message:\n%s", raw)
+				}
+			}
+
+			parsed, body := parseMessage(t, data)
+			subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+			if err != nil || subject != tt.subject {
+				t.Errorf("subject = %q, %v; want %q", subject, err, tt.subject)
+			}
+			if want := strings.ReplaceAll(tt.body, "\n", "\r\n"); body != want {
+				t.Errorf("body = %q, want %q", body, want)
+			}
+			if got := parsed.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+				t.Errorf("Content-Transfer-Encoding = %q", got)
+			}
+			if id := parsed.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+				t.Errorf("Message-ID = %q, want the sender domain", id)
+			}
+		})
+	}
+}
+
+func TestEnvelopeAddress(t *testing.T) {
+	tests := []struct {
+		addr    string
+		want    string
+		wantErr bool
+	}{
+		{"member@example.com", "member@example.com", false},
+		{"Prisma Market <no-reply@example.com>", "no-reply@example.com", false},
+		{"\"Kim, Member\" <member@example.com>", "member@example.com", false},
+		{"not an address", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := envelopeAddress(tt.addr)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("envelopeAddress(%q) = %q, %v; want %q", tt.addr, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		want    string
+		wantErr bool
+	}{
+		{"default smtp", &config.Config{SMTPHost: "smtp.example.com", SMTPPort: 587}, "*email.SMTPSender", false},
+		{"smtp", &config.Config{EmailSender: "smtp", SMTPHost: "smtp.example.com", SMTPSecurity: "tls"}, "*email.SMTPSender", false},
+		{"smtp without host", &config.Config{EmailSender: "smtp"}, "", true},
+		{"smtp with unknown security", &config.Config{EmailSender: "smtp", SMTPHost: "smtp.example.com", SMTPSecurity: "ssl"}, "", true},
+		{"mailbox", &config.Config{EmailSender: "mailbox", MailboxDir: t.TempDir()}, "*email.MailboxSender", false},
+		{"unknown", &config.Config{EmailSender: "sendgrid"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender, err := NewSender(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewSender = %T, want error", sender)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSender: %v", err)
+			}
+			if got := typeName(sender); got != tt.want {
+				t.Errorf("NewSender = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// typeName Sender 구현 타입 이름
+func typeName(sender Sender) string {
+	switch sender.(type) {
+	case *SMTPSender:
+		return "*email.SMTPSender"
+	case *MailboxSender:
+		return "*email.MailboxSender"
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPSecurity SMTP 연결 보안 방식
+type SMTPSecurity string
+
+const (
+	SMTPSecurityStartTLS SMTPSecurity = "starttls" // 평문 연결 후 STARTTLS (보통 587)
+	SMTPSecurityTLS      SMTPSecurity = "tls"      // 암묵적 TLS (보통 465)
+	SMTPSecurityNone     SMTPSecurity = "none"     // 암호화 없음 (로컬 개발용)
+)
+
+const smtpDialTimeout = 10 * time.Second
+
+// SMTPSender SMTP 서버를 통한 이메일 발송
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	security SMTPSecurity
+}
+
+// NewSMTPSender SMTPSender 생성자
+func NewSMTPSender(host string, port int, username, password string, security SMTPSecurity) (*SMTPSender, error) {
+	if host == "" {
+		return nil, errors.New("smtp host is required")
+	}
+	if security == "" {
+		security = SMTPSecurityStartTLS
+	}
+	switch security {
+	case SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone:
+	default:
+		return nil, fmt.Errorf("unknown smtp security: %s", security)
+	}
+
+	return &SMTPSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		security: security,
+	}, nil
+}
+
+// Send 메시지 발송
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	from, err := envelopeAddress(msg.From)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		rcpt, err := envelopeAddress(to)
+		if err != nil {
+			return err
+		}
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// dial 보안 방식에 맞춰 SMTP 서버 연결
+func (s *SMTPSender) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+
+	if s.security == SMTPSecurityTLS {
+		tlsDialer := &tls.Dialer{
+			NetDialer: dialer,
+			Config:    &tls.Config{ServerName: s.host},
+		}
+		return tlsDialer.DialContext(ctx, "tcp", addr)
+	}
+	return dialer.DialContext(ctx, "tcp", addr)
+}
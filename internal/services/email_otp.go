@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+const (
+	flowEmailOTPLogin         = "email_otp_login"
+	flowEmailOTPVerifyEmail   = "email_otp_verify_email"
+	flowEmailOTPResetPassword = "email_otp_reset_password"
+
+	// 이메일 OTP 자릿수
+	emailOTPDigits = 6
+)
+
+var errInvalidEmailOTP = errors.New("invalid or expired code")
+
+// SendLoginCode 로그인용 숫자 코드를 메일로 발송
+func (s *AuthService) SendLoginCode(ctx context.Context, req *models.EmailOTPRequest) error {
+	user, err := s.repo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil // 보안을 위해 사용자가 없어도 성공으로 처리
+	}
+	if skipInactiveAccount(user, "sign-in code") {
+		return nil
+	}
+
+	return s.sendEmailOTP(ctx, user, flowEmailOTPLogin, "Your Prisma Market sign-in code", "sign in")
+}
+
+// LoginWithCode 이메일과 숫자 코드로 로그인 (LoginUser와 같은 응답)
+// 코드는 메일로만 전달되므로 아직 인증되지 않은 이메일도 인증 처리된다.
+func (s *AuthService) LoginWithCode(ctx context.Context, req *models.EmailOTPLoginRequest) (*models.LoginResponse, error) {
+	user, err := s.verifyEmailOTP(ctx, req.Email, req.Code, flowEmailOTPLogin)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsLocked(time.Now()) {
+		return nil, errInvalidEmailOTP
+	}
+	if !user.EmailVerified {
+		if err := s.repo.MarkEmailVerified(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		// 이메일 인증 정책이 방금 인증된 값으로 적용되도록 반영
+		user.EmailVerified = true
+	}
+
+	// 비밀번호 로그인과 마찬가지로 2단계 인증 수단이 있으면 챌린지 반환
+	methods, err := s.secondFactorMethods(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) > 0 {
+		return s.mfaChallenge(ctx, user, methods)
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+// verifyEmailWithCode 이메일과 숫자 코드로 이메일 인증
+func (s *AuthService) verifyEmailWithCode(ctx context.Context, req *models.VerifyEmailRequest) error {
+	user, err := s.verifyEmailOTP(ctx, req.Email, req.Code, flowEmailOTPVerifyEmail)
+	if err != nil {
+		return err
+	}
+	return s.repo.MarkEmailVerified(ctx, user.ID)
+}
+
+// resetPasswordWithCode 이메일과 숫자 코드로 비밀번호 재설정 (토큰 버전이 올라가고 로그인 잠금도 해제)
+func (s *AuthService) resetPasswordWithCode(ctx context.Context, req *models.ResetPasswordRequest, hashedPassword string) (*models.User, error) {
+	user, err := s.verifyEmailOTP(ctx, req.Email, req.Code, flowEmailOTPResetPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err = s.repo.UpdatePassword(ctx, user.ID, hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ResetFailedLogins(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// sendEmailOTP 숫자 코드를 새로 발급해 메일로 발송 (같은 용도의 이전 코드는 무효)
+func (s *AuthService) sendEmailOTP(ctx context.Context, user *models.User, kind, subject, action string) error {
+	code := utils.GenerateNumericCode(emailOTPDigits)
+	if code == "" {
+		return errors.New("failed to generate code")
+	}
+	ttl := s.emailOTPTTL()
+
+	// 코드는 해시만 저장하고 원문은 메일로만 전달
+	if err := s.flowRepo.ReplaceFlow(ctx, &models.AuthFlow{
+		ID:        emailOTPFlowID(kind, user.ID),
+		Kind:      kind,
+		UserID:    user.ID,
+		Data:      []byte(utils.HashToken(code)),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return err
+	}
+
+	return s.emailService.SendCodeEmail(ctx, user.Email, subject, action, code, ttl)
+}
+
+// verifyEmailOTP 숫자 코드 확인 후 사용 처리
+// 코드는 이메일의 사용자에게 묶여 있어 다른 계정의 코드로는 맞출 수 없고,
+// 틀린 시도도 횟수에 포함되어 EmailOTPMaxAttempts를 넘기면 코드를 다시 받아야 한다.
+func (s *AuthService) verifyEmailOTP(ctx context.Context, email, code, kind string) (*models.User, error) {
+	if email == "" || !isNumericCode(code) {
+		return nil, errInvalidEmailOTP
+	}
+
+	user, err := s.repo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errInvalidEmailOTP
+	}
+
+	id := emailOTPFlowID(kind, user.ID)
+	flow, err := s.flowRepo.UseFlowAttempt(ctx, id, kind, s.emailOTPMaxAttempts())
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil || subtle.ConstantTimeCompare([]byte(utils.HashToken(code)), flow.Data) != 1 {
+		return nil, errInvalidEmailOTP
+	}
+
+	// 동시에 같은 코드가 들어와도 한 번만 사용
+	consumed, err := s.flowRepo.ConsumeFlow(ctx, id, kind)
+	if err != nil {
+		return nil, err
+	}
+	if consumed == nil {
+		return nil, errInvalidEmailOTP
+	}
+
+	// 코드로 이메일 소유를 확인한 뒤에만 계정 상태를 알려준다
+	if err := accountStatusError(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// emailOTPFlowID 사용자와 용도별로 하나만 유효한 코드의 절차 ID
+func emailOTPFlowID(kind string, userID primitive.ObjectID) string {
+	return kind + ":" + userID.Hex()
+}
+
+// isNumericCode emailOTPDigits 자리 숫자인지 확인
+func isNumericCode(code string) bool {
+	if len(code) != emailOTPDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// emailOTPTTL 코드 수명 (설정이 없으면 10분)
+func (s *AuthService) emailOTPTTL() time.Duration {
+	if s.config.EmailOTPTTL <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(s.config.EmailOTPTTL) * time.Minute
+}
+
+// emailOTPMaxAttempts 코드 하나당 검증 시도 횟수 (설정이 없으면 5회)
+func (s *AuthService) emailOTPMaxAttempts() int {
+	if s.config.EmailOTPMaxAttempts <= 0 {
+		return 5
+	}
+	return s.config.EmailOTPMaxAttempts
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// setLoginCode 알려진 로그인 코드를 직접 저장
+func setLoginCode(t *testing.T, ts *testService, user *models.User, code string) {
+	t.Helper()
+
+	err := ts.stores.AuthFlows.ReplaceFlow(context.Background(), &models.AuthFlow{
+		ID:        emailOTPFlowID(flowEmailOTPLogin, user.ID),
+		Kind:      flowEmailOTPLogin,
+		UserID:    user.ID,
+		Data:      []byte(utils.HashToken(code)),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("save login code: %v", err)
+	}
+}
+
+func TestLoginWithCodeVerifiesEmailBeforePolicy(t *testing.T) {
+	for _, policy := range []string{config.EmailVerificationDeny, config.EmailVerificationRestrict} {
+		t.Run(policy, func(t *testing.T) {
+			ts := newTestService(t, func(c *config.Config) { c.EmailVerificationPolicy = policy })
+			user := ts.createUser(t, "otp@example.com", "Password123!", false)
+			setLoginCode(t, ts, user, "123456")
+
+			resp, err := ts.LoginWithCode(context.Background(), &models.EmailOTPLoginRequest{Email: user.Email, Code: "123456"})
+			if err != nil {
+				t.Fatalf("login with code: %v", err)
+			}
+
+			claims, err := ts.ValidateToken(context.Background(), resp.Token)
+			if err != nil {
+				t.Fatalf("validate token: %v", err)
+			}
+			if claims.EmailVerified == nil || !*claims.EmailVerified {
+				t.Error("token issued after code login is not marked email_verified")
+			}
+
+			stored, _ := ts.stores.Users.FindUserByID(context.Background(), user.ID)
+			if !stored.EmailVerified {
+				t.Error("email was not marked verified in the store")
+			}
+		})
+	}
+}
+
+func TestLoginWithCodeIsSingleUse(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "otp@example.com", "Password123!", true)
+	setLoginCode(t, ts, user, "123456")
+
+	req := &models.EmailOTPLoginRequest{Email: user.Email, Code: "123456"}
+	if _, err := ts.LoginWithCode(context.Background(), req); err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if _, err := ts.LoginWithCode(context.Background(), req); err != errInvalidEmailOTP {
+		t.Fatalf("second login: got %v, want %v", err, errInvalidEmailOTP)
+	}
+}
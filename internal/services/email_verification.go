@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+const flowVerificationCooldown = "verification_email_cooldown" // 로그인 시 인증 메일 재발송 대기
+
+// ErrEmailNotVerified 이메일 인증 전에는 로그인할 수 없음 (EMAIL_VERIFICATION_POLICY=deny)
+var ErrEmailNotVerified = errors.New("email address is not verified")
+
+// checkEmailVerified deny 정책에서 미인증 계정의 토큰 발급 거부
+func (s *AuthService) checkEmailVerified(user *models.User) error {
+	if !user.EmailVerified && s.config.EmailVerificationPolicy == config.EmailVerificationDeny {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// offerVerificationEmail 미인증 계정이 로그인하면 인증 메일을 다시 발송 (보냈으면 true)
+// 대기 시간(VERIFICATION_EMAIL_COOLDOWN) 안에는 다시 보내지 않고, 발송 실패는 로그인 결과에 영향을 주지 않는다.
+func (s *AuthService) offerVerificationEmail(ctx context.Context, user *models.User) bool {
+	// 대기 중인 절차가 이미 있으면 ErrFlowExists, 저장소 에러면 대기 시간을 지킬 수 없으므로 보내지 않는다
+	if err := s.flowRepo.SaveFlow(ctx, s.verificationCooldownFlow(user)); err != nil {
+		if !errors.Is(err, repository.ErrFlowExists) {
+			log.Printf("failed to start verification email cooldown for %s: %v", user.ID.Hex(), err)
+		}
+		return false
+	}
+
+	if err := s.sendVerificationLink(ctx, user); err != nil {
+		log.Printf("failed to send verification email for %s: %v", user.ID.Hex(), err)
+		return false
+	}
+	return true
+}
+
+// verificationCooldownFlow 인증 메일 재발송 대기 시간 동안 유지되는 절차
+func (s *AuthService) verificationCooldownFlow(user *models.User) *models.AuthFlow {
+	return &models.AuthFlow{
+		ID:        flowVerificationCooldown + ":" + user.ID.Hex(),
+		Kind:      flowVerificationCooldown,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(s.verificationEmailCooldown()),
+	}
+}
+
+// verificationEmailCooldown 인증 메일 재발송 간격 (설정이 없으면 10분)
+func (s *AuthService) verificationEmailCooldown() time.Duration {
+	if s.config.VerificationEmailCooldown <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(s.config.VerificationEmailCooldown) * time.Minute
+}
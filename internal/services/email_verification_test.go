@@ -0,0 +1,91 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+)
+
+// verificationSubject 인증 메일 제목
+const verificationSubject = "Verify your Prisma Market email address"
+
+// failingFlows SaveFlow가 항상 실패하는 AuthFlowStore
+type failingFlows struct {
+	repository.AuthFlowStore
+}
+
+func (failingFlows) SaveFlow(ctx context.Context, flow *models.AuthFlow) error {
+	return errors.New("connection refused")
+}
+
+// captureLog 테스트가 끝날 때까지 표준 로거 출력을 버퍼에 기록
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(prev) })
+	return &buf
+}
+
+// loginUnverified 미인증 계정 로그인 후 인증 메일을 다시 보냈는지 반환
+func (ts *testService) loginUnverified(t *testing.T, emailAddr string) bool {
+	t.Helper()
+	return ts.login(t, emailAddr, "Password123!").VerificationSent
+}
+
+func TestVerificationEmailCooldown(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", false)
+	logs := captureLog(t)
+
+	if !ts.loginUnverified(t, user.Email) {
+		t.Fatal("first login did not send a verification email")
+	}
+	// 대기 시간 안에는 다시 보내지 않고, 정상적인 대기는 로그에 남기지 않는다
+	if ts.loginUnverified(t, user.Email) {
+		t.Error("verification email was sent again during the cooldown")
+	}
+	if subjects := ts.mail.subjects(); len(subjects) != 1 || subjects[0] != verificationSubject {
+		t.Errorf("sent %v, want one verification email", subjects)
+	}
+	if strings.Contains(logs.String(), "cooldown") {
+		t.Errorf("active cooldown was logged as a failure: %s", logs)
+	}
+
+	// 만료된 대기 절차가 아직 지워지지 않았어도 다시 보낸다
+	expired := ts.verificationCooldownFlow(user)
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	if err := ts.stores.AuthFlows.ReplaceFlow(context.Background(), expired); err != nil {
+		t.Fatalf("expire cooldown: %v", err)
+	}
+	if !ts.loginUnverified(t, user.Email) {
+		t.Error("verification email was not sent after the cooldown expired")
+	}
+}
+
+func TestVerificationEmailCooldownStoreError(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", false)
+	ts.flowRepo = failingFlows{ts.flowRepo}
+	logs := captureLog(t)
+
+	// 대기 시간을 기록할 수 없으면 보내지 않고 로그인은 그대로 진행
+	if ts.loginUnverified(t, user.Email) {
+		t.Error("verification email was sent without a cooldown")
+	}
+	if subjects := ts.mail.subjects(); len(subjects) != 0 {
+		t.Errorf("sent %v, want none", subjects)
+	}
+	if !strings.Contains(logs.String(), "connection refused") {
+		t.Errorf("store error was not logged: %q", logs)
+	}
+}
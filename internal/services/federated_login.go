@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/federation"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+const (
+	flowFederatedLogin  = "federated_login"  // 제공자 로그인 화면으로 보낸 요청 (state)
+	flowFederatedResult = "federated_result" // 콜백 후 웹 앱이 교환할 로그인 코드
+
+	federatedLoginTTL  = 10 * time.Minute
+	federatedResultTTL = time.Minute
+)
+
+// ErrUnknownProvider 설정되지 않은 외부 로그인 제공자
+var ErrUnknownProvider = errors.New("unknown provider")
+
+// 웹 앱으로 돌려보내는 외부 로그인 실패 코드 (error 파라미터, 그 밖의 실패는 server_error)
+var (
+	errFederatedAccessDenied      = errors.New("access_denied")             // state가 맞지 않거나, 제공자가 거부했거나, ID 토큰 검증에 실패
+	errFederatedEmailNotVerified  = errors.New("email_not_verified")        // 제공자가 인증된 이메일을 주지 않음
+	errFederatedAccountUnverified = errors.New("account_exists_unverified") // 같은 이메일의 미인증 계정이 이미 있음
+)
+
+// federatedLoginState 제공자로 보낸 요청 정보 (AuthFlow.Data)
+type federatedLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// FederatedProviders 설정된 외부 로그인 제공자 이름 목록
+func (s *AuthService) FederatedProviders() []string {
+	names := make([]string, 0, len(s.federated))
+	for name := range s.federated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BeginFederatedLogin 외부 제공자의 로그인 화면 URL
+func (s *AuthService) BeginFederatedLogin(ctx context.Context, providerName string) (string, error) {
+	provider, ok := s.federated[providerName]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	state := utils.GenerateRandomToken(32)
+	loginState := federatedLoginState{
+		Provider:     provider.Name,
+		Nonce:        utils.GenerateRandomToken(16),
+		CodeVerifier: utils.GenerateRandomToken(32),
+	}
+	if state == "" || loginState.Nonce == "" || loginState.CodeVerifier == "" {
+		return "", errors.New("failed to generate login state")
+	}
+
+	data, err := json.Marshal(&loginState)
+	if err != nil {
+		return "", err
+	}
+	if err := s.flowRepo.SaveFlow(ctx, &models.AuthFlow{
+		ID:        utils.HashToken(state),
+		Kind:      flowFederatedLogin,
+		Data:      data,
+		ExpiresAt: time.Now().Add(federatedLoginTTL),
+	}); err != nil {
+		return "", err
+	}
+
+	return provider.AuthCodeURL(ctx, s.federatedRedirectURI(provider.Name), state,
+		loginState.Nonce, utils.PKCEChallengeS256(loginState.CodeVerifier))
+}
+
+// FinishFederatedLogin 제공자 콜백 처리 후 웹 앱으로 돌아갈 URL
+// 성공하면 일회용 로그인 코드를, 실패하면 error를 붙여 {WebAppURL}/federated/callback으로 보낸다.
+func (s *AuthService) FinishFederatedLogin(ctx context.Context, providerName string, query url.Values) (string, error) {
+	provider, ok := s.federated[providerName]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	code, err := s.completeFederatedLogin(ctx, provider, query)
+	if err != nil {
+		// 자세한 원인은 서버 로그에만 남기고 웹 앱에는 정해진 코드만 전달
+		log.Printf("federated login with %s failed: %v", provider.Name, err)
+		return s.federatedCallbackURL(url.Values{"error": {federatedErrorCode(err)}}), nil
+	}
+	return s.federatedCallbackURL(url.Values{"code": {code}}), nil
+}
+
+// federatedErrorCode 외부 로그인 실패를 웹 앱에 전달할 코드로 변환
+func federatedErrorCode(err error) string {
+	for _, known := range []error{errFederatedAccessDenied, errFederatedEmailNotVerified, errFederatedAccountUnverified} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return "server_error"
+}
+
+// ExchangeFederatedLogin 로그인 코드를 LoginUser와 같은 응답으로 교환
+func (s *AuthService) ExchangeFederatedLogin(ctx context.Context, req *models.FederatedExchangeRequest) (*models.LoginResponse, error) {
+	if req.Code == "" {
+		return nil, errors.New("invalid or expired login code")
+	}
+
+	flow, err := s.flowRepo.ConsumeFlow(ctx, utils.HashToken(req.Code), flowFederatedResult)
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil {
+		return nil, errors.New("invalid or expired login code")
+	}
+
+	user, err := s.repo.FindUserByID(ctx, flow.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsLocked(time.Now()) {
+		return nil, errors.New("invalid or expired login code")
+	}
+
+	// 비밀번호 로그인과 마찬가지로 2단계 인증 수단이 있으면 챌린지 반환
+	methods, err := s.secondFactorMethods(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) > 0 {
+		return s.mfaChallenge(ctx, user, methods)
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+// completeFederatedLogin state 확인, 코드 교환, 사용자 연결 후 로그인 코드 발급
+func (s *AuthService) completeFederatedLogin(ctx context.Context, provider *federation.Provider, query url.Values) (string, error) {
+	state := query.Get("state")
+	if state == "" {
+		return "", fmt.Errorf("%w: missing login state", errFederatedAccessDenied)
+	}
+	flow, err := s.flowRepo.ConsumeFlow(ctx, utils.HashToken(state), flowFederatedLogin)
+	if err != nil {
+		return "", err
+	}
+	if flow == nil {
+		return "", fmt.Errorf("%w: invalid login state", errFederatedAccessDenied)
+	}
+
+	var loginState federatedLoginState
+	if err := json.Unmarshal(flow.Data, &loginState); err != nil {
+		return "", err
+	}
+	if loginState.Provider != provider.Name {
+		return "", fmt.Errorf("%w: login state belongs to %s", errFederatedAccessDenied, loginState.Provider)
+	}
+
+	// 사용자가 제공자 화면에서 취소한 경우 등
+	if providerErr := query.Get("error"); providerErr != "" {
+		return "", fmt.Errorf("%w: provider returned %s", errFederatedAccessDenied, providerErr)
+	}
+
+	identity, err := provider.Exchange(ctx, query.Get("code"), s.federatedRedirectURI(provider.Name),
+		state, loginState.Nonce, loginState.CodeVerifier)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errFederatedAccessDenied, err)
+	}
+
+	user, err := s.linkFederatedIdentity(ctx, provider.Name, identity)
+	if err != nil {
+		return "", err
+	}
+
+	code := utils.GenerateRandomToken(32)
+	if code == "" {
+		return "", errors.New("failed to generate login code")
+	}
+	if err := s.flowRepo.SaveFlow(ctx, &models.AuthFlow{
+		ID:        utils.HashToken(code),
+		Kind:      flowFederatedResult,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(federatedResultTTL),
+	}); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// linkFederatedIdentity 외부 계정에 연결된 사용자 찾기
+// 처음 로그인하면 제공자가 확인한 이메일로 기존 사용자에 연결하거나 새 사용자를 만든다.
+// 이메일 인증을 마치지 않은 기존 계정에는 연결하지 않는다 (남이 먼저 가입해 둔 계정 탈취 방지).
+func (s *AuthService) linkFederatedIdentity(ctx context.Context, provider string, identity *federation.Identity) (*models.User, error) {
+	link, err := s.identityRepo.FindIdentity(ctx, provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if link != nil {
+		user, err := s.repo.FindUserByID(ctx, link.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errors.New("user not found")
+		}
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, fmt.Errorf("%w: %s did not provide a verified email", errFederatedEmailNotVerified, provider)
+	}
+
+	user, err := s.repo.FindUserByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		// 비밀번호 없이 생성 (비밀번호 재설정으로 나중에 설정 가능)
+		user = &models.User{
+			Email:         identity.Email,
+			EmailVerified: true,
+			Roles:         []string{models.RoleBuyer},
+		}
+		if err := s.repo.CreateUser(ctx, user); err != nil {
+			return nil, err
+		}
+	} else if !user.EmailVerified {
+		return nil, fmt.Errorf("%w: verify the email before signing in with %s", errFederatedAccountUnverified, provider)
+	}
+
+	if err := s.identityRepo.CreateIdentity(ctx, &models.FederatedIdentity{
+		Provider: provider,
+		Subject:  identity.Subject,
+		UserID:   user.ID,
+		Email:    identity.Email,
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// federatedRedirectURI 제공자에 등록할 콜백 URL
+func (s *AuthService) federatedRedirectURI(provider string) string {
+	return fmt.Sprintf("%s/auth/federated/%s/callback", s.config.Issuer(), provider)
+}
+
+// federatedCallbackURL 웹 앱의 외부 로그인 결과 화면 URL
+func (s *AuthService) federatedCallbackURL(params url.Values) string {
+	return fmt.Sprintf("%s/federated/callback?%s", s.config.WebAppURL, params.Encode())
+}
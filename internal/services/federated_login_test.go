@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/federation"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/federation/federationtest"
+)
+
+// newFederatedTestService 가짜 제공자를 "test"와 "other" 이름으로 등록한 테스트용 AuthService
+func newFederatedTestService(t *testing.T) (*testService, *federationtest.Issuer) {
+	t.Helper()
+
+	issuer := federationtest.NewIssuer(t)
+	providers, err := federation.NewProviders([]config.FederatedProvider{
+		{Name: "test", Issuer: issuer.URL, ClientID: issuer.ClientID, ClientSecret: issuer.ClientSecret},
+		{Name: "other", Issuer: issuer.URL, ClientID: issuer.ClientID, ClientSecret: issuer.ClientSecret},
+	})
+	if err != nil {
+		t.Fatalf("new providers: %v", err)
+	}
+
+	ts := newTestService(t)
+	ts.federated = providers
+	return ts, issuer
+}
+
+// finishFederated 콜백 처리 후 웹 앱으로 돌아가는 URL의 로그인 코드 (실패하면 error 코드를 에러로)
+func (ts *testService) finishFederated(t *testing.T, provider string, callback url.Values) (string, error) {
+	t.Helper()
+
+	redirect, err := ts.FinishFederatedLogin(context.Background(), provider, callback)
+	if err != nil {
+		t.Fatalf("finish federated login: %v", err)
+	}
+	u, err := url.Parse(redirect)
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	if !strings.HasPrefix(redirect, ts.config.WebAppURL+"/federated/callback?") {
+		t.Fatalf("redirect %s does not go to the web app", redirect)
+	}
+	if msg := u.Query().Get("error"); msg != "" {
+		return "", errors.New(msg)
+	}
+	return u.Query().Get("code"), nil
+}
+
+// federatedLogin 로그인 시작부터 로그인 코드 교환까지
+func (ts *testService) federatedLogin(t *testing.T, issuer *federationtest.Issuer) (*models.LoginResponse, error) {
+	t.Helper()
+
+	authURL, err := ts.BeginFederatedLogin(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("begin federated login: %v", err)
+	}
+	code, err := ts.finishFederated(t, "test", issuer.Authorize(t, authURL))
+	if err != nil {
+		return nil, err
+	}
+	return ts.ExchangeFederatedLogin(context.Background(), &models.FederatedExchangeRequest{Code: code})
+}
+
+// tokenUser 액세스 토큰의 사용자
+func (ts *testService) tokenUser(t *testing.T, resp *models.LoginResponse) *models.User {
+	t.Helper()
+
+	claims, err := ts.ValidateToken(context.Background(), resp.Token)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	user, err := ts.userFromClaims(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("token user: %v", err)
+	}
+	return user
+}
+
+func TestFederatedLoginCreatesUser(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "new@example.com", EmailVerified: true})
+
+	resp, err := ts.federatedLogin(t, issuer)
+	if err != nil {
+		t.Fatalf("federated login: %v", err)
+	}
+	user := ts.tokenUser(t, resp)
+	if user.Email != "new@example.com" || !user.EmailVerified || user.Password != "" {
+		t.Errorf("created user = %+v", user)
+	}
+	if !user.HasRole(models.RoleBuyer) {
+		t.Errorf("created user roles = %v, want buyer", user.Roles)
+	}
+
+	link, err := ts.stores.FederatedIdentities.FindIdentity(context.Background(), "test", "subject-1")
+	if err != nil || link == nil || link.UserID != user.ID {
+		t.Fatalf("identity link = %+v, %v; want user %s", link, err, user.ID.Hex())
+	}
+
+	// 다시 로그인하면 연결된 같은 사용자
+	resp, err = ts.federatedLogin(t, issuer)
+	if err != nil {
+		t.Fatalf("second federated login: %v", err)
+	}
+	if again := ts.tokenUser(t, resp); again.ID != user.ID {
+		t.Errorf("second login user = %s, want %s", again.ID.Hex(), user.ID.Hex())
+	}
+}
+
+func TestFederatedLoginLinksVerifiedEmail(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	existing := ts.createUser(t, "member@example.com", "Password123!", true)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "member@example.com", EmailVerified: true})
+
+	resp, err := ts.federatedLogin(t, issuer)
+	if err != nil {
+		t.Fatalf("federated login: %v", err)
+	}
+	if user := ts.tokenUser(t, resp); user.ID != existing.ID {
+		t.Errorf("federated login user = %s, want existing user %s", user.ID.Hex(), existing.ID.Hex())
+	}
+
+	// 연결된 뒤에는 제공자 쪽 이메일이 바뀌어도 같은 사용자
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "changed@example.com", EmailVerified: true})
+	resp, err = ts.federatedLogin(t, issuer)
+	if err != nil {
+		t.Fatalf("second federated login: %v", err)
+	}
+	if user := ts.tokenUser(t, resp); user.ID != existing.ID {
+		t.Errorf("second login user = %s, want existing user %s", user.ID.Hex(), existing.ID.Hex())
+	}
+}
+
+func TestFederatedLoginDoesNotLinkUnverifiedAccount(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", false)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "member@example.com", EmailVerified: true})
+
+	if _, err := ts.federatedLogin(t, issuer); err == nil || err.Error() != "account_exists_unverified" {
+		t.Fatalf("federated login = %v, want account_exists_unverified", err)
+	}
+	if link, _ := ts.stores.FederatedIdentities.FindIdentity(context.Background(), "test", "subject-1"); link != nil {
+		t.Error("identity was linked to an unverified account")
+	}
+}
+
+func TestFederatedLoginRequiresVerifiedEmail(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "new@example.com", EmailVerified: false})
+
+	if _, err := ts.federatedLogin(t, issuer); err == nil || err.Error() != "email_not_verified" {
+		t.Fatalf("federated login = %v, want email_not_verified", err)
+	}
+	if user, _ := ts.stores.Users.FindUserByEmail(context.Background(), "new@example.com"); user != nil {
+		t.Error("user was created from an unverified email")
+	}
+}
+
+func TestFederatedLoginRejectsNonceMismatch(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "new@example.com", EmailVerified: true})
+	issuer.Tamper(func(c jwt.MapClaims) { c["nonce"] = "replayed-nonce" })
+
+	if _, err := ts.federatedLogin(t, issuer); err == nil || err.Error() != "access_denied" {
+		t.Fatalf("federated login = %v, want access_denied", err)
+	}
+	if user, _ := ts.stores.Users.FindUserByEmail(context.Background(), "new@example.com"); user != nil {
+		t.Error("user was created from an id token with the wrong nonce")
+	}
+}
+
+func TestFederatedLoginState(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "new@example.com", EmailVerified: true})
+	ctx := context.Background()
+
+	begin := func() url.Values {
+		authURL, err := ts.BeginFederatedLogin(ctx, "test")
+		if err != nil {
+			t.Fatalf("begin federated login: %v", err)
+		}
+		return issuer.Authorize(t, authURL)
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		callback := begin()
+		callback.Del("state")
+		if _, err := ts.finishFederated(t, "test", callback); err == nil || err.Error() != "access_denied" {
+			t.Errorf("callback without state = %v, want access_denied", err)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		callback := begin()
+		callback.Set("state", "forged-state")
+		if _, err := ts.finishFederated(t, "test", callback); err == nil || err.Error() != "access_denied" {
+			t.Errorf("callback with unknown state = %v, want access_denied", err)
+		}
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		callback := begin()
+		if _, err := ts.finishFederated(t, "test", callback); err != nil {
+			t.Fatalf("first callback: %v", err)
+		}
+		requests := issuer.TokenRequests()
+		if _, err := ts.finishFederated(t, "test", callback); err == nil || err.Error() != "access_denied" {
+			t.Errorf("replayed callback = %v, want access_denied", err)
+		}
+		if issuer.TokenRequests() != requests {
+			t.Error("replayed callback reached the token endpoint")
+		}
+	})
+
+	t.Run("other provider", func(t *testing.T) {
+		// "test"로 시작한 state를 "other" 콜백으로 보내면 거부
+		callback := begin()
+		if _, err := ts.finishFederated(t, "other", callback); err == nil || err.Error() != "access_denied" {
+			t.Errorf("callback for another provider = %v, want access_denied", err)
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		callback := begin()
+		callback.Del("code")
+		callback.Set("error", "access_denied")
+		if _, err := ts.finishFederated(t, "test", callback); err == nil || err.Error() != "access_denied" {
+			t.Errorf("callback with provider error = %v, want access_denied", err)
+		}
+		// 취소된 state도 다시 쓸 수 없다
+		callback.Del("error")
+		callback.Set("code", "late-code")
+		if _, err := ts.finishFederated(t, "test", callback); err == nil || err.Error() != "access_denied" {
+			t.Errorf("reused cancelled state = %v, want access_denied", err)
+		}
+	})
+}
+
+func TestFederatedExchangeCodeIsSingleUse(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "new@example.com", EmailVerified: true})
+	ctx := context.Background()
+
+	authURL, err := ts.BeginFederatedLogin(ctx, "test")
+	if err != nil {
+		t.Fatalf("begin federated login: %v", err)
+	}
+	code, err := ts.finishFederated(t, "test", issuer.Authorize(t, authURL))
+	if err != nil {
+		t.Fatalf("finish federated login: %v", err)
+	}
+
+	req := &models.FederatedExchangeRequest{Code: code}
+	if _, err := ts.ExchangeFederatedLogin(ctx, req); err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if _, err := ts.ExchangeFederatedLogin(ctx, req); err == nil {
+		t.Error("login code was exchanged twice")
+	}
+}
+
+func TestFederatedLoginUnknownProvider(t *testing.T) {
+	ts, _ := newFederatedTestService(t)
+
+	if _, err := ts.BeginFederatedLogin(context.Background(), "missing"); err != ErrUnknownProvider {
+		t.Errorf("BeginFederatedLogin(missing) = %v, want %v", err, ErrUnknownProvider)
+	}
+	if _, err := ts.FinishFederatedLogin(context.Background(), "missing", url.Values{}); err != ErrUnknownProvider {
+		t.Errorf("FinishFederatedLogin(missing) = %v, want %v", err, ErrUnknownProvider)
+	}
+}
+
+func TestFederatedErrorCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"access denied":      {fmt.Errorf("%w: invalid login state", errFederatedAccessDenied), "access_denied"},
+		"email not verified": {fmt.Errorf("%w: test did not provide a verified email", errFederatedEmailNotVerified), "email_not_verified"},
+		"account unverified": {fmt.Errorf("%w: verify the email", errFederatedAccountUnverified), "account_exists_unverified"},
+		"storage failure":    {errors.New("connection refused: mongo.internal:27017"), "server_error"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := federatedErrorCode(tt.err); got != tt.want {
+				t.Errorf("federatedErrorCode = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFederatedCallbackHidesErrorDetails(t *testing.T) {
+	ts, issuer := newFederatedTestService(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "new@example.com", EmailVerified: true})
+	issuer.Tamper(func(c jwt.MapClaims) { c["nonce"] = "replayed-nonce" })
+
+	authURL, err := ts.BeginFederatedLogin(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("begin federated login: %v", err)
+	}
+	redirect, err := ts.FinishFederatedLogin(context.Background(), "test", issuer.Authorize(t, authURL))
+	if err != nil {
+		t.Fatalf("finish federated login: %v", err)
+	}
+
+	// 리다이렉트에는 에러 코드만 있고 제공자 응답이나 내부 에러 메시지는 없다
+	u, err := url.Parse(redirect)
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	if len(u.Query()) != 1 || u.Query().Get("error") != "access_denied" {
+		t.Errorf("redirect query = %v, want only error=access_denied", u.Query())
+	}
+	if strings.Contains(redirect, "nonce") {
+		t.Errorf("redirect %s leaks the underlying error", redirect)
+	}
+}
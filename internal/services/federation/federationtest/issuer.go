@@ -0,0 +1,277 @@
+// Package federationtest 외부 로그인 테스트용 가짜 OIDC 제공자
+//
+// discovery, JWKS, 토큰, userinfo 엔드포인트를 httptest 서버로 제공하고,
+// Authorize로 사용자가 제공자 화면에서 로그인을 마친 것처럼 콜백 파라미터를 만든다.
+package federationtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// User 제공자에 로그인한 사용자
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+
+	// ID 토큰에서 email_verified를 빼고 userinfo로만 알려준다 (카카오처럼)
+	OmitEmailVerified bool
+}
+
+// authRequest Authorize로 발급한 인가 코드의 요청 정보
+type authRequest struct {
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Issuer 가짜 OIDC 제공자
+type Issuer struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+
+	server *httptest.Server
+	mu     sync.Mutex
+	key    *utils.SigningKey
+	signer *utils.SigningKey // ID 토큰 서명 키 (기본은 JWKS에 공개한 key)
+	user   User
+	tamper func(claims jwt.MapClaims)
+	codes  map[string]authRequest
+
+	discoveryRequests int
+	jwksRequests      int
+	tokenRequests     int
+}
+
+// NewIssuer RS256 키로 서명하는 가짜 제공자 시작 (테스트가 끝나면 종료)
+func NewIssuer(t *testing.T) *Issuer {
+	t.Helper()
+
+	key, err := utils.GenerateSigningKey(utils.AlgRS256)
+	if err != nil {
+		t.Fatalf("generate issuer key: %v", err)
+	}
+
+	issuer := &Issuer{
+		ClientID:     "test-client",
+		ClientSecret: "test-secret",
+		key:          key,
+		signer:       key,
+		codes:        make(map[string]authRequest),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/token", issuer.token)
+	mux.HandleFunc("/userinfo", issuer.userInfo)
+
+	issuer.server = httptest.NewServer(mux)
+	issuer.URL = issuer.server.URL
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// SetUser 다음 로그인에 사용할 사용자
+func (i *Issuer) SetUser(user User) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.user = user
+}
+
+// Tamper 서명하기 전에 ID 토큰 클레임 변경 (aud, exp, nonce 등 검증 테스트용)
+func (i *Issuer) Tamper(fn func(claims jwt.MapClaims)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tamper = fn
+}
+
+// SignWith JWKS에 공개하지 않은 키로 ID 토큰 서명
+func (i *Issuer) SignWith(key *utils.SigningKey) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.signer = key
+}
+
+// KeyID JWKS에 공개한 키의 kid
+func (i *Issuer) KeyID() string {
+	return i.key.ID
+}
+
+// DiscoveryRequests discovery 문서 요청 수
+func (i *Issuer) DiscoveryRequests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.discoveryRequests
+}
+
+// JWKSRequests JWKS 요청 수
+func (i *Issuer) JWKSRequests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.jwksRequests
+}
+
+// TokenRequests 토큰 엔드포인트 요청 수
+func (i *Issuer) TokenRequests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.tokenRequests
+}
+
+// Authorize 로그인 화면 URL을 받아 사용자가 로그인을 마친 뒤의 콜백 파라미터(code, state) 반환
+func (i *Issuer) Authorize(t *testing.T, authURL string) url.Values {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	if !strings.HasPrefix(authURL, i.URL+"/authorize?") {
+		t.Fatalf("authorization url %s does not use the discovered endpoint", authURL)
+	}
+
+	query := u.Query()
+	checks := map[string]string{
+		"response_type":         "code",
+		"client_id":             i.ClientID,
+		"code_challenge_method": "S256",
+	}
+	for name, want := range checks {
+		if got := query.Get(name); got != want {
+			t.Fatalf("authorization request %s = %q, want %q", name, got, want)
+		}
+	}
+	for _, name := range []string{"redirect_uri", "state", "nonce", "code_challenge"} {
+		if query.Get(name) == "" {
+			t.Fatalf("authorization request has no %s", name)
+		}
+	}
+
+	code := utils.GenerateRandomToken(16)
+	i.mu.Lock()
+	i.codes[code] = authRequest{
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	i.mu.Unlock()
+
+	return url.Values{"code": {code}, "state": {query.Get("state")}}
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	i.discoveryRequests++
+	i.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/authorize",
+		"token_endpoint":         i.URL + "/token",
+		"userinfo_endpoint":      i.URL + "/userinfo",
+		"jwks_uri":               i.URL + "/jwks",
+	})
+}
+
+func (i *Issuer) jwks(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	i.jwksRequests++
+	i.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, utils.NewJWKS(i.key))
+}
+
+// token 인가 코드 교환 (클라이언트 인증, redirect_uri, PKCE 확인 후 ID 토큰 발급)
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tokenRequests++
+
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("client_id") != i.ClientID || r.PostForm.Get("client_secret") != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	request, ok := i.codes[code]
+	delete(i.codes, code) // 인가 코드는 한 번만 사용
+	if r.PostForm.Get("grant_type") != "authorization_code" || !ok ||
+		r.PostForm.Get("redirect_uri") != request.redirectURI ||
+		!utils.VerifyPKCE(r.PostForm.Get("code_verifier"), request.codeChallenge) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   i.URL,
+		"aud":   i.ClientID,
+		"sub":   i.user.Subject,
+		"nonce": request.nonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(5 * time.Minute).Unix(),
+	}
+	if i.user.Email != "" {
+		claims["email"] = i.user.Email
+	}
+	if !i.user.OmitEmailVerified {
+		claims["email_verified"] = i.user.EmailVerified
+	}
+	if i.tamper != nil {
+		i.tamper(claims)
+	}
+
+	token := jwt.NewWithClaims(i.signer.Method(), claims)
+	if i.signer.ID != "" {
+		token.Header["kid"] = i.signer.ID
+	}
+	idToken, err := token.SignedString(i.signer.Key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "access-" + code,
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func (i *Issuer) userInfo(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer access-") {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sub":            i.user.Subject,
+		"email":          i.user.Email,
+		"email_verified": i.user.EmailVerified,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,163 @@
+package federation
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// 알 수 없는 kid로 JWKS를 다시 받아오는 최소 간격
+const jwksRefreshInterval = time.Minute
+
+// idTokenClaims 외부 제공자의 ID 토큰 클레임
+type idTokenClaims struct {
+	Email         string    `json:"email"`
+	EmailVerified *flexBool `json:"email_verified"`
+	Nonce         string    `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// flexBool true/false 또는 "true"/"false" 문자열을 모두 허용하는 bool
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean: %s", data)
+	}
+	return nil
+}
+
+// discover OIDC discovery 문서로 엔드포인트 설정 (성공할 때까지 요청마다 재시도)
+func (p *Provider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovered || p.Issuer == "" {
+		return nil
+	}
+
+	var metadata struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	if err := p.getJSON(ctx, p.Issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return err
+	}
+	if strings.TrimRight(metadata.Issuer, "/") != p.Issuer {
+		return fmt.Errorf("%s discovery issuer mismatch: %s", p.Name, metadata.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return fmt.Errorf("%s discovery document is incomplete", p.Name)
+	}
+
+	p.authURL = metadata.AuthorizationEndpoint
+	p.tokenURL = metadata.TokenEndpoint
+	p.userInfoURL = metadata.UserInfoEndpoint
+	p.jwksURL = metadata.JWKSURI
+	p.keys = &keySet{provider: p}
+	p.discovered = true
+	return nil
+}
+
+// verifyIDToken ID 토큰의 서명(제공자 JWKS), iss, aud, exp, nonce 검증
+func (p *Provider) verifyIDToken(ctx context.Context, raw, nonce string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.key(ctx, kid)
+	},
+		// 대칭키(HS256)는 client secret으로 서명되므로 허용하지 않음
+		jwt.WithValidMethods([]string{utils.AlgRS256, "RS384", "RS512", utils.AlgES256, "ES384", utils.AlgEdDSA}),
+		jwt.WithIssuer(p.Issuer),
+		jwt.WithAudience(p.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s id token: %w", p.Name, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("invalid %s id token: missing subject", p.Name)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("invalid %s id token: nonce mismatch", p.Name)
+	}
+	return claims, nil
+}
+
+// keySet 제공자 JWKS 캐시 (모르는 kid가 오면 다시 받아옴)
+type keySet struct {
+	provider  *Provider
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func (s *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	if time.Since(s.fetchedAt) < jwksRefreshInterval {
+		return nil, errors.New("unknown signing key")
+	}
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, errors.New("unknown signing key")
+}
+
+// lookup kid로 키 찾기 (kid가 없는 토큰은 키가 하나일 때만)
+func (s *keySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *keySet) fetch(ctx context.Context) error {
+	var jwks utils.JWKS
+	if err := s.provider.getJSON(ctx, s.provider.jwksURL, &jwks); err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue // 지원하지 않는 키는 건너뜀
+		}
+		keys[jwk.Kid] = key
+	}
+
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,297 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+)
+
+// Identity 외부 제공자가 확인해 준 사용자
+type Identity struct {
+	Subject       string // 제공자 안에서 고유한 사용자 ID
+	Email         string
+	EmailVerified bool
+}
+
+// Provider 외부 로그인 제공자 (OIDC 또는 네이버처럼 프로필 API를 쓰는 OAuth 2.0)
+type Provider struct {
+	Name         string
+	Issuer       string // 비어 있으면 ID 토큰 없이 프로필 API로 사용자 확인
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+	jwksURL     string
+	profile     func(body []byte) (*Identity, error) // 비 OIDC 제공자의 프로필 응답 해석
+
+	httpClient *http.Client
+	mu         sync.Mutex
+	discovered bool
+	keys       *keySet
+}
+
+// NewProviders 설정된 제공자 목록 생성 (google, kakao, naver는 기본 엔드포인트 사용)
+func NewProviders(cfgs []config.FederatedProvider) (map[string]*Provider, error) {
+	providers := make(map[string]*Provider, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.ClientID == "" {
+			return nil, fmt.Errorf("federated provider %s: client id is required", cfg.Name)
+		}
+
+		provider := &Provider{
+			Name:         cfg.Name,
+			Issuer:       cfg.Issuer,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Scopes:       cfg.Scopes,
+			httpClient:   &http.Client{Timeout: 10 * time.Second},
+		}
+		applyPreset(provider)
+
+		if provider.Issuer == "" && provider.profile == nil {
+			return nil, fmt.Errorf("federated provider %s: issuer is required", cfg.Name)
+		}
+		if len(provider.Scopes) == 0 && provider.profile == nil {
+			provider.Scopes = []string{"openid", "email"}
+		}
+		provider.Issuer = strings.TrimRight(provider.Issuer, "/")
+		providers[cfg.Name] = provider
+	}
+	return providers, nil
+}
+
+// applyPreset 잘 알려진 제공자의 기본 설정 (설정 파일 값이 우선)
+func applyPreset(p *Provider) {
+	switch p.Name {
+	case "google":
+		if p.Issuer == "" {
+			p.Issuer = "https://accounts.google.com"
+		}
+		if len(p.Scopes) == 0 {
+			p.Scopes = []string{"openid", "email"}
+		}
+	case "kakao":
+		// 카카오 ID 토큰에는 email_verified가 없어 userinfo로 확인
+		if p.Issuer == "" {
+			p.Issuer = "https://kauth.kakao.com"
+		}
+		if len(p.Scopes) == 0 {
+			p.Scopes = []string{"openid", "account_email"}
+		}
+	case "naver":
+		// 네이버 로그인은 OIDC가 아니므로 프로필 API로 사용자 확인
+		if p.Issuer == "" {
+			p.authURL = "https://nid.naver.com/oauth2.0/authorize"
+			p.tokenURL = "https://nid.naver.com/oauth2.0/token"
+			p.userInfoURL = "https://openapi.naver.com/v1/nid/me"
+			p.profile = naverProfile
+		}
+	}
+}
+
+// AuthCodeURL 제공자의 로그인 화면 URL (PKCE S256, OIDC면 nonce 포함)
+func (p *Provider) AuthCodeURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	if len(p.Scopes) > 0 {
+		query.Set("scope", strings.Join(p.Scopes, " "))
+	}
+	if p.Issuer != "" {
+		query.Set("nonce", nonce)
+	}
+
+	separator := "?"
+	if strings.Contains(p.authURL, "?") {
+		separator = "&"
+	}
+	return p.authURL + separator + query.Encode(), nil
+}
+
+// Exchange 인가 코드를 교환하고 사용자 확인
+// OIDC 제공자는 ID 토큰(서명, iss, aud, exp, nonce)을 검증하고,
+// ID 토큰에 이메일 인증 여부가 없으면 userinfo로 보완한다.
+func (p *Provider) Exchange(ctx context.Context, code, redirectURI, state, nonce, codeVerifier string) (*Identity, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+	if p.Issuer == "" {
+		form.Set("state", state) // 네이버는 토큰 요청에도 state 필요
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+		Error       string `json:"error"`
+	}
+	if err := p.postForm(ctx, p.tokenURL, form, &token); err != nil {
+		return nil, err
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("%s token request failed: %s", p.Name, token.Error)
+	}
+
+	if p.Issuer == "" {
+		body, err := p.getWithToken(ctx, p.userInfoURL, token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		return p.profile(body)
+	}
+
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%s did not return an id token", p.Name)
+	}
+	claims, err := p.verifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified != nil && bool(*claims.EmailVerified),
+	}
+	if (identity.Email == "" || claims.EmailVerified == nil) && p.userInfoURL != "" && token.AccessToken != "" {
+		if err := p.fillFromUserInfo(ctx, identity, token.AccessToken); err != nil {
+			return nil, err
+		}
+	}
+	return identity, nil
+}
+
+// fillFromUserInfo OIDC userinfo의 이메일 정보로 보완 (sub가 같을 때만)
+func (p *Provider) fillFromUserInfo(ctx context.Context, identity *Identity, accessToken string) error {
+	body, err := p.getWithToken(ctx, p.userInfoURL, accessToken)
+	if err != nil {
+		return err
+	}
+
+	var info struct {
+		Subject       string   `json:"sub"`
+		Email         string   `json:"email"`
+		EmailVerified flexBool `json:"email_verified"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return err
+	}
+	if info.Subject != identity.Subject {
+		return errors.New("userinfo subject does not match the id token")
+	}
+
+	identity.Email = info.Email
+	identity.EmailVerified = bool(info.EmailVerified)
+	return nil
+}
+
+// naverProfile 네이버 프로필 API 응답 해석
+// 네이버는 인증 여부를 따로 주지 않지만 계정에 등록된 확인된 이메일만 제공한다.
+func naverProfile(body []byte) (*Identity, error) {
+	var profile struct {
+		ResultCode string `json:"resultcode"`
+		Message    string `json:"message"`
+		Response   struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(body, &profile); err != nil {
+		return nil, err
+	}
+	if profile.ResultCode != "00" || profile.Response.ID == "" {
+		return nil, fmt.Errorf("naver profile request failed: %s", profile.Message)
+	}
+
+	return &Identity{
+		Subject:       profile.Response.ID,
+		Email:         profile.Response.Email,
+		EmailVerified: profile.Response.Email != "",
+	}, nil
+}
+
+func (p *Provider) postForm(ctx context.Context, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	body, err := p.do(req, true)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (p *Provider) getWithToken(ctx context.Context, endpoint, accessToken string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	return p.do(req, false)
+}
+
+func (p *Provider) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	body, err := p.do(req, false)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// do 요청 실행 (allowClientError면 토큰 엔드포인트의 4xx 오류 본문도 반환)
+func (p *Provider) do(req *http.Request, allowClientError bool) ([]byte, error) {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 && !(allowClientError && resp.StatusCode >= 400 && resp.StatusCode < 500) {
+		return nil, fmt.Errorf("%s request to %s failed with status %d", p.Name, req.URL.Host, resp.StatusCode)
+	}
+	return body, nil
+}
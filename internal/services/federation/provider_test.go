@@ -0,0 +1,242 @@
+package federation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/federation/federationtest"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+const testRedirectURI = "http://localhost:8080/auth/federated/test/callback"
+
+// newTestProvider 가짜 제공자를 쓰는 Provider
+func newTestProvider(t *testing.T, issuer *federationtest.Issuer) *Provider {
+	t.Helper()
+
+	providers, err := NewProviders([]config.FederatedProvider{{
+		Name:         "test",
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+	}})
+	if err != nil {
+		t.Fatalf("new providers: %v", err)
+	}
+	return providers["test"]
+}
+
+// login 로그인 화면 URL 생성부터 코드 교환까지 (exchangeNonce가 비어 있으면 요청한 nonce 사용)
+func login(t *testing.T, provider *Provider, issuer *federationtest.Issuer, exchangeNonce string) (*Identity, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	state := utils.GenerateRandomToken(32)
+	nonce := utils.GenerateRandomToken(16)
+	verifier := utils.GenerateRandomToken(32)
+
+	authURL, err := provider.AuthCodeURL(ctx, testRedirectURI, state, nonce, utils.PKCEChallengeS256(verifier))
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+	callback := issuer.Authorize(t, authURL)
+	if callback.Get("state") != state {
+		t.Fatalf("callback state = %q, want %q", callback.Get("state"), state)
+	}
+
+	if exchangeNonce == "" {
+		exchangeNonce = nonce
+	}
+	return provider.Exchange(ctx, callback.Get("code"), testRedirectURI, state, exchangeNonce, verifier)
+}
+
+func TestExchange(t *testing.T) {
+	issuer := federationtest.NewIssuer(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "fed@example.com", EmailVerified: true})
+	provider := newTestProvider(t, issuer)
+
+	identity, err := login(t, provider, issuer, "")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if identity.Subject != "subject-1" || identity.Email != "fed@example.com" || !identity.EmailVerified {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	// discovery와 JWKS는 한 번만 받아온다
+	if _, err := login(t, provider, issuer, ""); err != nil {
+		t.Fatalf("second exchange: %v", err)
+	}
+	if n := issuer.DiscoveryRequests(); n != 1 {
+		t.Errorf("discovery requests = %d, want 1", n)
+	}
+	if n := issuer.JWKSRequests(); n != 1 {
+		t.Errorf("jwks requests = %d, want 1", n)
+	}
+}
+
+func TestExchangeUnverifiedEmail(t *testing.T) {
+	issuer := federationtest.NewIssuer(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "fed@example.com", EmailVerified: false})
+	provider := newTestProvider(t, issuer)
+
+	identity, err := login(t, provider, issuer, "")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if identity.EmailVerified {
+		t.Error("unverified email reported as verified")
+	}
+}
+
+func TestExchangeFillsEmailVerifiedFromUserInfo(t *testing.T) {
+	issuer := federationtest.NewIssuer(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "fed@example.com", EmailVerified: true, OmitEmailVerified: true})
+	provider := newTestProvider(t, issuer)
+
+	identity, err := login(t, provider, issuer, "")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if !identity.EmailVerified {
+		t.Error("email_verified from userinfo was not used")
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	otherKey, err := utils.GenerateSigningKey(utils.AlgRS256)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(issuer *federationtest.Issuer)
+		nonce  string
+		reason string
+	}{
+		{
+			name:   "wrong audience",
+			setup:  func(i *federationtest.Issuer) { i.Tamper(func(c jwt.MapClaims) { c["aud"] = "another-client" }) },
+			reason: "audience",
+		},
+		{
+			name: "wrong issuer",
+			setup: func(i *federationtest.Issuer) {
+				i.Tamper(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" })
+			},
+			reason: "issuer",
+		},
+		{
+			name: "expired",
+			setup: func(i *federationtest.Issuer) {
+				i.Tamper(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() })
+			},
+			reason: "expired",
+		},
+		{
+			name:   "missing expiry",
+			setup:  func(i *federationtest.Issuer) { i.Tamper(func(c jwt.MapClaims) { delete(c, "exp") }) },
+			reason: "exp",
+		},
+		{
+			name:   "missing subject",
+			setup:  func(i *federationtest.Issuer) { i.Tamper(func(c jwt.MapClaims) { delete(c, "sub") }) },
+			reason: "subject",
+		},
+		{
+			name:   "nonce mismatch",
+			nonce:  "another-nonce",
+			reason: "nonce",
+		},
+		{
+			name:   "unpublished signing key",
+			setup:  func(i *federationtest.Issuer) { i.SignWith(otherKey) },
+			reason: "signing key",
+		},
+		{
+			name: "forged signature",
+			setup: func(i *federationtest.Issuer) {
+				// 공개된 kid를 쓰지만 다른 키로 서명
+				forged := *otherKey
+				forged.ID = i.KeyID()
+				i.SignWith(&forged)
+			},
+			reason: "signature",
+		},
+		{
+			name: "symmetric signature",
+			setup: func(i *federationtest.Issuer) {
+				// client secret으로 서명한 HS256 토큰은 허용하지 않는다
+				i.SignWith(utils.NewHMACSigningKey("", []byte(i.ClientSecret)))
+			},
+			reason: "signing method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := federationtest.NewIssuer(t)
+			issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "fed@example.com", EmailVerified: true})
+			provider := newTestProvider(t, issuer)
+			if tt.setup != nil {
+				tt.setup(issuer)
+			}
+
+			identity, err := login(t, provider, issuer, tt.nonce)
+			if err == nil {
+				t.Fatalf("exchange succeeded with identity %+v", identity)
+			}
+			if !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("error %q does not mention %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestExchangeRejectsWrongCodeVerifier(t *testing.T) {
+	issuer := federationtest.NewIssuer(t)
+	issuer.SetUser(federationtest.User{Subject: "subject-1", Email: "fed@example.com", EmailVerified: true})
+	provider := newTestProvider(t, issuer)
+	ctx := context.Background()
+
+	authURL, err := provider.AuthCodeURL(ctx, testRedirectURI, "state", "nonce", utils.PKCEChallengeS256(utils.GenerateRandomToken(32)))
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+	callback := issuer.Authorize(t, authURL)
+
+	_, err = provider.Exchange(ctx, callback.Get("code"), testRedirectURI, "state", "nonce", utils.GenerateRandomToken(32))
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("exchange with a wrong code verifier = %v, want invalid_grant", err)
+	}
+}
+
+func TestDiscoveryRejectsIssuerMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"issuer": "https://evil.example.com",
+			"authorization_endpoint": "https://evil.example.com/authorize",
+			"token_endpoint": "https://evil.example.com/token",
+			"jwks_uri": "https://evil.example.com/jwks"
+		}`))
+	}))
+	defer server.Close()
+
+	providers, err := NewProviders([]config.FederatedProvider{{Name: "test", Issuer: server.URL, ClientID: "client"}})
+	if err != nil {
+		t.Fatalf("new providers: %v", err)
+	}
+	_, err = providers["test"].AuthCodeURL(context.Background(), testRedirectURI, "state", "nonce", "challenge")
+	if err == nil || !strings.Contains(err.Error(), "issuer mismatch") {
+		t.Fatalf("AuthCodeURL = %v, want issuer mismatch", err)
+	}
+}
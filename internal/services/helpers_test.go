@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/repository/memory"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/email"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// testSecret 테스트용 HMAC 서명 키
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// recordingSender 발송한 메일을 보관하는 테스트용 Sender
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []*email.Message
+}
+
+func (r *recordingSender) Send(ctx context.Context, msg *email.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// subjects 발송한 메일 제목 (보낸 순서)
+func (r *recordingSender) subjects() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subjects := make([]string, len(r.messages))
+	for i, msg := range r.messages {
+		subjects[i] = msg.Subject
+	}
+	return subjects
+}
+
+// testService 메모리 저장소를 쓰는 테스트용 AuthService
+type testService struct {
+	*AuthService
+	stores repository.Stores
+	mail   *recordingSender
+}
+
+// newTestService 기본 설정으로 테스트용 AuthService 생성 (configure로 설정 변경)
+func newTestService(t *testing.T, configure ...func(*config.Config)) *testService {
+	t.Helper()
+
+	cfg := &config.Config{
+		AccessTokenTTL:          15,
+		RefreshTokenTTL:         24,
+		WebAppURL:               "http://localhost:3000",
+		EmailVerificationPolicy: config.EmailVerificationAllow,
+	}
+	for _, fn := range configure {
+		fn(cfg)
+	}
+
+	stores := memory.NewStores()
+	mail := &recordingSender{}
+	keys := utils.NewKeySet(utils.NewHMACSigningKey("", testSecret))
+	s := NewAuthService(stores, email.NewEmailService(mail, "no-reply@example.com"), nil, keys, nil, cfg)
+	return &testService{AuthService: s, stores: stores, mail: mail}
+}
+
+// createUser 비밀번호와 함께 사용자 생성
+func (ts *testService) createUser(t *testing.T, emailAddr, password string, verified bool) *models.User {
+	t.Helper()
+
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	user := &models.User{
+		Email:         emailAddr,
+		Password:      hashed,
+		EmailVerified: verified,
+		Roles:         []string{models.RoleBuyer},
+	}
+	if err := ts.stores.Users.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	created, err := ts.stores.Users.FindUserByEmail(context.Background(), emailAddr)
+	if err != nil || created == nil {
+		t.Fatalf("find created user: %v", err)
+	}
+	return created
+}
+
+// login 비밀번호 로그인 (2단계 인증 없이 토큰이 발급되어야 함)
+func (ts *testService) login(t *testing.T, emailAddr, password string) *models.LoginResponse {
+	t.Helper()
+
+	resp, err := ts.LoginUser(context.Background(), &models.LoginRequest{Email: emailAddr, Password: password})
+	if err != nil {
+		t.Fatalf("login %s: %v", emailAddr, err)
+	}
+	if resp.Token == "" {
+		t.Fatalf("login %s: no access token in response", emailAddr)
+	}
+	return resp
+}
+
+// claims 로그인 응답의 액세스 토큰 클레임
+func (ts *testService) claims(t *testing.T, resp *models.LoginResponse) *utils.JWTClaim {
+	t.Helper()
+
+	claims, err := ts.ValidateToken(context.Background(), resp.Token)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	return claims
+}
+
+// newOAuthTestService OAuth scope를 설정하고 key로 서명하는 테스트용 AuthService
+func newOAuthTestService(t *testing.T, key *utils.SigningKey) *testService {
+	t.Helper()
+
+	ts := newTestService(t, func(cfg *config.Config) {
+		cfg.IssuerURL = "http://localhost:8001"
+		cfg.OAuthScopes = []string{models.ScopeOpenID, models.ScopeProfile, models.ScopeEmail, models.ScopeOfflineAccess}
+	})
+	ts.keys = utils.NewKeySet(key)
+	return ts
+}
+
+// testRedirectURI 테스트 클라이언트의 redirect URI
+const testRedirectURI = "http://localhost:9000/callback"
+
+// createClient owner가 소유한 기밀 클라이언트 등록 (scopes가 없으면 지원하는 전체 scope)
+func (ts *testService) createClient(t *testing.T, owner *models.LoginResponse, scopes ...string) *models.CreateOAuthClientResponse {
+	t.Helper()
+
+	client, err := ts.CreateOAuthClient(context.Background(), ts.claims(t, owner), &models.CreateOAuthClientRequest{
+		Name:         "test client",
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       scopes,
+	})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client
+}
+
+// authorizeRequest client의 PKCE 인가 요청
+func authorizeRequest(client *models.CreateOAuthClientResponse, scope, verifier string) *models.AuthorizeRequest {
+	return &models.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            client.ClientID,
+		RedirectURI:         testRedirectURI,
+		Scope:               scope,
+		State:               "state-1",
+		CodeChallenge:       utils.PKCEChallengeS256(verifier),
+		CodeChallengeMethod: "S256",
+		Nonce:               "nonce-1",
+	}
+}
+
+// authorize user가 동의한 뒤 redirect URI로 돌아가는 쿼리 (code 또는 error)
+func (ts *testService) authorize(t *testing.T, user *models.LoginResponse, req *models.AuthorizeRequest) url.Values {
+	t.Helper()
+
+	resp, err := ts.Authorize(context.Background(), ts.claims(t, user), req)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	u, err := url.Parse(resp.RedirectTo)
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	return u.Query()
+}
+
+// exchangeCode 인가 코드를 토큰으로 교환
+func (ts *testService) exchangeCode(client *models.CreateOAuthClientResponse, code, verifier string) (*models.OAuthTokenResponse, error) {
+	return ts.OAuthToken(context.Background(), &models.OAuthTokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: verifier,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+	})
+}
+
+// waitFor 요청과 별개로 실행되는 작업(실패 기록 등)이 끝날 때까지 대기
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// storedUser 저장소의 현재 사용자
+func (ts *testService) storedUser(t *testing.T, id primitive.ObjectID) *models.User {
+	t.Helper()
+
+	user, err := ts.stores.Users.FindUserByID(context.Background(), id)
+	if err != nil || user == nil {
+		t.Fatalf("find user %s: %v", id.Hex(), err)
+	}
+	return user
+}
+
+// loginAdmin admin 역할을 가진 사용자를 만들고 로그인
+func (ts *testService) loginAdmin(t *testing.T, emailAddr string) *models.LoginResponse {
+	t.Helper()
+
+	user := ts.createUser(t, emailAddr, "Password123!", true)
+	if _, err := ts.stores.Users.SetRoles(context.Background(), user.ID, []string{models.RoleBuyer, models.RoleAdmin}); err != nil {
+		t.Fatalf("grant admin role: %v", err)
+	}
+	return ts.login(t, emailAddr, "Password123!")
+}
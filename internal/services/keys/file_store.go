@@ -0,0 +1,106 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// FileStore 디렉터리에 키마다 <kid>.json 파일로 저장하는 Store
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore FileStore 생성자
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("key directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// ListKeys 저장된 모든 키 조회
+func (s *FileStore) ListKeys(ctx context.Context) ([]*models.SigningKeyRecord, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*models.SigningKeyRecord, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// 다른 인스턴스가 방금 삭제한 경우
+				continue
+			}
+			return nil, err
+		}
+
+		var record models.SigningKeyRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+// SaveKey 키 저장 (임시 파일에 쓴 뒤 rename)
+func (s *FileStore) SaveKey(ctx context.Context, key *models.SigningKeyRecord) error {
+	path, err := s.path(key.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".key-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// DeleteKey 키 삭제
+func (s *FileStore) DeleteKey(ctx context.Context, kid string) error {
+	path, err := s.path(kid)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path kid를 파일 경로로 변환 (경로 조작 방지)
+func (s *FileStore) path(kid string) (string, error) {
+	if kid == "" || strings.ContainsAny(kid, `/\`) || strings.HasPrefix(kid, ".") {
+		return "", errors.New("invalid key id")
+	}
+	return filepath.Join(s.dir, kid+".json"), nil
+}
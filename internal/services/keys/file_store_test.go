@@ -0,0 +1,105 @@
+package keys
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	ctx := context.Background()
+
+	activatesAt := time.Now().UTC().Truncate(time.Second)
+	record := &models.SigningKeyRecord{ID: "kid-1", Algorithm: utils.AlgHS256, PrivateKey: "c2VjcmV0", ActivatesAt: activatesAt}
+	if err := store.SaveKey(ctx, record); err != nil {
+		t.Fatalf("SaveKey: %v", err)
+	}
+
+	// 개인키를 담으므로 소유자만 읽을 수 있다
+	info, err := os.Stat(filepath.Join(dir, "kid-1.json"))
+	if err != nil {
+		t.Fatalf("stat key file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("key file permission = %o, want 600", perm)
+	}
+
+	// 같은 kid로 다시 저장하면 덮어쓴다
+	record.ActivatesAt = activatesAt.Add(time.Hour)
+	if err := store.SaveKey(ctx, record); err != nil {
+		t.Fatalf("SaveKey again: %v", err)
+	}
+	records, err := store.ListKeys(ctx)
+	if err != nil {
+		t.Fatalf("ListKeys: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "kid-1" || records[0].PrivateKey != "c2VjcmV0" || !records[0].ActivatesAt.Equal(record.ActivatesAt) {
+		t.Fatalf("records = %+v", records)
+	}
+
+	// 임시 파일이 남지 않는다
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("key directory has %d entries, want 1", len(entries))
+	}
+
+	if err := store.DeleteKey(ctx, "kid-1"); err != nil {
+		t.Fatalf("DeleteKey: %v", err)
+	}
+	if err := store.DeleteKey(ctx, "kid-1"); err != nil {
+		t.Errorf("DeleteKey of a missing key: %v", err)
+	}
+	if records, _ := store.ListKeys(ctx); len(records) != 0 {
+		t.Errorf("records after delete = %+v", records)
+	}
+}
+
+func TestFileStoreRejectsInvalidKid(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+
+	for _, kid := range []string{"", "../escape", `..\escape`, ".hidden", "a/b"} {
+		if err := store.SaveKey(context.Background(), &models.SigningKeyRecord{ID: kid}); err == nil {
+			t.Errorf("SaveKey accepted kid %q", kid)
+		}
+		if err := store.DeleteKey(context.Background(), kid); err == nil {
+			t.Errorf("DeleteKey accepted kid %q", kid)
+		}
+	}
+}
+
+func TestKeyRingWithFileStore(t *testing.T) {
+	dir := t.TempDir()
+	clock := &testClock{now: time.Now()}
+
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	ring := newTestRing(t, store, clock, testOptions)
+	token := signToken(t, ring.SigningKey())
+
+	// 재시작 후에도 같은 키를 읽는다
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	restarted := newTestRing(t, reopened, clock, testOptions)
+	if restarted.SigningKey().ID != ring.SigningKey().ID {
+		t.Errorf("signing key after restart = %s, want %s", restarted.SigningKey().ID, ring.SigningKey().ID)
+	}
+	if _, err := utils.ValidateJWT(context.Background(), token, restarted); err != nil {
+		t.Errorf("token issued before restart: %v", err)
+	}
+}
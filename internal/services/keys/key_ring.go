@@ -0,0 +1,330 @@
+package keys
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// 키 상태 점검 주기
+const checkInterval = time.Minute
+
+// Store 서명 키 저장소 인터페이스
+type Store interface {
+	ListKeys(ctx context.Context) ([]*models.SigningKeyRecord, error)
+	SaveKey(ctx context.Context, key *models.SigningKeyRecord) error
+	DeleteKey(ctx context.Context, kid string) error
+}
+
+// Options KeyRing 설정
+type Options struct {
+	Algorithm        string
+	RotationInterval time.Duration     // 서명 키 교체 주기
+	VerifyWindow     time.Duration     // 교체된 키로 서명된 토큰이 모두 만료될 때까지의 시간
+	PublishLead      time.Duration     // 새 키를 서명에 쓰기 전 JWKS에 미리 공개하는 시간
+	Legacy           *utils.SigningKey // kid 없는 기존 토큰 검증용 (선택)
+}
+
+// KeyRing 서명 키 하나와 검증 전용 키들을 관리하고 주기적으로 교체
+//
+// 키 수명 주기:
+//   - 예약: ActivatesAt 이전. JWKS에 공개되지만 서명에는 쓰이지 않는다.
+//   - 활성: ActivatesAt이 현재 이전인 키 중 가장 최근 키. 새 토큰 서명에 사용된다.
+//   - 은퇴: 다음 키가 활성화된 뒤 VerifyWindow 동안 검증에만 사용된다.
+//   - 만료: 은퇴 후 VerifyWindow가 지나면 저장소에서 삭제된다.
+type KeyRing struct {
+	store Store
+	opts  Options
+	now   func() time.Time // 테스트에서 시각을 조정할 수 있도록
+
+	mu        sync.RWMutex
+	signing   *utils.SigningKey
+	keys      map[string]*utils.SigningKey
+	published []*utils.SigningKey
+}
+
+// NewKeyRing KeyRing 생성자 (저장소에서 키를 읽고 필요하면 첫 키를 만든다)
+func NewKeyRing(ctx context.Context, store Store, opts Options) (*KeyRing, error) {
+	return newKeyRing(ctx, store, opts, time.Now)
+}
+
+func newKeyRing(ctx context.Context, store Store, opts Options, now func() time.Time) (*KeyRing, error) {
+	if opts.RotationInterval <= 0 {
+		return nil, errors.New("key rotation interval must be positive")
+	}
+	if opts.PublishLead <= 0 || opts.PublishLead > opts.RotationInterval/2 {
+		opts.PublishLead = opts.RotationInterval / 2
+	}
+
+	r := &KeyRing{
+		store: store,
+		opts:  opts,
+		now:   now,
+	}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Run 주기적으로 키 상태를 점검 (ctx가 끝날 때까지 블록)
+func (r *KeyRing) Run(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				log.Printf("failed to refresh signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// Rotate 다음 키를 즉시 예약 (PublishLead 후 활성화)
+func (r *KeyRing) Rotate(ctx context.Context) error {
+	records, err := r.store.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := r.now()
+	if pending := pendingKey(records, now); pending == nil {
+		if _, err := r.createKey(ctx, now.Add(r.opts.PublishLead)); err != nil {
+			return err
+		}
+	}
+	return r.Refresh(ctx)
+}
+
+// Refresh 저장소와 동기화하고 키 생성/예약/삭제를 수행
+func (r *KeyRing) Refresh(ctx context.Context) error {
+	now := r.now()
+
+	records, err := r.store.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+	sortRecords(records)
+
+	// 활성 키가 없으면 바로 생성
+	active := activeKey(records, now)
+	if active == nil {
+		active, err = r.createKey(ctx, now)
+		if err != nil {
+			return err
+		}
+		records = append(records, active)
+		sortRecords(records)
+	}
+
+	// 교체 시점이 다가오거나 알고리즘이 바뀌었으면 다음 키 예약
+	if pendingKey(records, now) == nil {
+		rotateAt := active.ActivatesAt.Add(r.opts.RotationInterval)
+		if active.Algorithm != r.opts.Algorithm {
+			rotateAt = now
+		}
+		if !now.Before(rotateAt.Add(-r.opts.PublishLead)) {
+			// 늦어진 경우에도 최소 PublishLead 동안은 미리 공개
+			if earliest := now.Add(r.opts.PublishLead); rotateAt.Before(earliest) {
+				rotateAt = earliest
+			}
+			next, err := r.createKey(ctx, rotateAt)
+			if err != nil {
+				return err
+			}
+			records = append(records, next)
+			sortRecords(records)
+		}
+	}
+
+	// 은퇴 후 VerifyWindow가 지난 키 삭제
+	live := make([]*models.SigningKeyRecord, 0, len(records))
+	for i, record := range records {
+		if i+1 < len(records) {
+			successor := records[i+1]
+			if !successor.ActivatesAt.After(now) && successor.ActivatesAt.Add(r.opts.VerifyWindow).Before(now) {
+				if err := r.store.DeleteKey(ctx, record.ID); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		live = append(live, record)
+	}
+
+	return r.load(live, now)
+}
+
+// SigningKey 새 토큰 서명에 사용할 키
+func (r *KeyRing) SigningKey() *utils.SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.signing
+}
+
+// VerificationKey kid에 해당하는 검증 키
+func (r *KeyRing) VerificationKey(kid string) (*utils.SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+	return key, nil
+}
+
+// JWKS 예약/활성/은퇴 키의 공개키 목록
+func (r *KeyRing) JWKS() *utils.JWKS {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return utils.NewJWKS(r.published...)
+}
+
+// load 레코드를 디코딩해서 메모리 상태 교체
+func (r *KeyRing) load(records []*models.SigningKeyRecord, now time.Time) error {
+	var signing *utils.SigningKey
+	keys := make(map[string]*utils.SigningKey, len(records)+1)
+	published := make([]*utils.SigningKey, 0, len(records))
+
+	for _, record := range records {
+		key, err := decodeKey(record)
+		if err != nil {
+			return err
+		}
+		keys[key.ID] = key
+		published = append(published, key)
+		if !record.ActivatesAt.After(now) {
+			signing = key
+		}
+	}
+	if signing == nil {
+		return errors.New("no active signing key")
+	}
+	if r.opts.Legacy != nil {
+		if _, exists := keys[r.opts.Legacy.ID]; !exists {
+			keys[r.opts.Legacy.ID] = r.opts.Legacy
+		}
+	}
+
+	// 최신 키가 먼저 오도록
+	for i, j := 0, len(published)-1; i < j; i, j = i+1, j-1 {
+		published[i], published[j] = published[j], published[i]
+	}
+
+	r.mu.Lock()
+	r.signing = signing
+	r.keys = keys
+	r.published = published
+	r.mu.Unlock()
+	return nil
+}
+
+// createKey 새 키를 만들어 저장
+func (r *KeyRing) createKey(ctx context.Context, activatesAt time.Time) (*models.SigningKeyRecord, error) {
+	key, err := utils.GenerateSigningKey(r.opts.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if key.ID == "" {
+		key.ID = utils.GenerateRandomToken(16)
+	}
+
+	record, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	record.CreatedAt = r.now()
+	record.ActivatesAt = activatesAt
+
+	if err := r.store.SaveKey(ctx, record); err != nil {
+		return nil, err
+	}
+
+	log.Printf("created %s signing key %s (activates at %s)", key.Algorithm, key.ID, activatesAt.Format(time.RFC3339))
+	return record, nil
+}
+
+// sortRecords 활성화 순서대로 정렬 (동시에 만들어진 키는 kid 순)
+func sortRecords(records []*models.SigningKeyRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].ActivatesAt.Equal(records[j].ActivatesAt) {
+			return records[i].ID < records[j].ID
+		}
+		return records[i].ActivatesAt.Before(records[j].ActivatesAt)
+	})
+}
+
+// activeKey 정렬된 레코드 중 현재 서명 키
+func activeKey(records []*models.SigningKeyRecord, now time.Time) *models.SigningKeyRecord {
+	var active *models.SigningKeyRecord
+	for _, record := range records {
+		if !record.ActivatesAt.After(now) {
+			active = record
+		}
+	}
+	return active
+}
+
+// pendingKey 아직 활성화되지 않은 예약 키
+func pendingKey(records []*models.SigningKeyRecord, now time.Time) *models.SigningKeyRecord {
+	for _, record := range records {
+		if record.ActivatesAt.After(now) {
+			return record
+		}
+	}
+	return nil
+}
+
+// encodeKey SigningKey를 저장용 레코드로 변환
+func encodeKey(key *utils.SigningKey) (*models.SigningKeyRecord, error) {
+	record := &models.SigningKeyRecord{
+		ID:        key.ID,
+		Algorithm: key.Algorithm,
+	}
+
+	if key.IsSymmetric() {
+		secret, ok := key.Key.([]byte)
+		if !ok {
+			return nil, errors.New("invalid HMAC key")
+		}
+		record.PrivateKey = base64.StdEncoding.EncodeToString(secret)
+		return record, nil
+	}
+
+	data, err := utils.MarshalSigningKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	record.PrivateKey = string(data)
+	return record, nil
+}
+
+// decodeKey 저장된 레코드를 SigningKey로 변환
+func decodeKey(record *models.SigningKeyRecord) (*utils.SigningKey, error) {
+	if record.Algorithm == utils.AlgHS256 {
+		secret, err := base64.StdEncoding.DecodeString(record.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return utils.NewHMACSigningKey(record.ID, secret), nil
+	}
+
+	key, err := utils.ParseSigningKeyPEM(record.Algorithm, []byte(record.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	key.ID = record.ID
+	return key, nil
+}
@@ -0,0 +1,377 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// memoryStore 테스트용 메모리 Store
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]models.SigningKeyRecord
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]models.SigningKeyRecord)}
+}
+
+func (s *memoryStore) ListKeys(ctx context.Context) ([]*models.SigningKeyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([]*models.SigningKeyRecord, 0, len(s.records))
+	for _, record := range s.records {
+		record := record
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+func (s *memoryStore) SaveKey(ctx context.Context, key *models.SigningKeyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key.ID] = *key
+	return nil
+}
+
+func (s *memoryStore) DeleteKey(ctx context.Context, kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, kid)
+	return nil
+}
+
+// kids 저장된 키의 kid (정렬)
+func (s *memoryStore) kids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kids := make([]string, 0, len(s.records))
+	for kid := range s.records {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	return kids
+}
+
+// testClock 테스트가 직접 옮기는 시계
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// 하루마다 교체, 1시간 전에 공개, 교체 후 2시간 동안 검증
+var testOptions = Options{
+	Algorithm:        utils.AlgES256,
+	RotationInterval: 24 * time.Hour,
+	PublishLead:      time.Hour,
+	VerifyWindow:     2 * time.Hour,
+}
+
+func newTestRing(t *testing.T, store Store, clock *testClock, opts Options) *KeyRing {
+	t.Helper()
+
+	ring, err := newKeyRing(context.Background(), store, opts, clock.Now)
+	if err != nil {
+		t.Fatalf("new key ring: %v", err)
+	}
+	return ring
+}
+
+func refresh(t *testing.T, ring *KeyRing) {
+	t.Helper()
+
+	if err := ring.Refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+}
+
+// publishedKids JWKS에 공개된 kid (공개 순서)
+func publishedKids(ring *KeyRing) []string {
+	var kids []string
+	for _, jwk := range ring.JWKS().Keys {
+		kids = append(kids, jwk.Kid)
+	}
+	return kids
+}
+
+// signToken key로 서명한 토큰 (만료는 충분히 길게)
+func signToken(t *testing.T, key *utils.SigningKey) string {
+	t.Helper()
+
+	token, err := utils.GenerateJWT(&utils.JWTClaim{UserID: "user-1"}, key, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKeyRingRotation(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	store := newMemoryStore()
+	ring := newTestRing(t, store, clock, testOptions)
+
+	// 첫 키는 바로 활성화
+	first := ring.SigningKey()
+	if first == nil || first.Algorithm != utils.AlgES256 {
+		t.Fatalf("first signing key = %+v", first)
+	}
+	if kids := publishedKids(ring); !equalStrings(kids, []string{first.ID}) {
+		t.Fatalf("published = %v, want [%s]", kids, first.ID)
+	}
+	oldToken := signToken(t, first)
+
+	// 교체 1시간 전까지는 새 키를 만들지 않는다
+	clock.advance(23*time.Hour - time.Second)
+	refresh(t, ring)
+	if kids := store.kids(); len(kids) != 1 {
+		t.Fatalf("keys before the publish lead = %v, want only the first key", kids)
+	}
+
+	// 예약: 서명에 쓰기 전에 JWKS에 먼저 공개
+	clock.advance(time.Second)
+	refresh(t, ring)
+	kids := publishedKids(ring)
+	if len(kids) != 2 || kids[1] != first.ID {
+		t.Fatalf("published after scheduling = %v, want [next %s]", kids, first.ID)
+	}
+	next := kids[0]
+	if ring.SigningKey().ID != first.ID {
+		t.Fatalf("pending key %s is already signing", next)
+	}
+	if _, err := ring.VerificationKey(next); err != nil {
+		t.Errorf("pending key is not available for verification: %v", err)
+	}
+
+	// 활성화: 새 키로 서명하고 이전 키는 검증에만 사용
+	clock.advance(time.Hour)
+	refresh(t, ring)
+	if ring.SigningKey().ID != next {
+		t.Fatalf("signing key after activation = %s, want %s", ring.SigningKey().ID, next)
+	}
+	if _, err := utils.ValidateJWT(context.Background(), oldToken, ring); err != nil {
+		t.Errorf("token signed by the retired key: %v", err)
+	}
+	if kids := publishedKids(ring); !equalStrings(kids, []string{next, first.ID}) {
+		t.Errorf("published during overlap = %v, want [%s %s]", kids, next, first.ID)
+	}
+
+	// VerifyWindow가 끝날 때까지는 이전 키를 유지
+	clock.advance(2 * time.Hour)
+	refresh(t, ring)
+	if _, err := ring.VerificationKey(first.ID); err != nil {
+		t.Errorf("retired key removed before the verify window ended: %v", err)
+	}
+
+	// 만료: 저장소, VerificationKey, JWKS에서 모두 제거
+	clock.advance(time.Second)
+	refresh(t, ring)
+	if _, err := ring.VerificationKey(first.ID); err == nil {
+		t.Error("expired key is still used for verification")
+	}
+	if _, err := utils.ValidateJWT(context.Background(), oldToken, ring); err == nil {
+		t.Error("token signed by the expired key was accepted")
+	}
+	if kids := publishedKids(ring); !equalStrings(kids, []string{next}) {
+		t.Errorf("published after expiry = %v, want [%s]", kids, next)
+	}
+	if kids := store.kids(); !equalStrings(kids, []string{next}) {
+		t.Errorf("stored keys after expiry = %v, want [%s]", kids, next)
+	}
+}
+
+func TestKeyRingReloadsFromStore(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	store := newMemoryStore()
+	ring := newTestRing(t, store, clock, testOptions)
+
+	clock.advance(23 * time.Hour)
+	refresh(t, ring)
+
+	// 같은 저장소를 쓰는 다른 인스턴스는 키를 새로 만들지 않고 같은 상태를 읽는다
+	other := newTestRing(t, store, clock, testOptions)
+	if len(store.kids()) != 2 {
+		t.Fatalf("stored keys = %v, want 2", store.kids())
+	}
+	if other.SigningKey().ID != ring.SigningKey().ID {
+		t.Errorf("signing key = %s, want %s", other.SigningKey().ID, ring.SigningKey().ID)
+	}
+	if got, want := publishedKids(other), publishedKids(ring); !equalStrings(got, want) {
+		t.Errorf("published = %v, want %v", got, want)
+	}
+
+	// 한 인스턴스가 서명한 토큰을 다른 인스턴스가 검증
+	if _, err := utils.ValidateJWT(context.Background(), signToken(t, ring.SigningKey()), other); err != nil {
+		t.Errorf("token from another instance: %v", err)
+	}
+
+	// 다른 인스턴스가 교체한 키도 Refresh로 반영
+	clock.advance(time.Hour)
+	refresh(t, other)
+	refresh(t, ring)
+	if other.SigningKey().ID != ring.SigningKey().ID {
+		t.Errorf("signing keys diverged after rotation: %s, %s", other.SigningKey().ID, ring.SigningKey().ID)
+	}
+}
+
+func TestKeyRingLateRotationStillPublishesFirst(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	store := newMemoryStore()
+	ring := newTestRing(t, store, clock, testOptions)
+	first := ring.SigningKey().ID
+
+	// 서버가 교체 시점을 지나서 다시 시작된 경우
+	clock.advance(30 * time.Hour)
+	refresh(t, ring)
+	if ring.SigningKey().ID != first {
+		t.Fatal("a key was activated without being published first")
+	}
+	if len(publishedKids(ring)) != 2 {
+		t.Fatalf("published = %v, want the pending key and the current key", publishedKids(ring))
+	}
+
+	clock.advance(time.Hour)
+	refresh(t, ring)
+	if ring.SigningKey().ID == first {
+		t.Error("pending key was not activated after the publish lead")
+	}
+}
+
+func TestKeyRingRotate(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	store := newMemoryStore()
+	ring := newTestRing(t, store, clock, testOptions)
+	first := ring.SigningKey().ID
+	ctx := context.Background()
+
+	// 수동 교체도 PublishLead 뒤에 활성화되고, 예약 키는 하나만 만든다
+	for i := 0; i < 2; i++ {
+		if err := ring.Rotate(ctx); err != nil {
+			t.Fatalf("rotate: %v", err)
+		}
+	}
+	if kids := store.kids(); len(kids) != 2 {
+		t.Fatalf("stored keys = %v, want 2", kids)
+	}
+	if ring.SigningKey().ID != first {
+		t.Error("rotate activated the new key immediately")
+	}
+
+	clock.advance(time.Hour)
+	refresh(t, ring)
+	if ring.SigningKey().ID == first {
+		t.Error("rotated key was not activated")
+	}
+}
+
+func TestKeyRingAlgorithmChange(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	store := newMemoryStore()
+	newTestRing(t, store, clock, testOptions)
+
+	opts := testOptions
+	opts.Algorithm = utils.AlgEdDSA
+	ring := newTestRing(t, store, clock, opts)
+
+	// 알고리즘이 바뀌면 주기를 기다리지 않고 새 알고리즘 키를 예약
+	if got := ring.SigningKey().Algorithm; got != utils.AlgES256 {
+		t.Errorf("signing algorithm before activation = %s, want ES256", got)
+	}
+	clock.advance(time.Hour)
+	refresh(t, ring)
+	if got := ring.SigningKey().Algorithm; got != utils.AlgEdDSA {
+		t.Errorf("signing algorithm after activation = %s, want EdDSA", got)
+	}
+}
+
+func TestKeyRingLegacyKey(t *testing.T) {
+	legacy := utils.NewHMACSigningKey("", []byte("0123456789abcdef0123456789abcdef"))
+	opts := testOptions
+	opts.Legacy = legacy
+	ring := newTestRing(t, newMemoryStore(), &testClock{now: time.Now()}, opts)
+
+	// kid 없는 기존 토큰은 계속 검증하지만 비밀값은 공개하지 않는다
+	if _, err := utils.ValidateJWT(context.Background(), signToken(t, legacy), ring); err != nil {
+		t.Errorf("legacy token: %v", err)
+	}
+	for _, jwk := range ring.JWKS().Keys {
+		if jwk.Kid == "" || jwk.Alg == utils.AlgHS256 {
+			t.Errorf("legacy key was published: %+v", jwk)
+		}
+	}
+}
+
+func TestEncodeKeyRoundTrip(t *testing.T) {
+	for _, alg := range utils.SupportedAlgorithms {
+		t.Run(alg, func(t *testing.T) {
+			key, err := utils.GenerateSigningKey(alg)
+			if err != nil {
+				t.Fatalf("generate key: %v", err)
+			}
+			if key.ID == "" {
+				key.ID = utils.GenerateRandomToken(16)
+			}
+
+			record, err := encodeKey(key)
+			if err != nil {
+				t.Fatalf("encodeKey: %v", err)
+			}
+			decoded, err := decodeKey(record)
+			if err != nil {
+				t.Fatalf("decodeKey: %v", err)
+			}
+
+			if decoded.ID != key.ID || decoded.Algorithm != alg {
+				t.Errorf("decoded key = %s %s, want %s %s", decoded.Algorithm, decoded.ID, alg, key.ID)
+			}
+			if key.IsSymmetric() {
+				if !bytes.Equal(decoded.Key.([]byte), key.Key.([]byte)) {
+					t.Error("decoded HMAC secret differs")
+				}
+			} else if pub, ok := key.PublicKey().(interface{ Equal(crypto.PublicKey) bool }); !ok || !pub.Equal(decoded.PublicKey()) {
+				t.Error("decoded public key differs")
+			}
+
+			// 복원한 키로 서명한 토큰을 원래 키로 검증
+			if _, err := utils.ValidateJWT(context.Background(), signToken(t, decoded), utils.NewKeySet(key)); err != nil {
+				t.Errorf("token signed by the decoded key: %v", err)
+			}
+		})
+	}
+
+	if _, err := decodeKey(&models.SigningKeyRecord{ID: "broken", Algorithm: utils.AlgRS256, PrivateKey: "not a pem"}); err == nil {
+		t.Error("decodeKey accepted an invalid PEM")
+	}
+}
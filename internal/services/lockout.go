@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+var (
+	dummyPasswordHash     string
+	dummyPasswordHashOnce sync.Once
+)
+
+// checkDummyPassword 존재하지 않는 계정도 bcrypt 비교 시간만큼 걸리도록 더미 해시와 비교
+func checkDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = utils.HashPassword(utils.GenerateRandomToken(16))
+	})
+	utils.CheckPassword(password, dummyPasswordHash)
+}
+
+// lockoutDuration 연속 실패 횟수에 따른 잠금 시간 (임계값부터 실패마다 두 배, 최대값으로 제한)
+func (s *AuthService) lockoutDuration(failures int) time.Duration {
+	maxAttempts := s.config.LoginMaxAttempts
+	if maxAttempts <= 0 || failures < maxAttempts {
+		return 0
+	}
+
+	base := time.Duration(s.config.LoginLockoutBase) * time.Minute
+	limit := time.Duration(s.config.LoginLockoutMaxTTL) * time.Minute
+
+	if limit <= 0 {
+		limit = 24 * time.Hour
+	}
+
+	duration := base
+	for i := maxAttempts; i < failures && duration < limit; i++ {
+		duration *= 2
+	}
+	if duration > limit {
+		duration = limit
+	}
+	return duration
+}
+
+// recordFailedLogin 로그인 실패 기록, 임계값을 넘으면 잠그고 메일로 알림
+// 응답 시간으로 계정 존재 여부가 드러나지 않도록 요청과 별개로 실행된다.
+func (s *AuthService) recordFailedLogin(ctx context.Context, userID primitive.ObjectID) {
+	user, err := s.repo.RecordFailedLogin(ctx, userID)
+	if err != nil {
+		log.Printf("failed to record failed login for %s: %v", userID.Hex(), err)
+		return
+	}
+
+	duration := s.lockoutDuration(user.FailedLoginAttempts)
+	if duration == 0 {
+		return
+	}
+
+	until := time.Now().Add(duration)
+	if err := s.repo.LockUser(ctx, user.ID, until); err != nil {
+		log.Printf("failed to lock account %s: %v", user.ID.Hex(), err)
+		return
+	}
+
+	resetURL := fmt.Sprintf("%s/forgot-password", s.config.WebAppURL)
+	if err := s.emailService.SendAccountLockedEmail(ctx, user.Email, until, resetURL); err != nil {
+		log.Printf("failed to send account locked email for %s: %v", user.ID.Hex(), err)
+	}
+}
+
+// UnlockUser 관리자가 사용자의 로그인 잠금 해제
+func (s *AuthService) UnlockUser(ctx context.Context, claims *utils.JWTClaim, userID string) error {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionUsersWrite)
+	if err != nil {
+		return err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.ResetFailedLogins(ctx, user.ID); err != nil {
+		return err
+	}
+	s.recordAudit(ctx, admin, models.AuditActionUnlockUser, user, "", "")
+	return nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	s := &AuthService{config: &config.Config{
+		LoginMaxAttempts:   5,
+		LoginLockoutBase:   5,
+		LoginLockoutMaxTTL: 60,
+	}}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{4, 0},
+		{5, 5 * time.Minute},
+		{6, 10 * time.Minute},
+		{7, 20 * time.Minute},
+		{8, 40 * time.Minute},
+		{9, 60 * time.Minute}, // 최대값으로 제한
+		{50, 60 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := s.lockoutDuration(tt.failures); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLockoutDurationDefaults(t *testing.T) {
+	// LOGIN_MAX_ATTEMPTS가 0이면 잠그지 않는다
+	disabled := &AuthService{config: &config.Config{}}
+	if got := disabled.lockoutDuration(100); got != 0 {
+		t.Errorf("lockoutDuration with lockout disabled = %v, want 0", got)
+	}
+
+	// 최대 잠금 시간이 없으면 하루로 제한
+	uncapped := &AuthService{config: &config.Config{LoginMaxAttempts: 3, LoginLockoutBase: 60}}
+	if got := uncapped.lockoutDuration(100); got != 24*time.Hour {
+		t.Errorf("lockoutDuration without a maximum = %v, want 24h", got)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	ts := newTestService(t, func(cfg *config.Config) {
+		cfg.LoginMaxAttempts = 3
+		cfg.LoginLockoutBase = 15
+		cfg.LoginLockoutMaxTTL = 60
+	})
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	ctx := context.Background()
+
+	wrongLogin := func() {
+		t.Helper()
+		_, err := ts.LoginUser(ctx, &models.LoginRequest{Email: "member@example.com", Password: "Wrong123!"})
+		if err == nil || err.Error() != "invalid email or password" {
+			t.Fatalf("login with a wrong password = %v", err)
+		}
+	}
+
+	// 임계값 전에 성공하면 실패 횟수 초기화
+	for i := 0; i < 2; i++ {
+		wrongLogin()
+	}
+	waitFor(t, "failed logins to be recorded", func() bool {
+		return ts.storedUser(t, user.ID).FailedLoginAttempts == 2
+	})
+	ts.login(t, "member@example.com", "Password123!")
+	if failures := ts.storedUser(t, user.ID).FailedLoginAttempts; failures != 0 {
+		t.Fatalf("failed logins after success = %d, want 0", failures)
+	}
+
+	for i := 0; i < 3; i++ {
+		wrongLogin()
+	}
+	waitFor(t, "the account to be locked", func() bool {
+		return ts.storedUser(t, user.ID).IsLocked(time.Now())
+	})
+
+	locked := ts.storedUser(t, user.ID)
+	if until := time.Until(*locked.LockedUntil); until < 14*time.Minute || until > 15*time.Minute {
+		t.Errorf("locked for %v, want 15m", until)
+	}
+	waitFor(t, "the account locked email", func() bool {
+		for _, subject := range ts.mail.subjects() {
+			if subject == "Your Prisma Market account has been locked" {
+				return true
+			}
+		}
+		return false
+	})
+
+	// 잠긴 동안에는 맞는 비밀번호도 같은 응답으로 거부
+	_, err := ts.LoginUser(ctx, &models.LoginRequest{Email: "member@example.com", Password: "Password123!"})
+	if err == nil || err.Error() != "invalid email or password" {
+		t.Fatalf("login while locked = %v, want invalid email or password", err)
+	}
+
+	// 잠금이 풀리면 다시 로그인할 수 있다
+	if err := ts.stores.Users.ResetFailedLogins(ctx, user.ID); err != nil {
+		t.Fatalf("reset failed logins: %v", err)
+	}
+	ts.login(t, "member@example.com", "Password123!")
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// RequestMagicLink 한 번만 쓸 수 있는 로그인 링크를 메일로 발송
+func (s *AuthService) RequestMagicLink(ctx context.Context, req *models.MagicLinkRequest) error {
+	user, err := s.repo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil // 보안을 위해 사용자가 없어도 성공으로 처리
+	}
+	if skipInactiveAccount(user, "magic link") {
+		return nil
+	}
+
+	token := utils.GenerateRandomToken(32)
+	if token == "" {
+		return errors.New("failed to generate login link")
+	}
+	ttl := s.magicLinkTTL()
+
+	// 토큰은 해시만 저장하고 원문은 메일로만 전달 (새로 요청하면 이전 링크는 무효)
+	if err := s.repo.UpdateMagicLinkToken(ctx, user.ID, utils.HashToken(token), time.Now().Add(ttl)); err != nil {
+		return err
+	}
+
+	loginURL := fmt.Sprintf("%s/magic-link", s.config.WebAppURL)
+	return s.emailService.SendMagicLinkEmail(ctx, user.Email, token, loginURL, ttl)
+}
+
+// ConsumeMagicLink 로그인 링크를 LoginUser와 같은 응답으로 교환
+// 링크는 메일로만 전달되므로 아직 인증되지 않은 이메일도 인증 처리된다.
+func (s *AuthService) ConsumeMagicLink(ctx context.Context, req *models.ConsumeMagicLinkRequest) (*models.LoginResponse, error) {
+	if err := utils.ValidateVerificationToken(req.Token); err != nil {
+		return nil, errors.New("invalid or expired login link")
+	}
+
+	user, err := s.repo.ConsumeMagicLinkToken(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	if user.IsLocked(time.Now()) {
+		return nil, errors.New("invalid or expired login link")
+	}
+
+	// 비밀번호 로그인과 마찬가지로 2단계 인증 수단이 있으면 챌린지 반환
+	methods, err := s.secondFactorMethods(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) > 0 {
+		return s.mfaChallenge(ctx, user, methods)
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+// magicLinkTTL 로그인 링크 수명 (설정이 없으면 15분)
+func (s *AuthService) magicLinkTTL() time.Duration {
+	if s.config.MagicLinkTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(s.config.MagicLinkTTL) * time.Minute
+}
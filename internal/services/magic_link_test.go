@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// requestMagicLink 로그인 링크를 요청하고 메일로 받은 토큰 반환
+func (ts *testService) requestMagicLink(t *testing.T, emailAddr string) string {
+	t.Helper()
+
+	sent := len(ts.mail.subjects())
+	if err := ts.RequestMagicLink(context.Background(), &models.MagicLinkRequest{Email: emailAddr}); err != nil {
+		t.Fatalf("request magic link: %v", err)
+	}
+
+	ts.mail.mu.Lock()
+	defer ts.mail.mu.Unlock()
+	if len(ts.mail.messages) != sent+1 {
+		t.Fatalf("sent %d emails, want 1", len(ts.mail.messages)-sent)
+	}
+	for _, line := range strings.Split(ts.mail.messages[sent].Body, "\n") {
+		if !strings.HasPrefix(line, "http") {
+			continue
+		}
+		u, err := url.Parse(strings.TrimSpace(line))
+		if err != nil {
+			t.Fatalf("parse login link: %v", err)
+		}
+		if u.Path != "/magic-link" {
+			t.Errorf("login link path = %q, want /magic-link", u.Path)
+		}
+		return u.Query().Get("token")
+	}
+	t.Fatal("no login link in the email body")
+	return ""
+}
+
+func TestMagicLinkIsSingleUse(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "link@example.com", "Password123!", false)
+	token := ts.requestMagicLink(t, user.Email)
+
+	resp, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: token})
+	if err != nil {
+		t.Fatalf("consume magic link: %v", err)
+	}
+	if resp.Token == "" || resp.RefreshToken == "" {
+		t.Fatalf("login response = %+v, want tokens", resp)
+	}
+	if !ts.storedUser(t, user.ID).EmailVerified {
+		t.Error("email was not marked verified after signing in with a link")
+	}
+
+	if _, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: token}); err == nil {
+		t.Error("magic link was used twice")
+	}
+}
+
+func TestMagicLinkReplacesPreviousLink(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "link@example.com", "Password123!", true)
+	first := ts.requestMagicLink(t, user.Email)
+	second := ts.requestMagicLink(t, user.Email)
+
+	if _, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: first}); err == nil {
+		t.Error("previous link was accepted after a new one was requested")
+	}
+	if _, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: second}); err != nil {
+		t.Errorf("consume latest link: %v", err)
+	}
+}
+
+func TestMagicLinkRejectsExpiredLink(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "link@example.com", "Password123!", true)
+
+	token := utils.GenerateRandomToken(32)
+	err := ts.stores.Users.UpdateMagicLinkToken(context.Background(), user.ID, utils.HashToken(token), time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("store expired link: %v", err)
+	}
+
+	if resp, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: token}); err == nil {
+		t.Fatalf("expired link = %+v, want error", resp)
+	}
+}
+
+func TestMagicLinkRejectsMalformedToken(t *testing.T) {
+	ts := newTestService(t)
+
+	for _, token := range []string{"", "short", strings.Repeat("!", 43)} {
+		if _, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: token}); err == nil {
+			t.Errorf("token %q was accepted", token)
+		}
+	}
+}
+
+func TestMagicLinkUnknownEmailLooksLikeSuccess(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "link@example.com", "Password123!", true)
+
+	// 가입 여부를 알 수 없도록 없는 이메일도 성공으로 응답하고 메일은 보내지 않는다
+	if err := ts.RequestMagicLink(context.Background(), &models.MagicLinkRequest{Email: "nobody@example.com"}); err != nil {
+		t.Fatalf("request for unknown email: %v", err)
+	}
+	if subjects := ts.mail.subjects(); len(subjects) != 0 {
+		t.Errorf("sent %v for an unknown email", subjects)
+	}
+}
+
+func TestMagicLinkInactiveAccount(t *testing.T) {
+	for _, status := range []models.UserStatus{models.UserStatusSuspended, models.UserStatusBanned, models.UserStatusDeleted} {
+		t.Run(string(status), func(t *testing.T) {
+			ts := newTestService(t)
+			user := ts.createUser(t, "link@example.com", "Password123!", true)
+			token := ts.requestMagicLink(t, user.Email)
+
+			if _, err := ts.stores.Users.UpdateStatus(context.Background(), user.ID, models.UserStatusActive, status, "test"); err != nil {
+				t.Fatalf("update status: %v", err)
+			}
+
+			// 상태가 바뀐 뒤에는 새 링크를 보내지 않고, 이미 받은 링크로도 로그인할 수 없다
+			if err := ts.RequestMagicLink(context.Background(), &models.MagicLinkRequest{Email: user.Email}); err != nil {
+				t.Fatalf("request for %s account: %v", status, err)
+			}
+			if subjects := ts.mail.subjects(); len(subjects) != 1 {
+				t.Errorf("sent %v, want only the link sent before the status change", subjects)
+			}
+
+			if resp, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: token}); err == nil {
+				t.Errorf("consume for %s account = %+v, want error", status, resp)
+			}
+		})
+	}
+}
+
+func TestMagicLinkLockedAccount(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "link@example.com", "Password123!", true)
+	token := ts.requestMagicLink(t, user.Email)
+
+	if err := ts.stores.Users.LockUser(context.Background(), user.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("lock user: %v", err)
+	}
+
+	resp, err := ts.ConsumeMagicLink(context.Background(), &models.ConsumeMagicLinkRequest{Token: token})
+	if err == nil || err.Error() != "invalid or expired login link" {
+		t.Fatalf("consume for locked account = %+v, %v; want invalid or expired login link", resp, err)
+	}
+}
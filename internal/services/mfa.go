@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+const (
+	// 비밀번호 확인 후 2단계 인증을 마쳐야 하는 시간
+	mfaChallengeTTL = 5 * time.Minute
+	// MFA 토큰 하나로 코드를 확인할 수 있는 횟수 (넘기면 처음부터 다시 로그인)
+	mfaMaxAttempts = 5
+	// 발급한 MFA 토큰의 시도 횟수 (ID는 토큰의 jti)
+	flowMFAChallenge = "mfa_challenge"
+	// 발급하는 복구 코드 수
+	recoveryCodeCount = 10
+	// QR 코드 이미지 크기 (px)
+	qrCodeSize = 256
+)
+
+// EnrollTOTP TOTP 등록 시작: 비밀값 생성 후 otpauth URI와 QR 코드 반환
+// VerifyTOTP로 첫 코드를 확인해야 활성화된다.
+func (s *AuthService) EnrollTOTP(ctx context.Context, claims *utils.JWTClaim) (*models.TOTPEnrollResponse, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetTOTPSecret(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	uri := utils.TOTPURI(s.config.MFAIssuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TOTPEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: uri,
+		QRCodePNG:  base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// VerifyTOTP 첫 코드 확인 후 TOTP 활성화, 복구 코드는 이때 한 번만 평문으로 반환
+func (s *AuthService) VerifyTOTP(ctx context.Context, claims *utils.JWTClaim, req *models.TOTPVerifyRequest) (*models.TOTPVerifyResponse, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("two-factor authentication enrollment not started")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, req.Code, time.Now())
+	if !ok {
+		return nil, errors.New("invalid verification code")
+	}
+
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		if hashes[i], err = utils.HashPassword(code); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.EnableTOTP(ctx, user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return &models.TOTPVerifyResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP 비밀번호와 TOTP(또는 복구) 코드를 확인한 뒤 2단계 인증 해제
+func (s *AuthService) DisableTOTP(ctx context.Context, claims *utils.JWTClaim, req *models.TOTPDisableRequest) error {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+		return errors.New("password is incorrect")
+	}
+	if err := s.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode); err != nil {
+		return err
+	}
+
+	return s.repo.DisableTOTP(ctx, user.ID)
+}
+
+// CompleteMFAChallenge 로그인 2단계: MFA 토큰과 코드를 확인하고 토큰 발급
+// 틀린 코드는 MFA 토큰의 시도 횟수와 계정 잠금 실패 횟수에 모두 포함된다.
+func (s *AuthService) CompleteMFAChallenge(ctx context.Context, req *models.MFAChallengeRequest) (*models.LoginResponse, error) {
+	user, challengeID, err := s.userFromMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is not enabled")
+	}
+
+	flow, err := s.flowRepo.UseFlowAttempt(ctx, challengeID, flowMFAChallenge, mfaMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil || flow.UserID != user.ID {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+
+	if err := s.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode); err != nil {
+		go s.recordFailedLogin(context.WithoutCancel(ctx), user.ID)
+		return nil, err
+	}
+
+	if err := s.consumeMFAChallenge(ctx, challengeID); err != nil {
+		return nil, err
+	}
+	return s.finishLogin(ctx, user)
+}
+
+// userFromMFAToken MFA 토큰 검증 후 사용자와 챌린지 ID(jti) 반환
+func (s *AuthService) userFromMFAToken(ctx context.Context, mfaToken string) (*models.User, string, error) {
+	claims, err := utils.ValidateJWT(ctx, mfaToken, s.keys)
+	if err != nil || claims.TokenUse != utils.TokenUseMFA {
+		return nil, "", errors.New("invalid or expired mfa token")
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, "", errors.New("invalid or expired mfa token")
+	}
+	// 챌린지 발급 후 비밀번호가 바뀌었거나 2단계 실패로 계정이 잠긴 경우
+	if claims.TokenVersion != user.TokenVersion || user.IsLocked(time.Now()) {
+		return nil, "", errors.New("invalid or expired mfa token")
+	}
+	return user, claims.ID, nil
+}
+
+// consumeMFAChallenge 2단계 인증을 마친 MFA 토큰은 다시 쓸 수 없도록 삭제
+func (s *AuthService) consumeMFAChallenge(ctx context.Context, challengeID string) error {
+	flow, err := s.flowRepo.ConsumeFlow(ctx, challengeID, flowMFAChallenge)
+	if err != nil {
+		return err
+	}
+	if flow == nil {
+		return errors.New("invalid or expired mfa token")
+	}
+	return nil
+}
+
+// secondFactorMethods 사용자가 등록한 2단계 인증 수단 (없으면 빈 목록)
+func (s *AuthService) secondFactorMethods(ctx context.Context, user *models.User) ([]string, error) {
+	var methods []string
+	if user.TOTPEnabled {
+		methods = append(methods, "totp")
+	}
+
+	passkeys, err := s.credentialRepo.CountCredentialsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if passkeys > 0 {
+		methods = append(methods, "webauthn")
+	}
+	return methods, nil
+}
+
+// mfaChallenge 2단계 인증 대기 응답 생성
+func (s *AuthService) mfaChallenge(ctx context.Context, user *models.User, methods []string) (*models.LoginResponse, error) {
+	if err := accountStatusError(user); err != nil {
+		return nil, err
+	}
+
+	claims := &utils.JWTClaim{
+		UserID:       user.ID.Hex(),
+		Email:        user.Email,
+		TokenVersion: user.TokenVersion,
+		TokenUse:     utils.TokenUseMFA,
+	}
+	token, err := utils.GenerateJWT(claims, s.keys.SigningKey(), mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	// 토큰마다 코드 확인 시도 횟수를 기록
+	if err := s.flowRepo.SaveFlow(ctx, &models.AuthFlow{
+		ID:        claims.ID,
+		Kind:      flowMFAChallenge,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &models.LoginResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		MFAMethods:  methods,
+		ExpiresIn:   int(mfaChallengeTTL.Seconds()),
+	}, nil
+}
+
+// verifySecondFactor TOTP 코드 또는 복구 코드 확인 (둘 다 일회용)
+func (s *AuthService) verifySecondFactor(ctx context.Context, user *models.User, code, recoveryCode string) error {
+	if code != "" {
+		step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+		if !ok {
+			return errors.New("invalid verification code")
+		}
+		used, err := s.repo.UseTOTPStep(ctx, user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errors.New("verification code has already been used")
+		}
+		return nil
+	}
+
+	if recoveryCode != "" {
+		normalized := utils.NormalizeRecoveryCode(recoveryCode)
+		for _, hash := range user.RecoveryCodes {
+			if utils.CheckPassword(normalized, hash) != nil {
+				continue
+			}
+			used, err := s.repo.UseRecoveryCode(ctx, user.ID, hash)
+			if err != nil {
+				return err
+			}
+			if !used {
+				return errors.New("invalid recovery code")
+			}
+			return nil
+		}
+		return errors.New("invalid recovery code")
+	}
+
+	return errors.New("verification code is required")
+}
+
+// userFromClaims 토큰의 사용자 조회
+func (s *AuthService) userFromClaims(ctx context.Context, claims *utils.JWTClaim) (*models.User, error) {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+
+	user, err := s.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// enableTOTP 로그인한 사용자의 TOTP 등록 후 비밀값과 복구 코드 반환
+// 등록에 현재 시간 단계의 코드를 쓰므로 로그인에는 totpCode(t, secret, 1)을 사용한다.
+func (ts *testService) enableTOTP(t *testing.T, emailAddr, password string) (string, []string) {
+	t.Helper()
+
+	claims := ts.claims(t, ts.login(t, emailAddr, password))
+	enroll, err := ts.EnrollTOTP(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("enroll totp: %v", err)
+	}
+	verified, err := ts.VerifyTOTP(context.Background(), claims, &models.TOTPVerifyRequest{Code: totpCode(t, enroll.Secret, 0)})
+	if err != nil {
+		t.Fatalf("verify totp: %v", err)
+	}
+	return enroll.Secret, verified.RecoveryCodes
+}
+
+// totpCode 현재 시간 단계에서 offset만큼 떨어진 단계의 코드
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("totp code: %v", err)
+	}
+	return code
+}
+
+// wrongTOTPCode 허용 범위의 어느 단계와도 맞지 않는 코드
+func wrongTOTPCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	for n := 0; ; n++ {
+		code := fmt.Sprintf("%06d", n)
+		if _, ok := utils.ValidateTOTP(secret, code, time.Now()); !ok {
+			return code
+		}
+	}
+}
+
+// mfaToken 비밀번호 로그인 후 받은 MFA 토큰
+func (ts *testService) mfaToken(t *testing.T, emailAddr, password string) string {
+	t.Helper()
+
+	resp, err := ts.LoginUser(context.Background(), &models.LoginRequest{Email: emailAddr, Password: password})
+	if err != nil {
+		t.Fatalf("login %s: %v", emailAddr, err)
+	}
+	if !resp.MFARequired || resp.MFAToken == "" {
+		t.Fatalf("login %s did not require a second factor", emailAddr)
+	}
+	return resp.MFAToken
+}
+
+func TestMFAChallengeAttemptLimit(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	secret, _ := ts.enableTOTP(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	token := ts.mfaToken(t, "member@example.com", "Password123!")
+	for i := 0; i < mfaMaxAttempts; i++ {
+		_, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, Code: wrongTOTPCode(t, secret)})
+		if err == nil || err.Error() != "invalid verification code" {
+			t.Fatalf("attempt %d = %v, want invalid verification code", i+1, err)
+		}
+	}
+
+	// 시도 횟수를 다 쓴 토큰은 맞는 코드로도 완료할 수 없다
+	_, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, Code: totpCode(t, secret, 1)})
+	if err == nil || err.Error() != "invalid or expired mfa token" {
+		t.Fatalf("challenge after %d failures = %v, want invalid or expired mfa token", mfaMaxAttempts, err)
+	}
+
+	// 다시 로그인하면 새 토큰으로 완료할 수 있다
+	token = ts.mfaToken(t, "member@example.com", "Password123!")
+	resp, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, Code: totpCode(t, secret, 1)})
+	if err != nil || resp.Token == "" {
+		t.Fatalf("challenge with a new token = %+v, %v", resp, err)
+	}
+}
+
+func TestMFATokenIsSingleUse(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	_, recoveryCodes := ts.enableTOTP(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	token := ts.mfaToken(t, "member@example.com", "Password123!")
+	if _, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, RecoveryCode: recoveryCodes[0]}); err != nil {
+		t.Fatalf("challenge: %v", err)
+	}
+
+	_, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, RecoveryCode: recoveryCodes[1]})
+	if err == nil || err.Error() != "invalid or expired mfa token" {
+		t.Fatalf("reused mfa token = %v, want invalid or expired mfa token", err)
+	}
+}
+
+func TestMFAChallengeFailuresLockAccount(t *testing.T) {
+	ts := newTestService(t, func(cfg *config.Config) {
+		cfg.LoginMaxAttempts = 3
+		cfg.LoginLockoutBase = 15
+	})
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	secret, _ := ts.enableTOTP(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	token := ts.mfaToken(t, "member@example.com", "Password123!")
+	for i := 0; i < 3; i++ {
+		if _, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, Code: wrongTOTPCode(t, secret)}); err == nil {
+			t.Fatalf("attempt %d with a wrong code succeeded", i+1)
+		}
+	}
+
+	waitFor(t, "the account to be locked", func() bool {
+		return ts.storedUser(t, user.ID).IsLocked(time.Now())
+	})
+
+	_, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{MFAToken: token, Code: totpCode(t, secret, 1)})
+	if err == nil || err.Error() != "invalid or expired mfa token" {
+		t.Fatalf("challenge on a locked account = %v, want invalid or expired mfa token", err)
+	}
+}
+
+// completeMFA TOTP 코드로 2단계 인증을 마친 로그인 응답
+func (ts *testService) completeMFA(t *testing.T, emailAddr, password, code string) *models.LoginResponse {
+	t.Helper()
+
+	resp, err := ts.CompleteMFAChallenge(context.Background(), &models.MFAChallengeRequest{
+		MFAToken: ts.mfaToken(t, emailAddr, password),
+		Code:     code,
+	})
+	if err != nil {
+		t.Fatalf("complete mfa challenge: %v", err)
+	}
+	return resp
+}
+
+func TestTOTPEnrollment(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	claims := ts.claims(t, ts.login(t, "member@example.com", "Password123!"))
+	ctx := context.Background()
+
+	enroll, err := ts.EnrollTOTP(ctx, claims)
+	if err != nil {
+		t.Fatalf("enroll totp: %v", err)
+	}
+	if enroll.Secret == "" || enroll.QRCodePNG == "" {
+		t.Fatalf("enroll response = %+v", enroll)
+	}
+
+	// 첫 코드를 확인하기 전에는 활성화되지 않는다
+	if _, err := ts.VerifyTOTP(ctx, claims, &models.TOTPVerifyRequest{Code: wrongTOTPCode(t, enroll.Secret)}); err == nil {
+		t.Fatal("enrollment verified with a wrong code")
+	}
+	if stored, _ := ts.stores.Users.FindUserByID(ctx, user.ID); stored.TOTPEnabled {
+		t.Fatal("totp enabled before the first code was verified")
+	}
+
+	verified, err := ts.VerifyTOTP(ctx, claims, &models.TOTPVerifyRequest{Code: totpCode(t, enroll.Secret, 0)})
+	if err != nil {
+		t.Fatalf("verify totp: %v", err)
+	}
+	if len(verified.RecoveryCodes) != recoveryCodeCount {
+		t.Errorf("recovery codes = %d, want %d", len(verified.RecoveryCodes), recoveryCodeCount)
+	}
+
+	// 복구 코드는 해시로만 저장된다
+	stored, err := ts.stores.Users.FindUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if !stored.TOTPEnabled || len(stored.RecoveryCodes) != recoveryCodeCount {
+		t.Fatalf("stored totp state = enabled %v, %d recovery codes", stored.TOTPEnabled, len(stored.RecoveryCodes))
+	}
+	for i, hash := range stored.RecoveryCodes {
+		if hash == verified.RecoveryCodes[i] || utils.CheckPassword(verified.RecoveryCodes[i], hash) != nil {
+			t.Errorf("recovery code %d is not stored as its hash", i)
+		}
+	}
+
+	if _, err := ts.EnrollTOTP(ctx, claims); err == nil {
+		t.Error("enrollment restarted while totp is enabled")
+	}
+}
+
+func TestTOTPCodeIsSingleUse(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	secret, _ := ts.enableTOTP(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	code := totpCode(t, secret, 1)
+	ts.completeMFA(t, "member@example.com", "Password123!", code)
+
+	// 같은 코드와 이미 지난 시간 단계의 코드는 다시 쓸 수 없다
+	for name, reused := range map[string]string{"same step": code, "earlier step": totpCode(t, secret, 0)} {
+		_, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{
+			MFAToken: ts.mfaToken(t, "member@example.com", "Password123!"),
+			Code:     reused,
+		})
+		if err == nil || err.Error() != "verification code has already been used" {
+			t.Errorf("%s code = %v, want already used", name, err)
+		}
+	}
+}
+
+func TestRecoveryCodeIsSingleUse(t *testing.T) {
+	ts := newTestService(t)
+	user := ts.createUser(t, "member@example.com", "Password123!", true)
+	_, recoveryCodes := ts.enableTOTP(t, "member@example.com", "Password123!")
+	ctx := context.Background()
+
+	// 대소문자와 하이픈 없이 입력해도 같은 코드
+	typed := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	resp, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{
+		MFAToken:     ts.mfaToken(t, "member@example.com", "Password123!"),
+		RecoveryCode: typed,
+	})
+	if err != nil || resp.Token == "" {
+		t.Fatalf("challenge with a recovery code = %+v, %v", resp, err)
+	}
+
+	_, err = ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{
+		MFAToken:     ts.mfaToken(t, "member@example.com", "Password123!"),
+		RecoveryCode: recoveryCodes[0],
+	})
+	if err == nil || err.Error() != "invalid recovery code" {
+		t.Fatalf("reused recovery code = %v, want invalid recovery code", err)
+	}
+
+	stored, err := ts.stores.Users.FindUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if len(stored.RecoveryCodes) != recoveryCodeCount-1 {
+		t.Errorf("remaining recovery codes = %d, want %d", len(stored.RecoveryCodes), recoveryCodeCount-1)
+	}
+
+	// 다른 복구 코드는 그대로 쓸 수 있다
+	if _, err := ts.CompleteMFAChallenge(ctx, &models.MFAChallengeRequest{
+		MFAToken:     ts.mfaToken(t, "member@example.com", "Password123!"),
+		RecoveryCode: recoveryCodes[1],
+	}); err != nil {
+		t.Errorf("another recovery code: %v", err)
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	secret, recoveryCodes := ts.enableTOTP(t, "member@example.com", "Password123!")
+	claims := ts.claims(t, ts.completeMFA(t, "member@example.com", "Password123!", totpCode(t, secret, 1)))
+	ctx := context.Background()
+
+	if err := ts.DisableTOTP(ctx, claims, &models.TOTPDisableRequest{Password: "Wrong123!", RecoveryCode: recoveryCodes[0]}); err == nil {
+		t.Fatal("totp disabled with a wrong password")
+	}
+	if err := ts.DisableTOTP(ctx, claims, &models.TOTPDisableRequest{Password: "Password123!", Code: wrongTOTPCode(t, secret)}); err == nil {
+		t.Fatal("totp disabled with a wrong code")
+	}
+	if err := ts.DisableTOTP(ctx, claims, &models.TOTPDisableRequest{Password: "Password123!", RecoveryCode: recoveryCodes[0]}); err != nil {
+		t.Fatalf("disable totp: %v", err)
+	}
+
+	// 해제한 뒤에는 비밀번호만으로 로그인
+	ts.login(t, "member@example.com", "Password123!")
+}
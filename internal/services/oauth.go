@@ -0,0 +1,555 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+const (
+	flowOAuthAuthorizationCode = "oauth_authorization_code"
+
+	// 인가 코드 수명 (클라이언트가 리다이렉트 직후 바로 교환)
+	oauthCodeTTL = time.Minute
+)
+
+// OAuthError RFC 6749 형식의 오류 (error, error_description)
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Description
+}
+
+func oauthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// oauthAuthorizationCode 인가 코드에 묶인 요청 정보 (AuthFlow.Data)
+type oauthAuthorizationCode struct {
+	ClientID      string `json:"client_id"`
+	RedirectURI   string `json:"redirect_uri"`
+	Scope         string `json:"scope"`
+	CodeChallenge string `json:"code_challenge"`
+	Nonce         string `json:"nonce,omitempty"`
+}
+
+// CreateOAuthClient OAuth 클라이언트 등록 (시크릿은 응답으로 한 번만 반환)
+func (s *AuthService) CreateOAuthClient(ctx context.Context, claims *utils.JWTClaim, req *models.CreateOAuthClientRequest) (*models.CreateOAuthClientResponse, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("client name is required")
+	}
+	if len(req.RedirectURIs) == 0 {
+		return nil, errors.New("at least one redirect URI is required")
+	}
+	for _, uri := range req.RedirectURIs {
+		if err := validateRedirectURI(uri); err != nil {
+			return nil, err
+		}
+	}
+
+	supported := s.supportedScopes()
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = supported
+	}
+	for _, scope := range scopes {
+		if !containsString(supported, scope) {
+			return nil, fmt.Errorf("unsupported scope: %s", scope)
+		}
+	}
+
+	client := &models.OAuthClient{
+		ClientID:     utils.GenerateRandomToken(16),
+		Name:         name,
+		RedirectURIs: append([]string(nil), req.RedirectURIs...),
+		Scopes:       append([]string(nil), scopes...),
+		Public:       req.Public,
+		OwnerID:      user.ID,
+	}
+	if client.ClientID == "" {
+		return nil, errors.New("failed to generate client id")
+	}
+
+	var secret string
+	if !client.Public {
+		secret = utils.GenerateRandomToken(32)
+		if secret == "" {
+			return nil, errors.New("failed to generate client secret")
+		}
+		client.SecretHash = utils.HashToken(secret)
+	}
+
+	if err := s.clientRepo.CreateClient(ctx, client); err != nil {
+		return nil, err
+	}
+	return &models.CreateOAuthClientResponse{OAuthClient: client, ClientSecret: secret}, nil
+}
+
+// ListOAuthClients 사용자가 등록한 OAuth 클라이언트 목록
+func (s *AuthService) ListOAuthClients(ctx context.Context, claims *utils.JWTClaim) ([]*models.OAuthClient, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	return s.clientRepo.ListClientsByOwner(ctx, user.ID)
+}
+
+// DeleteOAuthClient OAuth 클라이언트 삭제 (발급된 리프레시 토큰도 폐기)
+func (s *AuthService) DeleteOAuthClient(ctx context.Context, claims *utils.JWTClaim, id string) error {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("client not found")
+	}
+
+	clients, err := s.clientRepo.ListClientsByOwner(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, client := range clients {
+		if client.ID == clientID {
+			if err := s.clientRepo.DeleteClient(ctx, user.ID, client.ID); err != nil {
+				return err
+			}
+			return s.refreshRepo.RevokeClientRefreshTokens(ctx, client.ClientID)
+		}
+	}
+	return errors.New("client not found")
+}
+
+// BeginAuthorization /oauth/authorize 요청을 확인하고 이동할 URL 반환
+// 정상 요청이면 웹 앱의 로그인/동의 화면으로, 클라이언트에 알릴 수 있는 오류면 redirect_uri로 보낸다.
+// client_id나 redirect_uri가 잘못된 경우는 돌려보낼 곳을 믿을 수 없으므로 에러를 반환한다.
+func (s *AuthService) BeginAuthorization(ctx context.Context, req *models.AuthorizeRequest) (string, error) {
+	client, scope, err := s.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		var oauthErr *OAuthError
+		if client != nil && errors.As(err, &oauthErr) {
+			return authorizeErrorRedirect(req, oauthErr), nil
+		}
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {req.ResponseType},
+		"client_id":             {client.ClientID},
+		"client_name":           {client.Name},
+		"redirect_uri":          {req.RedirectURI},
+		"scope":                 {scope},
+		"code_challenge":        {req.CodeChallenge},
+		"code_challenge_method": {req.CodeChallengeMethod},
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	if req.Nonce != "" {
+		query.Set("nonce", req.Nonce)
+	}
+	return fmt.Sprintf("%s/oauth/authorize?%s", s.config.WebAppURL, query.Encode()), nil
+}
+
+// Authorize 로그인한 사용자의 동의 결과로 인가 코드 발급
+// 웹 앱은 응답의 RedirectTo(클라이언트의 redirect_uri)로 이동한다.
+func (s *AuthService) Authorize(ctx context.Context, claims *utils.JWTClaim, req *models.AuthorizeRequest) (*models.AuthorizeResponse, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	client, scope, err := s.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		var oauthErr *OAuthError
+		if client != nil && errors.As(err, &oauthErr) {
+			return &models.AuthorizeResponse{RedirectTo: authorizeErrorRedirect(req, oauthErr)}, nil
+		}
+		return nil, err
+	}
+	if req.Deny {
+		return &models.AuthorizeResponse{
+			RedirectTo: authorizeErrorRedirect(req, oauthError("access_denied", "the user denied the request")),
+		}, nil
+	}
+
+	code := utils.GenerateRandomToken(32)
+	if code == "" {
+		return nil, errors.New("failed to generate authorization code")
+	}
+	data, err := json.Marshal(&oauthAuthorizationCode{
+		ClientID:      client.ClientID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         scope,
+		CodeChallenge: req.CodeChallenge,
+		Nonce:         req.Nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 코드는 해시만 저장하고 한 번 교환하면 삭제
+	if err := s.flowRepo.SaveFlow(ctx, &models.AuthFlow{
+		ID:        utils.HashToken(code),
+		Kind:      flowOAuthAuthorizationCode,
+		UserID:    user.ID,
+		Data:      data,
+		ExpiresAt: time.Now().Add(oauthCodeTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"code": {code}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return &models.AuthorizeResponse{RedirectTo: appendQuery(req.RedirectURI, params)}, nil
+}
+
+// OAuthToken /oauth/token 처리 (authorization_code, refresh_token, client_credentials)
+func (s *AuthService) OAuthToken(ctx context.Context, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	// 서비스 클라이언트는 별도 저장소에서 인증
+	if req.GrantType == "client_credentials" {
+		return s.clientCredentialsToken(ctx, req)
+	}
+
+	client, err := s.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case "authorization_code":
+		return s.exchangeAuthorizationCode(ctx, client, req)
+	case "refresh_token":
+		return s.refreshOAuthToken(ctx, client, req)
+	case "":
+		return nil, oauthError("invalid_request", "grant_type is required")
+	default:
+		return nil, oauthError("unsupported_grant_type", "unsupported grant_type")
+	}
+}
+
+// RevokeOAuthToken 클라이언트의 토큰 폐기 (RFC 7009, 모르는 토큰이어도 성공)
+func (s *AuthService) RevokeOAuthToken(ctx context.Context, req *models.OAuthRevokeRequest) error {
+	client, err := s.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return err
+	}
+	if req.Token == "" {
+		return oauthError("invalid_request", "token is required")
+	}
+
+	// 리프레시 토큰이면 패밀리 전체 폐기
+	token, err := s.refreshRepo.FindRefreshTokenByHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if token != nil {
+		if token.ClientID != client.ClientID {
+			return nil
+		}
+		return s.refreshRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+	}
+
+	// 액세스 토큰이면 jti 폐기
+	claims, err := s.ValidateToken(ctx, req.Token)
+	if err != nil || claims.ClientID != client.ClientID {
+		return nil
+	}
+	return s.revokeAccessToken(ctx, claims)
+}
+
+// checkAuthorizeRequest 인가 요청 확인 후 클라이언트와 부여할 scope 반환
+// client_id/redirect_uri 오류는 클라이언트 없이, 그 밖의 오류는 클라이언트와 *OAuthError로 반환한다.
+func (s *AuthService) checkAuthorizeRequest(ctx context.Context, req *models.AuthorizeRequest) (*models.OAuthClient, string, error) {
+	if req.ClientID == "" {
+		return nil, "", errors.New("client_id is required")
+	}
+
+	client, err := s.clientRepo.FindClientByClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, "", err
+	}
+	if client == nil {
+		return nil, "", errors.New("unknown client")
+	}
+	if !containsString(client.RedirectURIs, req.RedirectURI) {
+		return nil, "", errors.New("redirect_uri is not registered for this client")
+	}
+
+	if req.ResponseType != "code" {
+		return client, "", oauthError("unsupported_response_type", "only the authorization code flow is supported")
+	}
+	// 모든 클라이언트에 PKCE(S256) 필수
+	if req.CodeChallengeMethod != "S256" || len(req.CodeChallenge) != 43 {
+		return client, "", oauthError("invalid_request", "code_challenge with code_challenge_method S256 is required")
+	}
+	if len(req.Nonce) > maxNonceLength {
+		return client, "", oauthError("invalid_request", "nonce is too long")
+	}
+
+	scope, err := s.grantableScope(client, req.Scope)
+	if err != nil {
+		return client, "", err
+	}
+	return client, scope, nil
+}
+
+// grantableScope 요청한 scope 확인 (비어 있으면 클라이언트에 허용된 전체 scope)
+func (s *AuthService) grantableScope(client *models.OAuthClient, requested string) (string, error) {
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !containsString(client.Scopes, scope) || !containsString(s.supportedScopes(), scope) {
+			return "", oauthError("invalid_scope", fmt.Sprintf("scope %q is not allowed for this client", scope))
+		}
+		if !containsString(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return strings.Join(granted, " "), nil
+}
+
+// authenticateClient 토큰 엔드포인트의 클라이언트 인증 (공개 클라이언트는 client_id만)
+func (s *AuthService) authenticateClient(ctx context.Context, clientID, secret string) (*models.OAuthClient, error) {
+	if clientID == "" {
+		return nil, oauthError("invalid_client", "client authentication failed")
+	}
+
+	client, err := s.clientRepo.FindClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, oauthError("invalid_client", "client authentication failed")
+	}
+	if client.Public {
+		return client, nil
+	}
+
+	if secret == "" || subtle.ConstantTimeCompare([]byte(utils.HashToken(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthError("invalid_client", "client authentication failed")
+	}
+	return client, nil
+}
+
+// exchangeAuthorizationCode 인가 코드를 토큰으로 교환 (코드는 한 번만 사용 가능)
+func (s *AuthService) exchangeAuthorizationCode(ctx context.Context, client *models.OAuthClient, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	if req.Code == "" {
+		return nil, oauthError("invalid_request", "code is required")
+	}
+
+	flow, err := s.flowRepo.ConsumeFlow(ctx, utils.HashToken(req.Code), flowOAuthAuthorizationCode)
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil {
+		return nil, oauthError("invalid_grant", "invalid or expired authorization code")
+	}
+
+	var code oauthAuthorizationCode
+	if err := json.Unmarshal(flow.Data, &code); err != nil {
+		return nil, err
+	}
+	if code.ClientID != client.ClientID || code.RedirectURI != req.RedirectURI {
+		return nil, oauthError("invalid_grant", "invalid or expired authorization code")
+	}
+	if !utils.VerifyPKCE(req.CodeVerifier, code.CodeChallenge) {
+		return nil, oauthError("invalid_grant", "code_verifier does not match the code challenge")
+	}
+
+	user, err := s.repo.FindUserByID(ctx, flow.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, oauthError("invalid_grant", "invalid or expired authorization code")
+	}
+	// 동의 후 교환 전에 정지, 차단, 삭제된 계정
+	if err := accountStatusError(user); err != nil {
+		return nil, oauthError("invalid_grant", err.Error())
+	}
+
+	response, err := s.issueOAuthTokens(ctx, user, client.ClientID, code.Scope, code.Scope, utils.GenerateRandomToken(16))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachIDToken(response, user, client.ClientID, code.Scope, code.Nonce); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// refreshOAuthToken 클라이언트의 리프레시 토큰 회전 (scope는 처음 부여된 범위 안에서만 줄일 수 있다)
+func (s *AuthService) refreshOAuthToken(ctx context.Context, client *models.OAuthClient, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, oauthError("invalid_request", "refresh_token is required")
+	}
+
+	// 회전하기 전에 요청한 scope부터 확인 (잘못된 요청으로 토큰이 소모되지 않도록)
+	var accessScope string
+	if req.Scope != "" {
+		existing, err := s.refreshRepo.FindRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			granted := strings.Fields(existing.Scope)
+			for _, requested := range strings.Fields(req.Scope) {
+				if !containsString(granted, requested) {
+					return nil, oauthError("invalid_scope", fmt.Sprintf("scope %q was not granted", requested))
+				}
+			}
+			accessScope = strings.Join(strings.Fields(req.Scope), " ")
+		}
+	}
+
+	token, user, err := s.rotateRefreshToken(ctx, req.RefreshToken, client.ClientID)
+	if err != nil {
+		return nil, oauthError("invalid_grant", err.Error())
+	}
+	if accessScope == "" {
+		accessScope = token.Scope
+	}
+
+	// 새 리프레시 토큰은 처음 부여된 scope를 그대로 유지 (RFC 6749 6절)
+	response, err := s.issueOAuthTokens(ctx, user, client.ClientID, accessScope, token.Scope, token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	// 갱신 응답의 ID 토큰은 선택 사항이므로 서명 키가 HS256으로 바뀌었으면 생략 (OpenID Connect Core 12.2)
+	if s.idTokensEnabled() {
+		if err := s.attachIDToken(response, user, client.ClientID, accessScope, ""); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// issueOAuthTokens 클라이언트에 위임된 액세스 토큰 발급
+// 부여된 scope(grantedScope)에 offline_access가 있으면 같은 scope의 리프레시 토큰도 발급한다.
+func (s *AuthService) issueOAuthTokens(ctx context.Context, user *models.User, clientID, scope, grantedScope, familyID string) (*models.OAuthTokenResponse, error) {
+	claims := &utils.JWTClaim{
+		UserID:       user.ID.Hex(),
+		TokenVersion: user.TokenVersion,
+		TokenUse:     utils.TokenUseAccess,
+		ClientID:     clientID,
+		Scope:        scope,
+	}
+	// 이메일은 email scope를 받은 클라이언트에만 공개
+	if containsString(strings.Fields(scope), models.ScopeEmail) {
+		claims.Email = user.Email
+	}
+
+	accessToken, err := utils.GenerateJWT(claims, s.keys.SigningKey(), s.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.accessTTL.Seconds()),
+		Scope:       scope,
+	}
+
+	if containsString(strings.Fields(grantedScope), models.ScopeOfflineAccess) {
+		refreshToken, err := s.createRefreshToken(ctx, &models.RefreshToken{
+			FamilyID: familyID,
+			UserID:   user.ID,
+			ClientID: clientID,
+			Scope:    grantedScope,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.RefreshToken = refreshToken
+	}
+	return response, nil
+}
+
+// validateRedirectURI 등록 가능한 redirect URI 확인
+// https, 루프백 http, 네이티브 앱의 역도메인 스킴(com.example.app:/callback)만 허용한다.
+func validateRedirectURI(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Fragment != "" {
+		return fmt.Errorf("invalid redirect URI: %s", raw)
+	}
+
+	switch u.Scheme {
+	case "https":
+		if u.Host == "" {
+			return fmt.Errorf("invalid redirect URI: %s", raw)
+		}
+	case "http":
+		host := u.Hostname()
+		if host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			return fmt.Errorf("redirect URI must use https: %s", raw)
+		}
+	default:
+		if !strings.Contains(u.Scheme, ".") {
+			return fmt.Errorf("invalid redirect URI: %s", raw)
+		}
+	}
+	return nil
+}
+
+// authorizeErrorRedirect 인가 오류를 클라이언트의 redirect_uri로 전달하는 URL
+func authorizeErrorRedirect(req *models.AuthorizeRequest, oauthErr *OAuthError) string {
+	params := url.Values{
+		"error":             {oauthErr.Code},
+		"error_description": {oauthErr.Description},
+	}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return appendQuery(req.RedirectURI, params)
+}
+
+// appendQuery URL의 기존 쿼리를 유지하면서 파라미터 추가
+func appendQuery(raw string, params url.Values) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
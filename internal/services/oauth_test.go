@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+func TestAuthorizationCodeRejectsInactiveAccount(t *testing.T) {
+	for _, status := range []models.UserStatus{models.UserStatusSuspended, models.UserStatusBanned, models.UserStatusDeleted} {
+		t.Run(string(status), func(t *testing.T) {
+			ts := newOAuthTestService(t, utils.NewHMACSigningKey("", testSecret))
+			owner := ts.createUser(t, "member@example.com", "Password123!", true)
+			user := ts.login(t, "member@example.com", "Password123!")
+			client := ts.createClient(t, user)
+
+			verifier := utils.GenerateRandomToken(32)
+			callback := ts.authorize(t, user, authorizeRequest(client, "email offline_access", verifier))
+
+			// 동의한 뒤 코드를 교환하기 전에 계정 상태가 바뀐 경우
+			if _, err := ts.stores.Users.UpdateStatus(context.Background(), owner.ID, models.UserStatusActive, status, "test"); err != nil {
+				t.Fatalf("update status: %v", err)
+			}
+
+			resp, err := ts.exchangeCode(client, callback.Get("code"), verifier)
+			var oauthErr *OAuthError
+			if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+				t.Fatalf("exchange = %+v, %v; want invalid_grant", resp, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizationCodeRequiresPKCE(t *testing.T) {
+	ts := newOAuthTestService(t, utils.NewHMACSigningKey("", testSecret))
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	user := ts.login(t, "member@example.com", "Password123!")
+	client := ts.createClient(t, user)
+
+	// S256 code_challenge가 없거나 plain이면 인가 코드를 발급하지 않는다
+	tests := map[string]func(req *models.AuthorizeRequest){
+		"missing challenge": func(req *models.AuthorizeRequest) { req.CodeChallenge, req.CodeChallengeMethod = "", "" },
+		"plain method":      func(req *models.AuthorizeRequest) { req.CodeChallengeMethod = "plain" },
+		"short challenge":   func(req *models.AuthorizeRequest) { req.CodeChallenge = req.CodeChallenge[:20] },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := authorizeRequest(client, "email", utils.GenerateRandomToken(32))
+			modify(req)
+			callback := ts.authorize(t, user, req)
+			if callback.Get("error") != "invalid_request" || callback.Get("code") != "" {
+				t.Errorf("authorize = %v, want invalid_request", callback)
+			}
+		})
+	}
+}
+
+func TestAuthorizationCodeVerifiesPKCE(t *testing.T) {
+	ts := newOAuthTestService(t, utils.NewHMACSigningKey("", testSecret))
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	user := ts.login(t, "member@example.com", "Password123!")
+	client := ts.createClient(t, user)
+
+	verifier := utils.GenerateRandomToken(32)
+	callback := ts.authorize(t, user, authorizeRequest(client, "email", verifier))
+	if callback.Get("state") != "state-1" {
+		t.Errorf("callback state = %q, want state-1", callback.Get("state"))
+	}
+
+	// 틀린 code_verifier로 교환을 시도하면 코드는 소모된다
+	_, err := ts.exchangeCode(client, callback.Get("code"), utils.GenerateRandomToken(32))
+	var oauthErr *OAuthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+		t.Fatalf("exchange with a wrong verifier = %v, want invalid_grant", err)
+	}
+	if _, err := ts.exchangeCode(client, callback.Get("code"), verifier); err == nil {
+		t.Error("code was exchanged after a failed PKCE check")
+	}
+
+	// 새 코드는 맞는 code_verifier로 한 번만 교환된다
+	callback = ts.authorize(t, user, authorizeRequest(client, "email", verifier))
+	resp, err := ts.exchangeCode(client, callback.Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if resp.AccessToken == "" || resp.Scope != "email" {
+		t.Errorf("token response = %+v", resp)
+	}
+	if _, err := ts.exchangeCode(client, callback.Get("code"), verifier); err == nil {
+		t.Error("authorization code was exchanged twice")
+	}
+}
+
+func TestPublicClientUsesPKCEWithoutSecret(t *testing.T) {
+	ts := newOAuthTestService(t, utils.NewHMACSigningKey("", testSecret))
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	user := ts.login(t, "member@example.com", "Password123!")
+
+	client, err := ts.CreateOAuthClient(context.Background(), ts.claims(t, user), &models.CreateOAuthClientRequest{
+		Name:         "mobile app",
+		RedirectURIs: []string{testRedirectURI},
+		Public:       true,
+	})
+	if err != nil {
+		t.Fatalf("create public client: %v", err)
+	}
+	if client.ClientSecret != "" {
+		t.Fatal("public client was issued a secret")
+	}
+
+	verifier := utils.GenerateRandomToken(32)
+	callback := ts.authorize(t, user, authorizeRequest(client, "email", verifier))
+	if _, err := ts.exchangeCode(client, callback.Get("code"), verifier); err != nil {
+		t.Fatalf("public client exchange: %v", err)
+	}
+}
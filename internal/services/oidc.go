@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// 인가 요청의 nonce 최대 길이
+const maxNonceLength = 255
+
+// ProviderMetadata OpenID Connect Discovery 문서 (/.well-known/openid-configuration)
+// 대칭키(HS256)로 서명하는 동안에는 openid scope와 ID 토큰 서명 알고리즘을 광고하지 않는다.
+func (s *AuthService) ProviderMetadata() *models.ProviderMetadata {
+	issuer := s.config.Issuer()
+	idTokenAlgs := []string{}
+	if s.idTokensEnabled() {
+		idTokenAlgs = append(idTokenAlgs, s.keys.SigningKey().Algorithm)
+	}
+	return &models.ProviderMetadata{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth/authorize",
+		TokenEndpoint:                     issuer + "/oauth/token",
+		UserInfoEndpoint:                  issuer + "/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		RevocationEndpoint:                issuer + "/oauth/revoke",
+		ScopesSupported:                   s.supportedScopes(),
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token", "client_credentials"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  idTokenAlgs,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "exp", "iat", "nonce", "email", "email_verified", "updated_at"},
+	}
+}
+
+// idTokensEnabled ID 토큰 발급 가능 여부
+// HS256 서명 키는 공개할 수 없어 클라이언트가 ID 토큰을 검증할 수 없으므로 비대칭 알고리즘일 때만 발급한다.
+func (s *AuthService) idTokensEnabled() bool {
+	return !s.keys.SigningKey().IsSymmetric()
+}
+
+// supportedScopes 부여할 수 있는 scope (ID 토큰을 발급할 수 없으면 openid 제외)
+func (s *AuthService) supportedScopes() []string {
+	scopes := make([]string, 0, len(s.config.OAuthScopes))
+	for _, scope := range s.config.OAuthScopes {
+		if scope == models.ScopeOpenID && !s.idTokensEnabled() {
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// UserInfo 위임된 액세스 토큰의 사용자 정보 (openid scope 필요)
+func (s *AuthService) UserInfo(ctx context.Context, claims *utils.JWTClaim) (*models.UserInfo, error) {
+	scopes := strings.Fields(claims.Scope)
+	if claims.ClientID == "" || !containsString(scopes, models.ScopeOpenID) {
+		return nil, oauthError("insufficient_scope", "the access token does not have the openid scope")
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &models.UserInfo{Sub: user.ID.Hex()}
+	if containsString(scopes, models.ScopeEmail) {
+		verified := user.EmailVerified
+		info.Email = user.Email
+		info.EmailVerified = &verified
+	}
+	if containsString(scopes, models.ScopeProfile) && !user.UpdatedAt.IsZero() {
+		info.UpdatedAt = user.UpdatedAt.Unix()
+	}
+	return info, nil
+}
+
+// attachIDToken openid scope가 있으면 응답에 ID 토큰 추가
+func (s *AuthService) attachIDToken(response *models.OAuthTokenResponse, user *models.User, clientID, scope, nonce string) error {
+	scopes := strings.Fields(scope)
+	if !containsString(scopes, models.ScopeOpenID) {
+		return nil
+	}
+	// 서명 키가 HS256으로 바뀌기 전에 발급된 인가 코드
+	if !s.idTokensEnabled() {
+		return oauthError("invalid_scope", "openid requires an asymmetric JWT_ALGORITHM")
+	}
+
+	claims := &utils.IDTokenClaims{
+		Nonce: nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   s.config.Issuer(),
+			Subject:  user.ID.Hex(),
+			Audience: jwt.ClaimStrings{clientID},
+		},
+	}
+	if containsString(scopes, models.ScopeEmail) {
+		verified := user.EmailVerified
+		claims.Email = user.Email
+		claims.EmailVerified = &verified
+	}
+
+	idToken, err := utils.GenerateIDToken(claims, s.keys.SigningKey(), s.accessTTL)
+	if err != nil {
+		return err
+	}
+	response.IDToken = idToken
+	return nil
+}
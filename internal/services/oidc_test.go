@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// generateKey 테스트용 서명 키 생성
+func generateKey(t *testing.T, alg string) *utils.SigningKey {
+	t.Helper()
+
+	key, err := utils.GenerateSigningKey(alg)
+	if err != nil {
+		t.Fatalf("generate %s key: %v", alg, err)
+	}
+	return key
+}
+
+func TestIDTokenSignedWithAsymmetricKey(t *testing.T) {
+	key := generateKey(t, utils.AlgRS256)
+	ts := newOAuthTestService(t, key)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	user := ts.login(t, "member@example.com", "Password123!")
+	client := ts.createClient(t, user)
+
+	metadata := ts.ProviderMetadata()
+	if !containsString(metadata.ScopesSupported, models.ScopeOpenID) {
+		t.Errorf("scopes_supported = %v, want openid", metadata.ScopesSupported)
+	}
+	if len(metadata.IDTokenSigningAlgValuesSupported) != 1 || metadata.IDTokenSigningAlgValuesSupported[0] != utils.AlgRS256 {
+		t.Errorf("id_token_signing_alg_values_supported = %v, want [RS256]", metadata.IDTokenSigningAlgValuesSupported)
+	}
+
+	verifier := utils.GenerateRandomToken(32)
+	callback := ts.authorize(t, user, authorizeRequest(client, "openid email", verifier))
+	resp, err := ts.exchangeCode(client, callback.Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("exchange code: %v", err)
+	}
+	if resp.IDToken == "" {
+		t.Fatal("no id token for the openid scope")
+	}
+
+	// 클라이언트는 공개키만으로 ID 토큰을 검증할 수 있어야 한다
+	claims := &utils.IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(resp.IDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return key.PublicKey(), nil
+	}, jwt.WithValidMethods([]string{utils.AlgRS256}), jwt.WithIssuer("http://localhost:8001"), jwt.WithAudience(client.ClientID))
+	if err != nil {
+		t.Fatalf("verify id token with the public key: %v", err)
+	}
+	if claims.Nonce != "nonce-1" || claims.Email != "member@example.com" {
+		t.Errorf("id token claims = %+v", claims)
+	}
+}
+
+func TestOpenIDScopeRefusedWithSymmetricKey(t *testing.T) {
+	ts := newOAuthTestService(t, utils.NewHMACSigningKey("", testSecret))
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	user := ts.login(t, "member@example.com", "Password123!")
+
+	t.Run("discovery", func(t *testing.T) {
+		metadata := ts.ProviderMetadata()
+		if containsString(metadata.ScopesSupported, models.ScopeOpenID) {
+			t.Errorf("scopes_supported = %v, advertises openid", metadata.ScopesSupported)
+		}
+		if len(metadata.IDTokenSigningAlgValuesSupported) != 0 {
+			t.Errorf("id_token_signing_alg_values_supported = %v, want none", metadata.IDTokenSigningAlgValuesSupported)
+		}
+	})
+
+	t.Run("client registration", func(t *testing.T) {
+		_, err := ts.CreateOAuthClient(context.Background(), ts.claims(t, user), &models.CreateOAuthClientRequest{
+			Name:         "oidc client",
+			RedirectURIs: []string{testRedirectURI},
+			Scopes:       []string{models.ScopeOpenID},
+		})
+		if err == nil {
+			t.Fatal("client registered with the openid scope")
+		}
+		if client := ts.createClient(t, user); containsString(client.Scopes, models.ScopeOpenID) {
+			t.Errorf("default client scopes = %v, include openid", client.Scopes)
+		}
+	})
+
+	t.Run("authorization", func(t *testing.T) {
+		client := ts.createClient(t, user)
+		callback := ts.authorize(t, user, authorizeRequest(client, "openid email", utils.GenerateRandomToken(32)))
+		if callback.Get("error") != "invalid_scope" || callback.Get("code") != "" {
+			t.Errorf("authorize openid = %v, want invalid_scope", callback)
+		}
+	})
+}
+
+func TestIDTokenRefusedAfterSwitchToSymmetricKey(t *testing.T) {
+	key := generateKey(t, utils.AlgES256)
+	ts := newOAuthTestService(t, key)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	user := ts.login(t, "member@example.com", "Password123!")
+	client := ts.createClient(t, user)
+
+	verifier := utils.GenerateRandomToken(32)
+	callback := ts.authorize(t, user, authorizeRequest(client, "openid offline_access", verifier))
+	resp, err := ts.exchangeCode(client, callback.Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("exchange code: %v", err)
+	}
+
+	// 예전에 부여된 openid scope로 갱신해도 HS256으로 서명한 ID 토큰은 발급하지 않는다
+	ts.keys = utils.NewKeySet(utils.NewHMACSigningKey("", testSecret))
+	refreshed, err := ts.OAuthToken(context.Background(), &models.OAuthTokenRequest{
+		GrantType:    "refresh_token",
+		RefreshToken: resp.RefreshToken,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+	})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if refreshed.IDToken != "" {
+		t.Error("id token was signed with the symmetric key")
+	}
+
+	// 전환 전에 발급된 인가 코드도 ID 토큰 없이는 교환하지 않는다
+	ts.keys = utils.NewKeySet(key)
+	callback = ts.authorize(t, user, authorizeRequest(client, "openid", verifier))
+	ts.keys = utils.NewKeySet(utils.NewHMACSigningKey("", testSecret))
+	_, err = ts.exchangeCode(client, callback.Get("code"), verifier)
+	var oauthErr *OAuthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_scope" {
+		t.Fatalf("exchange = %v, want invalid_scope", err)
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// ErrPermissionDenied 요청에 필요한 권한이 없음
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ListRoles 관리자: 정의된 역할과 역할별 권한
+func (s *AuthService) ListRoles(ctx context.Context, claims *utils.JWTClaim) ([]models.RoleInfo, error) {
+	if err := s.requirePermission(ctx, claims, models.PermissionRolesAssign); err != nil {
+		return nil, err
+	}
+	return models.Roles(), nil
+}
+
+// SetUserRoles 관리자: 사용자의 역할 교체
+// 토큰 버전이 올라가므로 기존 액세스 토큰은 무효가 되고 리프레시하면 새 역할로 발급된다.
+func (s *AuthService) SetUserRoles(ctx context.Context, claims *utils.JWTClaim, userID string, req *models.UpdateRolesRequest) (*models.User, error) {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionRolesAssign)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == admin.ID {
+		return nil, errors.New("cannot change your own roles")
+	}
+
+	roles, err := normalizeNames(req.Roles, models.IsKnownRole, "role")
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, errors.New("at least one role is required")
+	}
+
+	updated, err := s.repo.SetRoles(ctx, user.ID, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, admin, models.AuditActionUpdateRoles, updated, accessChange(user.AccountRoles(), roles), "")
+	return updated, nil
+}
+
+// BootstrapAdmins 시작할 때 ADMIN_EMAILS 계정에 admin 역할 부여 (첫 관리자 생성용)
+// 먼저 가입한 다른 사람이 권한을 가져가지 않도록 이메일 인증을 마친 활성 계정만 승격하고,
+// 이미 admin이면 건너뛴다. 부여한 역할은 시스템 작업으로 감사 기록에 남는다.
+func (s *AuthService) BootstrapAdmins(ctx context.Context) error {
+	for _, email := range s.config.AdminEmails {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+
+		user, err := s.repo.FindUserByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			log.Printf("ADMIN_EMAILS: no account for %s, skipped", email)
+			continue
+		}
+		if !user.EmailVerified || accountStatusError(user) != nil {
+			log.Printf("ADMIN_EMAILS: %s is not a verified active account, skipped", email)
+			continue
+		}
+		if user.HasRole(models.RoleAdmin) {
+			continue
+		}
+
+		roles := append(append([]string(nil), user.AccountRoles()...), models.RoleAdmin)
+		updated, err := s.repo.SetRoles(ctx, user.ID, roles)
+		if err != nil {
+			return err
+		}
+		s.recordAudit(ctx, nil, models.AuditActionBootstrapAdmin, updated, accessChange(user.AccountRoles(), roles), "ADMIN_EMAILS")
+		log.Printf("ADMIN_EMAILS: granted admin role to %s", email)
+	}
+	return nil
+}
+
+// SetUserPermissions 관리자: 역할과 별개로 직접 부여한 권한 교체 (빈 목록이면 모두 회수)
+func (s *AuthService) SetUserPermissions(ctx context.Context, claims *utils.JWTClaim, userID string, req *models.UpdatePermissionsRequest) (*models.User, error) {
+	admin, err := s.userWithPermission(ctx, claims, models.PermissionRolesAssign)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByHex(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == admin.ID {
+		return nil, errors.New("cannot change your own permissions")
+	}
+
+	permissions, err := normalizeNames(req.Permissions, models.IsKnownPermission, "permission")
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.SetPermissions(ctx, user.ID, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, admin, models.AuditActionUpdatePermissions, updated, accessChange(user.Permissions, permissions), "")
+	return updated, nil
+}
+
+// requirePermission 토큰의 사용자가 권한을 가졌는지 확인
+func (s *AuthService) requirePermission(ctx context.Context, claims *utils.JWTClaim, permission string) error {
+	_, err := s.userWithPermission(ctx, claims, permission)
+	return err
+}
+
+// userWithPermission 권한을 가진 토큰의 사용자 (권한이 없으면 ErrPermissionDenied)
+// 토큰의 클레임이 아니라 저장된 현재 역할과 권한으로 확인한다.
+func (s *AuthService) userWithPermission(ctx context.Context, claims *utils.JWTClaim, permission string) (*models.User, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !user.HasPermission(permission) {
+		return nil, ErrPermissionDenied
+	}
+	return user, nil
+}
+
+// normalizeNames 공백 제거, 중복 제거, 정렬 후 알려진 이름인지 확인
+func normalizeNames(names []string, known func(string) bool, kind string) ([]string, error) {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !known(name) {
+			return nil, errors.New("unknown " + kind + ": " + name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// accessChange 감사 기록용 변경 전후 목록
+func accessChange(before, after []string) string {
+	return strings.Join(before, ",") + " -> " + strings.Join(after, ",")
+}
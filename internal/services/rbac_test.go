@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+func TestBootstrapAdmins(t *testing.T) {
+	ts := newTestService(t, func(cfg *config.Config) {
+		cfg.AdminEmails = []string{" owner@example.com", "unverified@example.com", "missing@example.com", ""}
+	})
+	owner := ts.createUser(t, "owner@example.com", "Password123!", true)
+	unverified := ts.createUser(t, "unverified@example.com", "Password123!", false)
+	ctx := context.Background()
+
+	if err := ts.BootstrapAdmins(ctx); err != nil {
+		t.Fatalf("bootstrap admins: %v", err)
+	}
+
+	promoted, err := ts.stores.Users.FindUserByID(ctx, owner.ID)
+	if err != nil {
+		t.Fatalf("find owner: %v", err)
+	}
+	if !promoted.HasRole(models.RoleAdmin) || !promoted.HasRole(models.RoleBuyer) {
+		t.Errorf("owner roles = %v, want buyer and admin", promoted.Roles)
+	}
+	if promoted.TokenVersion == owner.TokenVersion {
+		t.Error("token version was not bumped, old tokens keep the previous roles")
+	}
+
+	logs, err := ts.stores.AuditLogs.ListAuditLogsByTarget(ctx, owner.ID, 10)
+	if err != nil {
+		t.Fatalf("list audit logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("audit logs = %d, want 1", len(logs))
+	}
+	entry := logs[0]
+	if entry.Action != models.AuditActionBootstrapAdmin || entry.ActorEmail != models.AuditActorSystem ||
+		entry.ActorID != primitive.NilObjectID || entry.Reason != "ADMIN_EMAILS" || entry.Detail != "buyer -> buyer,admin" {
+		t.Errorf("audit log = %+v", entry)
+	}
+
+	// 이메일 인증을 마치지 않은 계정은 승격하지 않는다
+	if stored, _ := ts.stores.Users.FindUserByID(ctx, unverified.ID); stored.HasRole(models.RoleAdmin) {
+		t.Error("unverified account was granted the admin role")
+	}
+
+	// 다시 시작해도 이미 admin인 계정은 그대로
+	if err := ts.BootstrapAdmins(ctx); err != nil {
+		t.Fatalf("second bootstrap: %v", err)
+	}
+	if logs, _ := ts.stores.AuditLogs.ListAuditLogsByTarget(ctx, owner.ID, 10); len(logs) != 1 {
+		t.Errorf("audit logs after restart = %d, want 1", len(logs))
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// CreateServiceClient 관리자: 서비스 클라이언트 등록 (시크릿은 응답으로 한 번만 반환)
+func (s *AuthService) CreateServiceClient(ctx context.Context, claims *utils.JWTClaim, req *models.CreateServiceClientRequest) (*models.CreateServiceClientResponse, error) {
+	if err := s.requirePermission(ctx, claims, models.PermissionServiceClientsManage); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("client name is required")
+	}
+	for _, scope := range req.Scopes {
+		// scope는 공백으로 구분되어 토큰에 실리므로 공백을 포함할 수 없다
+		if fields := strings.Fields(scope); len(fields) != 1 || fields[0] != scope {
+			return nil, fmt.Errorf("invalid scope: %q", scope)
+		}
+	}
+
+	secret := utils.GenerateRandomToken(32)
+	client := &models.ServiceClient{
+		ClientID:   utils.GenerateRandomToken(16),
+		SecretHash: utils.HashToken(secret),
+		Name:       name,
+		Scopes:     append([]string{}, req.Scopes...),
+	}
+	if secret == "" || client.ClientID == "" {
+		return nil, errors.New("failed to generate client credentials")
+	}
+
+	if err := s.serviceClientRepo.CreateServiceClient(ctx, client); err != nil {
+		return nil, err
+	}
+	return &models.CreateServiceClientResponse{ServiceClient: client, ClientSecret: secret}, nil
+}
+
+// ListServiceClients 관리자: 서비스 클라이언트 목록
+func (s *AuthService) ListServiceClients(ctx context.Context, claims *utils.JWTClaim) ([]*models.ServiceClient, error) {
+	if err := s.requirePermission(ctx, claims, models.PermissionServiceClientsManage); err != nil {
+		return nil, err
+	}
+	return s.serviceClientRepo.ListServiceClients(ctx)
+}
+
+// DeleteServiceClient 관리자: 서비스 클라이언트 삭제 (발급된 토큰도 바로 무효)
+func (s *AuthService) DeleteServiceClient(ctx context.Context, claims *utils.JWTClaim, id string) error {
+	if err := s.requirePermission(ctx, claims, models.PermissionServiceClientsManage); err != nil {
+		return err
+	}
+
+	clientID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("client not found")
+	}
+	return s.serviceClientRepo.DeleteServiceClient(ctx, clientID)
+}
+
+// clientCredentialsToken client_credentials 그랜트로 서비스 토큰 발급 (리프레시 토큰 없음)
+// 사용자 토큰과 구분되도록 token_use는 service, sub는 client_id이고 UserID는 비어 있다.
+func (s *AuthService) clientCredentialsToken(ctx context.Context, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	client, err := s.authenticateServiceClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := strings.Fields(req.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !containsString(client.Scopes, scope) {
+			return nil, oauthError("invalid_scope", fmt.Sprintf("scope %q is not allowed for this client", scope))
+		}
+		if !containsString(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	scope := strings.Join(granted, " ")
+
+	claims := &utils.JWTClaim{
+		TokenUse: utils.TokenUseService,
+		ClientID: client.ClientID,
+		Scope:    scope,
+	}
+	claims.Subject = client.ClientID
+
+	accessToken, err := utils.GenerateJWT(claims, s.keys.SigningKey(), s.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.accessTTL.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// authenticateServiceClient 서비스 클라이언트의 client_id와 시크릿 확인
+func (s *AuthService) authenticateServiceClient(ctx context.Context, clientID, secret string) (*models.ServiceClient, error) {
+	if clientID == "" || secret == "" {
+		return nil, oauthError("invalid_client", "client authentication failed")
+	}
+
+	client, err := s.serviceClientRepo.FindServiceClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil || subtle.ConstantTimeCompare([]byte(utils.HashToken(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthError("invalid_client", "client authentication failed")
+	}
+	return client, nil
+}
+
+// validateServiceClaims 서비스 토큰 검증 (클라이언트가 삭제되었으면 무효)
+func (s *AuthService) validateServiceClaims(ctx context.Context, claims *utils.JWTClaim) error {
+	if claims.ClientID == "" || claims.UserID != "" {
+		return errors.New("invalid token")
+	}
+
+	client, err := s.serviceClientRepo.FindServiceClientByClientID(ctx, claims.ClientID)
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return errors.New("token has been revoked")
+	}
+	return nil
+}
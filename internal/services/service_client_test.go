@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// createServiceClient 관리자가 scopes를 요청할 수 있는 서비스 클라이언트 등록
+func (ts *testService) createServiceClient(t *testing.T, admin *models.LoginResponse, scopes ...string) *models.CreateServiceClientResponse {
+	t.Helper()
+
+	client, err := ts.CreateServiceClient(context.Background(), ts.claims(t, admin), &models.CreateServiceClientRequest{
+		Name:   "order service",
+		Scopes: scopes,
+	})
+	if err != nil {
+		t.Fatalf("create service client: %v", err)
+	}
+	return client
+}
+
+// clientCredentials client_credentials 그랜트로 서비스 토큰 요청
+func (ts *testService) clientCredentials(clientID, secret, scope string) (*models.OAuthTokenResponse, error) {
+	return ts.OAuthToken(context.Background(), &models.OAuthTokenRequest{
+		GrantType:    "client_credentials",
+		ClientID:     clientID,
+		ClientSecret: secret,
+		Scope:        scope,
+	})
+}
+
+// requireOAuthError err가 code인 OAuthError인지 확인
+func requireOAuthError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var oauthErr *OAuthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != code {
+		t.Fatalf("error = %v, want %s", err, code)
+	}
+}
+
+func TestClientCredentialsToken(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.loginAdmin(t, "admin@example.com")
+	client := ts.createServiceClient(t, admin, models.PermissionOrdersRead, models.PermissionProductsWrite)
+
+	// scope를 요청하지 않으면 허용된 전체 scope
+	resp, err := ts.clientCredentials(client.ClientID, client.ClientSecret, "")
+	if err != nil {
+		t.Fatalf("client credentials: %v", err)
+	}
+	if resp.RefreshToken != "" || resp.Scope != "orders:read products:write" {
+		t.Errorf("token response = %+v", resp)
+	}
+
+	claims, err := ts.ValidateToken(context.Background(), resp.AccessToken)
+	if err != nil {
+		t.Fatalf("validate service token: %v", err)
+	}
+	if claims.TokenUse != utils.TokenUseService || claims.Subject != client.ClientID ||
+		claims.ClientID != client.ClientID || claims.UserID != "" || claims.SessionID != "" {
+		t.Errorf("service token claims = %+v", claims)
+	}
+
+	// 허용된 scope 중 일부만 요청하면 그 scope만 부여
+	resp, err = ts.clientCredentials(client.ClientID, client.ClientSecret, "orders:read orders:read")
+	if err != nil {
+		t.Fatalf("client credentials with scope: %v", err)
+	}
+	if resp.Scope != "orders:read" {
+		t.Errorf("scope = %q, want orders:read", resp.Scope)
+	}
+}
+
+func TestClientCredentialsRejectsBadClient(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.loginAdmin(t, "admin@example.com")
+	client := ts.createServiceClient(t, admin, models.PermissionOrdersRead)
+
+	tests := map[string][2]string{
+		"wrong secret":   {client.ClientID, utils.GenerateRandomToken(32)},
+		"missing secret": {client.ClientID, ""},
+		"unknown client": {utils.GenerateRandomToken(16), client.ClientSecret},
+		"missing client": {"", client.ClientSecret},
+	}
+	for name, credentials := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := ts.clientCredentials(credentials[0], credentials[1], "")
+			if resp != nil {
+				t.Errorf("token response = %+v, want none", resp)
+			}
+			requireOAuthError(t, err, "invalid_client")
+		})
+	}
+}
+
+func TestClientCredentialsRejectsScopeOutsideAllowedSet(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.loginAdmin(t, "admin@example.com")
+	client := ts.createServiceClient(t, admin, models.PermissionOrdersRead)
+
+	// 허용되지 않은 scope가 하나라도 있으면 일부만 부여하지 않고 거부
+	for _, scope := range []string{models.PermissionUsersWrite, "orders:read users:write"} {
+		resp, err := ts.clientCredentials(client.ClientID, client.ClientSecret, scope)
+		if resp != nil {
+			t.Errorf("scope %q: token response = %+v, want none", scope, resp)
+		}
+		requireOAuthError(t, err, "invalid_scope")
+	}
+}
+
+func TestDeletedServiceClient(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.loginAdmin(t, "admin@example.com")
+	client := ts.createServiceClient(t, admin, models.PermissionOrdersRead)
+
+	resp, err := ts.clientCredentials(client.ClientID, client.ClientSecret, "")
+	if err != nil {
+		t.Fatalf("client credentials: %v", err)
+	}
+
+	if err := ts.DeleteServiceClient(context.Background(), ts.claims(t, admin), client.ID.Hex()); err != nil {
+		t.Fatalf("delete service client: %v", err)
+	}
+
+	// 삭제된 클라이언트는 새 토큰을 받을 수 없고 이미 발급된 토큰도 무효
+	_, err = ts.clientCredentials(client.ClientID, client.ClientSecret, "")
+	requireOAuthError(t, err, "invalid_client")
+	if _, err := ts.ValidateToken(context.Background(), resp.AccessToken); err == nil {
+		t.Error("service token was accepted after its client was deleted")
+	}
+}
+
+func TestServiceClientsRequirePermission(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	member := ts.claims(t, ts.login(t, "member@example.com", "Password123!"))
+
+	_, err := ts.CreateServiceClient(context.Background(), member, &models.CreateServiceClientRequest{Name: "order service"})
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("create by member = %v, want %v", err, ErrPermissionDenied)
+	}
+	if _, err := ts.ListServiceClients(context.Background(), member); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("list by member = %v, want %v", err, ErrPermissionDenied)
+	}
+}
+
+func TestServiceTokenCannotActAsUser(t *testing.T) {
+	ts := newTestService(t)
+	admin := ts.loginAdmin(t, "admin@example.com")
+	client := ts.createServiceClient(t, admin, models.PermissionOrdersRead)
+
+	resp, err := ts.clientCredentials(client.ClientID, client.ClientSecret, "")
+	if err != nil {
+		t.Fatalf("client credentials: %v", err)
+	}
+	claims, err := ts.ValidateToken(context.Background(), resp.AccessToken)
+	if err != nil {
+		t.Fatalf("validate service token: %v", err)
+	}
+
+	// 사용자가 없는 토큰이므로 사용자 대상 기능과 관리 기능에 쓸 수 없다
+	if _, err := ts.ListSessions(context.Background(), claims); err == nil {
+		t.Error("service token listed sessions")
+	}
+	if _, err := ts.ListServiceClients(context.Background(), claims); err == nil {
+		t.Error("service token listed service clients")
+	}
+
+	// user_id를 끼워 넣은 서비스 토큰은 거부
+	forged := *claims
+	forged.UserID = ts.claims(t, admin).UserID
+	if err := ts.ValidateClaims(context.Background(), &forged); err == nil {
+		t.Error("service token with a user id was accepted")
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// ClientInfo 로그인한 기기 정보 (세션 목록에 표시)
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo 요청한 기기 정보를 context에 저장
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// clientInfoFrom context에 저장된 기기 정보 (없으면 빈 값)
+func clientInfoFrom(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// recordSession 토큰을 발급할 때 세션 기록
+// 새 패밀리면 세션을 만들고, 회전이면 마지막 사용 시각과 만료 시각을 연장한다.
+func (s *AuthService) recordSession(ctx context.Context, user *models.User, familyID string) error {
+	now := time.Now()
+	session, err := s.sessionRepo.FindSession(ctx, familyID)
+	if err != nil {
+		return err
+	}
+	if session != nil {
+		if session.RevokedAt != nil || session.UserID != user.ID {
+			return errors.New("session has been revoked")
+		}
+		return s.sessionRepo.TouchSession(ctx, familyID, now, now.Add(s.refreshTTL))
+	}
+
+	info := clientInfoFrom(ctx)
+	return s.sessionRepo.CreateSession(ctx, &models.Session{
+		ID:         familyID,
+		UserID:     user.ID,
+		UserAgent:  info.UserAgent,
+		IP:         info.IP,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+	})
+}
+
+// validateSession 액세스 토큰의 세션이 아직 유효한지 확인 (sid가 없는 이전 토큰은 통과)
+func (s *AuthService) validateSession(ctx context.Context, claims *utils.JWTClaim) error {
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	session, err := s.sessionRepo.FindSession(ctx, claims.SessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.RevokedAt != nil || session.UserID.Hex() != claims.UserID {
+		return errors.New("session has been revoked")
+	}
+	return nil
+}
+
+// ListSessions 로그인한 기기 목록 (요청한 토큰의 세션은 Current로 표시)
+func (s *AuthService) ListSessions(ctx context.Context, claims *utils.JWTClaim) ([]*models.Session, error) {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+
+	sessions, err := s.sessionRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Current = session.ID == claims.SessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession 세션 하나를 원격 로그아웃 (해당 기기의 액세스 토큰과 리프레시 토큰이 모두 무효)
+func (s *AuthService) RevokeSession(ctx context.Context, claims *utils.JWTClaim, sessionID string) error {
+	session, err := s.sessionRepo.FindSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.RevokedAt != nil || session.UserID.Hex() != claims.UserID {
+		return errors.New("session not found")
+	}
+
+	return s.endSession(ctx, sessionID)
+}
+
+// RevokeOtherSessions 요청한 기기를 뺀 모든 세션을 원격 로그아웃
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, claims *utils.JWTClaim) error {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return errors.New("invalid token")
+	}
+
+	revoked, err := s.sessionRepo.RevokeUserSessions(ctx, userID, claims.SessionID)
+	if err != nil {
+		return err
+	}
+	for _, id := range revoked {
+		if err := s.refreshRepo.RevokeRefreshTokenFamily(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endSession 세션과 그 세션의 리프레시 토큰 패밀리 폐기
+func (s *AuthService) endSession(ctx context.Context, sessionID string) error {
+	if err := s.sessionRepo.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+	return s.refreshRepo.RevokeRefreshTokenFamily(ctx, sessionID)
+}
+
+// revokeUserSessions 사용자의 모든 세션과 리프레시 토큰 폐기 (OAuth 클라이언트에 발급된 토큰 포함)
+func (s *AuthService) revokeUserSessions(ctx context.Context, userID primitive.ObjectID) error {
+	if _, err := s.sessionRepo.RevokeUserSessions(ctx, userID, ""); err != nil {
+		return err
+	}
+	return s.refreshRepo.RevokeUserRefreshTokens(ctx, userID)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+)
+
+// requireSessionEnded 원격 로그아웃된 세션의 액세스 토큰과 리프레시 토큰을 모두 쓸 수 없는지 확인
+func (ts *testService) requireSessionEnded(t *testing.T, resp *models.LoginResponse) {
+	t.Helper()
+
+	if _, err := ts.ValidateToken(context.Background(), resp.Token); err == nil || err.Error() != "session has been revoked" {
+		t.Errorf("access token of the revoked session = %v, want session has been revoked", err)
+	}
+	if _, err := ts.refresh(resp.RefreshToken); err == nil {
+		t.Error("refresh token of the revoked session still refreshes")
+	}
+}
+
+// requireSessionActive 세션의 액세스 토큰과 리프레시 토큰을 아직 쓸 수 있는지 확인
+func (ts *testService) requireSessionActive(t *testing.T, resp *models.LoginResponse) {
+	t.Helper()
+
+	if _, err := ts.ValidateToken(context.Background(), resp.Token); err != nil {
+		t.Errorf("access token of an active session: %v", err)
+	}
+	if _, err := ts.refresh(resp.RefreshToken); err != nil {
+		t.Errorf("refresh token of an active session: %v", err)
+	}
+}
+
+func TestListSessionsMarksCurrent(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	laptop := ts.login(t, "member@example.com", "Password123!")
+	ts.login(t, "member@example.com", "Password123!")
+
+	sessions, err := ts.ListSessions(context.Background(), ts.claims(t, laptop))
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("sessions = %d, want 2", len(sessions))
+	}
+	current := 0
+	for _, session := range sessions {
+		if session.Current {
+			current++
+			if session.ID != ts.claims(t, laptop).SessionID {
+				t.Errorf("current session = %s, want the laptop session", session.ID)
+			}
+		}
+	}
+	if current != 1 {
+		t.Errorf("sessions marked current = %d, want 1", current)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	laptop := ts.login(t, "member@example.com", "Password123!")
+	phone := ts.login(t, "member@example.com", "Password123!")
+
+	phoneSession := ts.claims(t, phone).SessionID
+	if err := ts.RevokeSession(context.Background(), ts.claims(t, laptop), phoneSession); err != nil {
+		t.Fatalf("revoke session: %v", err)
+	}
+
+	ts.requireSessionEnded(t, phone)
+	ts.requireSessionActive(t, laptop)
+
+	// 이미 폐기된 세션은 다시 폐기할 수 없다
+	if err := ts.RevokeSession(context.Background(), ts.claims(t, laptop), phoneSession); err == nil {
+		t.Error("revoked session was revoked again")
+	}
+}
+
+func TestRevokeSessionOfAnotherUser(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	ts.createUser(t, "other@example.com", "Password123!", true)
+	member := ts.login(t, "member@example.com", "Password123!")
+	other := ts.login(t, "other@example.com", "Password123!")
+
+	// 다른 사용자의 세션은 없는 세션과 같게 응답하고 폐기하지 않는다
+	err := ts.RevokeSession(context.Background(), ts.claims(t, member), ts.claims(t, other).SessionID)
+	if err == nil || err.Error() != "session not found" {
+		t.Fatalf("revoke another user's session = %v, want session not found", err)
+	}
+	ts.requireSessionActive(t, other)
+}
+
+func TestRevokeOtherSessionsKeepsCurrent(t *testing.T) {
+	ts := newTestService(t)
+	ts.createUser(t, "member@example.com", "Password123!", true)
+	ts.createUser(t, "other@example.com", "Password123!", true)
+	laptop := ts.login(t, "member@example.com", "Password123!")
+	phone := ts.login(t, "member@example.com", "Password123!")
+	tablet := ts.login(t, "member@example.com", "Password123!")
+	other := ts.login(t, "other@example.com", "Password123!")
+
+	if err := ts.RevokeOtherSessions(context.Background(), ts.claims(t, laptop)); err != nil {
+		t.Fatalf("revoke other sessions: %v", err)
+	}
+
+	ts.requireSessionEnded(t, phone)
+	ts.requireSessionEnded(t, tablet)
+	ts.requireSessionActive(t, other)
+
+	// 요청한 기기의 세션은 남아 있고 목록에도 그 세션만 보인다
+	sessions, err := ts.ListSessions(context.Background(), ts.claims(t, laptop))
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Errorf("sessions after revoking others = %+v, want only the current one", sessions)
+	}
+	ts.requireSessionActive(t, laptop)
+}
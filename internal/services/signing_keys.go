@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/services/keys"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// 교체 예정 키를 JWKS에 미리 공개하는 시간 (다운스트림 JWKS 캐시 대비)
+const keyPublishLead = time.Hour
+
+// TokenKeys 토큰 서명/검증 키 제공자 (utils.KeySet 또는 keys.KeyRing)
+type TokenKeys interface {
+	utils.KeyResolver
+	SigningKey() *utils.SigningKey
+	JWKS() *utils.JWKS
+}
+
+// NewTokenKeys 설정에 맞는 키 제공자 생성
+// store가 nil이면 설정 파일의 고정 키를, 아니면 주기적으로 교체되는 KeyRing을 사용한다.
+func NewTokenKeys(ctx context.Context, cfg *config.Config, store keys.Store) (TokenKeys, error) {
+	if store == nil {
+		return LoadKeySet(cfg)
+	}
+
+	alg := cfg.JWTAlgorithm
+	if alg == "" {
+		alg = utils.AlgHS256
+	}
+
+	var legacy *utils.SigningKey
+	if cfg.JWTSecret != "" {
+		legacy = utils.NewHMACSigningKey("", []byte(cfg.JWTSecret))
+	}
+
+	ring, err := keys.NewKeyRing(ctx, store, keys.Options{
+		Algorithm:        alg,
+		RotationInterval: time.Duration(cfg.JWTKeyRotation) * time.Hour,
+		VerifyWindow:     maxTokenLifetime(cfg),
+		PublishLead:      keyPublishLead,
+		Legacy:           legacy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go ring.Run(context.Background())
+	return ring, nil
+}
+
+// maxTokenLifetime 서명하는 JWT 중 가장 긴 수명 (은퇴한 키를 검증에 남겨 둘 기간)
+func maxTokenLifetime(cfg *config.Config) time.Duration {
+	lifetime := cfg.AccessTokenLifetime()
+	if mfaChallengeTTL > lifetime {
+		lifetime = mfaChallengeTTL
+	}
+	return lifetime
+}
+
+// LoadKeySet 설정에 맞는 JWT 서명 키 집합 로드
+// JWT_SECRET이 있으면 kid 없는 기존 HS256 토큰도 계속 검증한다.
+func LoadKeySet(cfg *config.Config) (*utils.KeySet, error) {
+	var legacy *utils.SigningKey
+	if cfg.JWTSecret != "" {
+		legacy = utils.NewHMACSigningKey("", []byte(cfg.JWTSecret))
+	}
+
+	alg := cfg.JWTAlgorithm
+	if alg == "" {
+		alg = utils.AlgHS256
+	}
+
+	if alg == utils.AlgHS256 {
+		if legacy == nil {
+			return nil, errors.New("JWT_SECRET is required for HS256")
+		}
+		return utils.NewKeySet(legacy), nil
+	}
+
+	key, err := loadOrCreatePrivateKey(alg, cfg.JWTPrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.JWTKeyID != "" {
+		key.ID = cfg.JWTKeyID
+	}
+
+	if legacy != nil {
+		return utils.NewKeySet(key, legacy), nil
+	}
+	return utils.NewKeySet(key), nil
+}
+
+// loadOrCreatePrivateKey PEM 파일에서 개인키를 읽고, 파일이 없으면 새로 생성해서 저장
+func loadOrCreatePrivateKey(alg, path string) (*utils.SigningKey, error) {
+	if path == "" {
+		log.Printf("JWT_PRIVATE_KEY_FILE is not set, using an ephemeral %s key", alg)
+		return utils.GenerateSigningKey(alg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return utils.ParseSigningKeyPEM(alg, data)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := utils.GenerateSigningKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	data, err = utils.MarshalSigningKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	log.Printf("generated new %s signing key at %s", alg, path)
+	return key, nil
+}
@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+// WebAuthn 절차 종류 (AuthFlow.Kind)
+const (
+	flowWebAuthnRegistration = "webauthn_registration"
+	flowWebAuthnLogin        = "webauthn_login"
+	flowWebAuthnMFA          = "webauthn_mfa"
+)
+
+// 등록/인증 절차를 마쳐야 하는 시간
+const webAuthnCeremonyTTL = 5 * time.Minute
+
+// NewWebAuthn 설정으로 WebAuthn Relying Party 생성
+func NewWebAuthn(cfg *config.Config) (*webauthn.WebAuthn, error) {
+	return webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthnRPID,
+		RPDisplayName: cfg.WebAuthnRPName,
+		RPOrigins:     cfg.WebAuthnRPOrigins,
+	})
+}
+
+// webAuthnUser webauthn.User 구현
+type webAuthnUser struct {
+	user        *models.User
+	credentials []*models.WebAuthnCredential
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return u.user.ID[:]
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, len(u.credentials))
+	for i, c := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, len(c.Transports))
+		for j, t := range c.Transports {
+			transports[j] = protocol.AuthenticatorTransport(t)
+		}
+
+		credentials[i] = webauthn.Credential{
+			ID:              c.CredentialID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				UserVerified:   c.UserVerified,
+				BackupEligible: c.BackupEligible,
+				BackupState:    c.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:       c.AAGUID,
+				SignCount:    c.SignCount,
+				CloneWarning: c.CloneWarning,
+			},
+		}
+	}
+	return credentials
+}
+
+// BeginWebAuthnRegistration 패스키 등록 시작
+func (s *AuthService) BeginWebAuthnRegistration(ctx context.Context, claims *utils.JWTClaim) (*models.WebAuthnBeginResponse, error) {
+	user, err := s.loadWebAuthnUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// 이미 등록된 자격 증명은 다시 등록하지 않도록 제외
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(user.credentials))
+	for _, credential := range user.WebAuthnCredentials() {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	creation, session, err := s.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremonyID, err := s.saveWebAuthnSession(ctx, flowWebAuthnRegistration, user.user.ID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebAuthnBeginResponse{
+		CeremonyID: ceremonyID,
+		Options:    creation,
+	}, nil
+}
+
+// FinishWebAuthnRegistration 패스키 등록 완료
+func (s *AuthService) FinishWebAuthnRegistration(ctx context.Context, claims *utils.JWTClaim, req *models.WebAuthnRegisterFinishRequest) (*models.WebAuthnCredential, error) {
+	user, err := s.loadWebAuthnUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.consumeWebAuthnSession(ctx, req.CeremonyID, flowWebAuthnRegistration, user.user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(req.Credential)
+	if err != nil {
+		return nil, errors.New("invalid credential")
+	}
+
+	credential, err := s.webAuthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		return nil, errors.New("credential verification failed")
+	}
+
+	transports := make([]string, len(credential.Transport))
+	for i, t := range credential.Transport {
+		transports[i] = string(t)
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "Passkey"
+	}
+
+	record := &models.WebAuthnCredential{
+		UserID:          user.user.ID,
+		Name:            name,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      transports,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		UserVerified:    credential.Flags.UserVerified,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}
+	if err := s.credentialRepo.CreateCredential(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ListWebAuthnCredentials 등록된 패스키 목록
+func (s *AuthService) ListWebAuthnCredentials(ctx context.Context, claims *utils.JWTClaim) ([]*models.WebAuthnCredential, error) {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return s.credentialRepo.ListCredentialsByUser(ctx, userID)
+}
+
+// DeleteWebAuthnCredential 패스키 삭제
+func (s *AuthService) DeleteWebAuthnCredential(ctx context.Context, claims *utils.JWTClaim, credentialID string) error {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return errors.New("invalid token")
+	}
+	id, err := primitive.ObjectIDFromHex(credentialID)
+	if err != nil {
+		return errors.New("credential not found")
+	}
+	return s.credentialRepo.DeleteCredential(ctx, userID, id)
+}
+
+// BeginWebAuthnLogin 패스키로 비밀번호 없이 로그인 시작 (discoverable credential)
+// 이메일을 받지 않으므로 계정 존재 여부가 드러나지 않는다.
+func (s *AuthService) BeginWebAuthnLogin(ctx context.Context) (*models.WebAuthnBeginResponse, error) {
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin(
+		webauthn.WithUserVerification(protocol.VerificationRequired),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremonyID, err := s.saveWebAuthnSession(ctx, flowWebAuthnLogin, primitive.NilObjectID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebAuthnBeginResponse{
+		CeremonyID: ceremonyID,
+		Options:    assertion,
+	}, nil
+}
+
+// FinishWebAuthnLogin 패스키 로그인 완료
+// 사용자 확인(UV)을 요구하므로 패스키 자체가 다단계 인증 역할을 해 추가 챌린지는 없다.
+func (s *AuthService) FinishWebAuthnLogin(ctx context.Context, req *models.WebAuthnLoginFinishRequest) (*models.LoginResponse, error) {
+	session, err := s.consumeWebAuthnSession(ctx, req.CeremonyID, flowWebAuthnLogin, primitive.NilObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(req.Credential)
+	if err != nil {
+		return nil, errors.New("invalid credential")
+	}
+
+	var loaded *webAuthnUser
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		if len(userHandle) != len(primitive.ObjectID{}) {
+			return nil, errors.New("unknown user")
+		}
+		var userID primitive.ObjectID
+		copy(userID[:], userHandle)
+
+		user, err := s.repo.FindUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errors.New("unknown user")
+		}
+		credentials, err := s.credentialRepo.ListCredentialsByUser(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		loaded = &webAuthnUser{user: user, credentials: credentials}
+		return loaded, nil
+	}
+
+	credential, err := s.webAuthn.ValidateDiscoverableLogin(handler, *session, parsed)
+	if err != nil {
+		return nil, errors.New("passkey verification failed")
+	}
+	if err := s.recordWebAuthnUsage(ctx, loaded, credential); err != nil {
+		return nil, err
+	}
+	// 다른 로그인 방식과 같이 잠긴 계정은 같은 응답으로 거부
+	if loaded.user.IsLocked(time.Now()) {
+		return nil, errors.New("passkey verification failed")
+	}
+
+	return s.finishLogin(ctx, loaded.user)
+}
+
+// BeginWebAuthnMFA 2단계 인증으로 패스키 사용 시작
+func (s *AuthService) BeginWebAuthnMFA(ctx context.Context, req *models.WebAuthnMFABeginRequest) (*models.WebAuthnBeginResponse, error) {
+	user, _, err := s.userFromMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.credentialRepo.ListCredentialsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) == 0 {
+		return nil, errors.New("no passkeys registered")
+	}
+
+	assertion, session, err := s.webAuthn.BeginLogin(&webAuthnUser{user: user, credentials: credentials})
+	if err != nil {
+		return nil, err
+	}
+
+	ceremonyID, err := s.saveWebAuthnSession(ctx, flowWebAuthnMFA, user.ID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebAuthnBeginResponse{
+		CeremonyID: ceremonyID,
+		Options:    assertion,
+	}, nil
+}
+
+// FinishWebAuthnMFA 패스키로 2단계 인증 완료 후 토큰 발급
+func (s *AuthService) FinishWebAuthnMFA(ctx context.Context, req *models.WebAuthnMFAFinishRequest) (*models.LoginResponse, error) {
+	user, challengeID, err := s.userFromMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.consumeWebAuthnSession(ctx, req.CeremonyID, flowWebAuthnMFA, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(req.Credential)
+	if err != nil {
+		return nil, errors.New("invalid credential")
+	}
+
+	credentials, err := s.credentialRepo.ListCredentialsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	waUser := &webAuthnUser{user: user, credentials: credentials}
+
+	credential, err := s.webAuthn.ValidateLogin(waUser, *session, parsed)
+	if err != nil {
+		return nil, errors.New("passkey verification failed")
+	}
+	if err := s.recordWebAuthnUsage(ctx, waUser, credential); err != nil {
+		return nil, err
+	}
+	if err := s.consumeMFAChallenge(ctx, challengeID); err != nil {
+		return nil, err
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+// loadWebAuthnUser 토큰의 사용자와 등록된 자격 증명 조회
+func (s *AuthService) loadWebAuthnUser(ctx context.Context, claims *utils.JWTClaim) (*webAuthnUser, error) {
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := s.credentialRepo.ListCredentialsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &webAuthnUser{user: user, credentials: credentials}, nil
+}
+
+// recordWebAuthnUsage 서명 카운터 갱신, 복제 의심 자격 증명은 거부
+func (s *AuthService) recordWebAuthnUsage(ctx context.Context, user *webAuthnUser, credential *webauthn.Credential) error {
+	for _, record := range user.credentials {
+		if string(record.CredentialID) != string(credential.ID) {
+			continue
+		}
+
+		record.SignCount = credential.Authenticator.SignCount
+		record.CloneWarning = credential.Authenticator.CloneWarning
+		record.BackupState = credential.Flags.BackupState
+		if err := s.credentialRepo.UpdateCredentialUsage(ctx, record); err != nil {
+			return err
+		}
+
+		// 서명 카운터가 줄었거나 그대로면 복제된 인증기일 수 있음
+		if credential.Authenticator.CloneWarning {
+			return errors.New("passkey verification failed")
+		}
+		return nil
+	}
+	return errors.New("passkey verification failed")
+}
+
+// saveWebAuthnSession 챌린지 세션 저장 후 절차 ID 반환
+func (s *AuthService) saveWebAuthnSession(ctx context.Context, kind string, userID primitive.ObjectID, session *webauthn.SessionData) (string, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+
+	id := utils.GenerateRandomToken(32)
+	if err := s.flowRepo.SaveFlow(ctx, &models.AuthFlow{
+		ID:        id,
+		Kind:      kind,
+		UserID:    userID,
+		Data:      data,
+		ExpiresAt: time.Now().Add(webAuthnCeremonyTTL),
+	}); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// consumeWebAuthnSession 챌린지 세션을 꺼내면서 삭제 (한 번만 사용 가능)
+func (s *AuthService) consumeWebAuthnSession(ctx context.Context, id, kind string, userID primitive.ObjectID) (*webauthn.SessionData, error) {
+	if id == "" {
+		return nil, errors.New("invalid or expired ceremony")
+	}
+
+	flow, err := s.flowRepo.ConsumeFlow(ctx, id, kind)
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil || flow.UserID != userID {
+		return nil, errors.New("invalid or expired ceremony")
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal(flow.Data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
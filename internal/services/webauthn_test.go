@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/config"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/internal/models"
+	"github.com/kihyun1998/prisma-market/prisma-auth-service/pkg/utils"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:3000"
+)
+
+// authenticator flags
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+// softAuthenticator "none" 증명을 쓰는 ES256 소프트웨어 인증기
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	return &softAuthenticator{key: key, credentialID: id}
+}
+
+// authData 인증기 데이터 (attested가 있으면 공개 키 포함)
+func (a *softAuthenticator) authData(t *testing.T, flags byte, attested bool) []byte {
+	t.Helper()
+
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if !attested {
+		return data
+	}
+
+	publicKey, err := webauthncbor.Marshal(map[int]interface{}{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatalf("encode public key: %v", err)
+	}
+
+	data = append(data, make([]byte, 16)...) // AAGUID
+	data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+	data = append(data, a.credentialID...)
+	return append(data, publicKey...)
+}
+
+// clientData 브라우저가 만드는 clientDataJSON
+func clientData(t *testing.T, ceremony string, challenge []byte) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    testOrigin,
+	})
+	if err != nil {
+		t.Fatalf("encode client data: %v", err)
+	}
+	return data
+}
+
+// register navigator.credentials.create() 응답
+func (a *softAuthenticator) register(t *testing.T, options interface{}) json.RawMessage {
+	t.Helper()
+
+	creation, ok := options.(*protocol.CredentialCreation)
+	if !ok {
+		t.Fatalf("registration options have type %T", options)
+	}
+	a.userHandle = creation.Response.User.ID.(protocol.URLEncodedBase64)
+
+	attestation, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(t, flagUserPresent|flagUserVerified|flagAttestedData, true),
+	})
+	if err != nil {
+		t.Fatalf("encode attestation: %v", err)
+	}
+
+	return a.credential(t, map[string]string{
+		"clientDataJSON":    encode(clientData(t, "webauthn.create", creation.Response.Challenge)),
+		"attestationObject": encode(attestation),
+	})
+}
+
+// assert navigator.credentials.get() 응답 (서명 카운터는 signCount 그대로 사용)
+func (a *softAuthenticator) assert(t *testing.T, options interface{}) json.RawMessage {
+	t.Helper()
+
+	assertion, ok := options.(*protocol.CredentialAssertion)
+	if !ok {
+		t.Fatalf("assertion options have type %T", options)
+	}
+
+	authData := a.authData(t, flagUserPresent|flagUserVerified, false)
+	client := clientData(t, "webauthn.get", assertion.Response.Challenge)
+	clientHash := sha256.Sum256(client)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatalf("sign assertion: %v", err)
+	}
+
+	return a.credential(t, map[string]string{
+		"clientDataJSON":    encode(client),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.userHandle),
+	})
+}
+
+// credential PublicKeyCredential JSON
+func (a *softAuthenticator) credential(t *testing.T, response map[string]string) json.RawMessage {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]interface{}{
+		"id":       encode(a.credentialID),
+		"rawId":    encode(a.credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	if err != nil {
+		t.Fatalf("encode credential: %v", err)
+	}
+	return data
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// newWebAuthnTestService WebAuthn을 사용하는 테스트용 AuthService와 패스키를 등록한 사용자
+func newWebAuthnTestService(t *testing.T) (*testService, *models.User, *softAuthenticator) {
+	t.Helper()
+
+	ts := newTestService(t, func(c *config.Config) {
+		c.WebAuthnRPID = testRPID
+		c.WebAuthnRPName = "Prisma Market"
+		c.WebAuthnRPOrigins = []string{testOrigin}
+	})
+	webAuthn, err := NewWebAuthn(ts.config)
+	if err != nil {
+		t.Fatalf("new webauthn: %v", err)
+	}
+	ts.webAuthn = webAuthn
+
+	user := ts.createUser(t, "passkey@example.com", "Password123!", true)
+	claims := &utils.JWTClaim{UserID: user.ID.Hex()}
+	authenticator := newSoftAuthenticator(t)
+
+	begin, err := ts.BeginWebAuthnRegistration(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("begin registration: %v", err)
+	}
+	_, err = ts.FinishWebAuthnRegistration(context.Background(), claims, &models.WebAuthnRegisterFinishRequest{
+		CeremonyID: begin.CeremonyID,
+		Name:       "Test key",
+		Credential: authenticator.register(t, begin.Options),
+	})
+	if err != nil {
+		t.Fatalf("finish registration: %v", err)
+	}
+	return ts, user, authenticator
+}
+
+// passkeyLogin 비밀번호 없는 패스키 로그인 한 번
+func (ts *testService) passkeyLogin(t *testing.T, authenticator *softAuthenticator) (*models.LoginResponse, error) {
+	t.Helper()
+
+	begin, err := ts.BeginWebAuthnLogin(context.Background())
+	if err != nil {
+		t.Fatalf("begin login: %v", err)
+	}
+	return ts.FinishWebAuthnLogin(context.Background(), &models.WebAuthnLoginFinishRequest{
+		CeremonyID: begin.CeremonyID,
+		Credential: authenticator.assert(t, begin.Options),
+	})
+}
+
+// storedCredential 사용자의 유일한 패스키
+func (ts *testService) storedCredential(t *testing.T, user *models.User) *models.WebAuthnCredential {
+	t.Helper()
+
+	credentials, err := ts.stores.WebAuthnCredentials.ListCredentialsByUser(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("list credentials: %v", err)
+	}
+	if len(credentials) != 1 {
+		t.Fatalf("user has %d credentials, want 1", len(credentials))
+	}
+	return credentials[0]
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	ts, user, authenticator := newWebAuthnTestService(t)
+
+	credential := ts.storedCredential(t, user)
+	if string(credential.CredentialID) != string(authenticator.credentialID) {
+		t.Error("stored credential ID does not match the authenticator")
+	}
+	if credential.Name != "Test key" || credential.AttestationType != "none" || !credential.UserVerified {
+		t.Errorf("stored credential = %+v", credential)
+	}
+
+	// 같은 인증기를 다시 등록하면 제외 목록에 들어 있다
+	begin, err := ts.BeginWebAuthnRegistration(context.Background(), &utils.JWTClaim{UserID: user.ID.Hex()})
+	if err != nil {
+		t.Fatalf("begin registration: %v", err)
+	}
+	exclusions := begin.Options.(*protocol.CredentialCreation).Response.CredentialExcludeList
+	if len(exclusions) != 1 || string(exclusions[0].CredentialID) != string(authenticator.credentialID) {
+		t.Errorf("exclude list = %v, want the registered credential", exclusions)
+	}
+}
+
+func TestWebAuthnRegistrationCeremonyIsSingleUse(t *testing.T) {
+	ts, user, _ := newWebAuthnTestService(t)
+	claims := &utils.JWTClaim{UserID: user.ID.Hex()}
+	authenticator := newSoftAuthenticator(t)
+
+	begin, err := ts.BeginWebAuthnRegistration(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("begin registration: %v", err)
+	}
+	req := &models.WebAuthnRegisterFinishRequest{CeremonyID: begin.CeremonyID, Credential: authenticator.register(t, begin.Options)}
+	if _, err := ts.FinishWebAuthnRegistration(context.Background(), claims, req); err != nil {
+		t.Fatalf("finish registration: %v", err)
+	}
+	if _, err := ts.FinishWebAuthnRegistration(context.Background(), claims, req); err == nil {
+		t.Error("registration ceremony was accepted twice")
+	}
+}
+
+func TestWebAuthnLogin(t *testing.T) {
+	ts, user, authenticator := newWebAuthnTestService(t)
+
+	authenticator.signCount = 1
+	resp, err := ts.passkeyLogin(t, authenticator)
+	if err != nil {
+		t.Fatalf("passkey login: %v", err)
+	}
+	claims, err := ts.ValidateToken(context.Background(), resp.Token)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	if claims.UserID != user.ID.Hex() {
+		t.Errorf("token user = %s, want %s", claims.UserID, user.ID.Hex())
+	}
+
+	credential := ts.storedCredential(t, user)
+	if credential.SignCount != 1 || credential.CloneWarning || credential.LastUsedAt == nil {
+		t.Errorf("credential after login: sign_count=%d clone_warning=%v last_used=%v", credential.SignCount, credential.CloneWarning, credential.LastUsedAt)
+	}
+}
+
+func TestWebAuthnLoginRejectsWrongKey(t *testing.T) {
+	ts, _, authenticator := newWebAuthnTestService(t)
+
+	// 같은 자격 증명 ID를 쓰지만 다른 키로 서명
+	impostor := newSoftAuthenticator(t)
+	impostor.credentialID = authenticator.credentialID
+	impostor.userHandle = authenticator.userHandle
+	impostor.signCount = 1
+	if _, err := ts.passkeyLogin(t, impostor); err == nil {
+		t.Fatal("assertion signed with another key was accepted")
+	}
+}
+
+func TestWebAuthnLoginSignCountRegression(t *testing.T) {
+	ts, user, authenticator := newWebAuthnTestService(t)
+
+	authenticator.signCount = 5
+	if _, err := ts.passkeyLogin(t, authenticator); err != nil {
+		t.Fatalf("passkey login: %v", err)
+	}
+
+	// 카운터가 줄어들면 복제된 인증기로 보고 거부
+	authenticator.signCount = 3
+	if _, err := ts.passkeyLogin(t, authenticator); err == nil {
+		t.Fatal("assertion with a lower sign count was accepted")
+	}
+	credential := ts.storedCredential(t, user)
+	if !credential.CloneWarning {
+		t.Error("clone warning was not recorded")
+	}
+	if credential.SignCount != 5 {
+		t.Errorf("sign count = %d after regression, want 5", credential.SignCount)
+	}
+
+	// 복제 경고가 남은 자격 증명은 카운터가 다시 올라가도 사용할 수 없다
+	authenticator.signCount = 10
+	if _, err := ts.passkeyLogin(t, authenticator); err == nil {
+		t.Error("credential with a clone warning was accepted")
+	}
+}
+
+func TestWebAuthnLoginRepeatedSignCount(t *testing.T) {
+	ts, user, authenticator := newWebAuthnTestService(t)
+
+	authenticator.signCount = 2
+	if _, err := ts.passkeyLogin(t, authenticator); err != nil {
+		t.Fatalf("passkey login: %v", err)
+	}
+	if _, err := ts.passkeyLogin(t, authenticator); err == nil {
+		t.Fatal("assertion repeating the sign count was accepted")
+	}
+	if !ts.storedCredential(t, user).CloneWarning {
+		t.Error("clone warning was not recorded")
+	}
+}
+
+func TestWebAuthnLoginZeroSignCount(t *testing.T) {
+	ts, _, authenticator := newWebAuthnTestService(t)
+
+	// 카운터를 쓰지 않는 인증기(항상 0)는 복제 경고 없이 계속 사용할 수 있다
+	for i := 0; i < 2; i++ {
+		if _, err := ts.passkeyLogin(t, authenticator); err != nil {
+			t.Fatalf("passkey login %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestWebAuthnLoginRejectsLockedAccount(t *testing.T) {
+	ts, user, authenticator := newWebAuthnTestService(t)
+
+	if err := ts.stores.Users.LockUser(context.Background(), user.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("lock user: %v", err)
+	}
+	authenticator.signCount = 1
+	if _, err := ts.passkeyLogin(t, authenticator); err == nil {
+		t.Fatal("passkey login succeeded for a locked account")
+	}
+
+	if err := ts.stores.Users.ResetFailedLogins(context.Background(), user.ID); err != nil {
+		t.Fatalf("unlock user: %v", err)
+	}
+	authenticator.signCount = 2
+	if _, err := ts.passkeyLogin(t, authenticator); err != nil {
+		t.Fatalf("passkey login after unlock: %v", err)
+	}
+}
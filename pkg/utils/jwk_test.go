@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJWKThumbprint(t *testing.T) {
+	tests := []struct {
+		name string
+		jwk  JWK
+		want string
+	}{
+		{
+			// RFC 7638 3.1
+			name: "RSA",
+			jwk: JWK{
+				Kty: "RSA",
+				N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+				E:   "AQAB",
+				Kid: "2011-04-29",
+				Alg: "RS256",
+			},
+			want: "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs",
+		},
+		{
+			// RFC 8037 A.3
+			name: "Ed25519",
+			jwk:  JWK{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+			want: "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.jwk.Thumbprint(); got != tt.want {
+				t.Errorf("Thumbprint = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	keys := []*SigningKey{
+		generateKey(t, AlgRS256),
+		generateKey(t, AlgES256),
+		generateKey(t, AlgEdDSA),
+		NewHMACSigningKey("hmac", []byte("0123456789abcdef0123456789abcdef")),
+	}
+
+	data, err := json.Marshal(NewJWKS(keys...))
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+	var published struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &published); err != nil {
+		t.Fatalf("unmarshal JWKS: %v", err)
+	}
+
+	// 대칭키는 공개하지 않는다
+	if len(published.Keys) != 3 {
+		t.Fatalf("JWKS has %d keys, want 3 (the HMAC key must not be published): %s", len(published.Keys), data)
+	}
+
+	wantMembers := map[string][]string{
+		AlgRS256: {"kty", "kid", "use", "alg", "n", "e"},
+		AlgES256: {"kty", "kid", "use", "alg", "crv", "x", "y"},
+		AlgEdDSA: {"kty", "kid", "use", "alg", "crv", "x"},
+	}
+	for i, member := range published.Keys {
+		key := keys[i]
+		t.Run(key.Algorithm, func(t *testing.T) {
+			if len(member) != len(wantMembers[key.Algorithm]) {
+				t.Errorf("JWK members = %v, want only %v", member, wantMembers[key.Algorithm])
+			}
+			for _, name := range wantMembers[key.Algorithm] {
+				if member[name] == "" {
+					t.Errorf("JWK is missing %q: %v", name, member)
+				}
+			}
+			if member["use"] != "sig" || member["alg"] != key.Algorithm {
+				t.Errorf("use = %q, alg = %q", member["use"], member["alg"])
+			}
+
+			// kid는 공개키의 RFC 7638 thumbprint
+			jwk := JWK{Kty: member["kty"], N: member["n"], E: member["e"], Crv: member["crv"], X: member["x"], Y: member["y"]}
+			if member["kid"] != key.ID || jwk.Thumbprint() != key.ID {
+				t.Errorf("kid = %s, thumbprint = %s, want %s", member["kid"], jwk.Thumbprint(), key.ID)
+			}
+
+			// 공개된 값으로 원래 공개키를 복원할 수 있다
+			pub, err := jwk.PublicKey()
+			if err != nil {
+				t.Fatalf("JWK.PublicKey: %v", err)
+			}
+			if !publicKeyEqual(key, pub) {
+				t.Error("public key from the JWK does not match the signing key")
+			}
+		})
+	}
+}
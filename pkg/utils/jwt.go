@@ -1,46 +1,160 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// 토큰 용도 (token_use 클레임)
+const (
+	TokenUseAccess  = "access"
+	TokenUseMFA     = "mfa"     // 비밀번호 확인 후 2단계 인증 대기 중인 토큰
+	TokenUseID      = "id"      // OpenID Connect ID 토큰 (API 호출에는 사용할 수 없음)
+	TokenUseService = "service" // client_credentials로 발급된 서비스 토큰 (사용자 없음)
+)
+
 type JWTClaim struct {
-	UserID string
-	Email  string
+	UserID        string
+	Email         string
+	TokenVersion  int    `json:"ver"`                      // 사용자 보안 스탬프, 비밀번호 변경 등으로 올라가면 기존 토큰 무효
+	TokenUse      string `json:"token_use,omitempty"`      // 비어 있으면 기존 액세스 토큰
+	ClientID      string `json:"client_id,omitempty"`      // OAuth 클라이언트에 위임된 토큰 (비어 있으면 자체 로그인)
+	Scope         string `json:"scope,omitempty"`          // 위임된 scope (공백으로 구분), 이메일 미인증 제한 토큰은 unverified
+	SessionID     string `json:"sid,omitempty"`            // 로그인 세션 (리프레시 토큰 패밀리 ID)
+	EmailVerified *bool  `json:"email_verified,omitempty"` // 발급 당시 이메일 인증 여부
+
+	// 자체 로그인 토큰에만 포함 (바뀌면 토큰 버전이 올라가 이전 클레임의 토큰은 무효)
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IDTokenClaims OpenID Connect ID 토큰 클레임 (iss, sub, aud는 RegisteredClaims)
+type IDTokenClaims struct {
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"` // email scope가 있을 때만 포함
+	Nonce         string `json:"nonce,omitempty"`
+	TokenUse      string `json:"token_use"`
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT JWT 토큰 생성
-func GenerateJWT(userID, email, secret string, expiresHours int) (string, error) {
-	claims := &JWTClaim{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * time.Duration(expiresHours))),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
+// ClaimsValidator 서명 검증 이후 추가 검증 (폐기 여부 등)
+type ClaimsValidator interface {
+	ValidateClaims(ctx context.Context, claims *JWTClaim) error
+}
+
+// KeyResolver kid로 검증 키 조회
+type KeyResolver interface {
+	VerificationKey(kid string) (*SigningKey, error)
+}
+
+// KeySet 서명 키 하나와 검증 전용 키들의 집합
+type KeySet struct {
+	signing *SigningKey
+	keys    map[string]*SigningKey
+}
+
+// NewKeySet KeySet 생성자
+func NewKeySet(signing *SigningKey, verifyOnly ...*SigningKey) *KeySet {
+	keys := map[string]*SigningKey{signing.ID: signing}
+	for _, key := range verifyOnly {
+		if _, exists := keys[key.ID]; !exists {
+			keys[key.ID] = key
+		}
 	}
+	return &KeySet{signing: signing, keys: keys}
+}
+
+// SigningKey 새 토큰 서명에 사용할 키
+func (s *KeySet) SigningKey() *SigningKey {
+	return s.signing
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+// VerificationKey kid에 해당하는 검증 키 (kid가 없는 기존 토큰은 "" 키로 조회)
+func (s *KeySet) VerificationKey(kid string) (*SigningKey, error) {
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+	return key, nil
+}
+
+// JWKS 공개 가능한 키 목록
+func (s *KeySet) JWKS() *JWKS {
+	keys := make([]*SigningKey, 0, len(s.keys))
+	keys = append(keys, s.signing)
+	for _, key := range s.keys {
+		if key != s.signing {
+			keys = append(keys, key)
+		}
+	}
+	return NewJWKS(keys...)
+}
+
+// GenerateJWT JWT 토큰 생성 (jti, exp, iat, nbf는 여기서 채운다)
+func GenerateJWT(claims *JWTClaim, key *SigningKey, expiresIn time.Duration) (string, error) {
+	now := time.Now()
+	claims.ID = GenerateRandomToken(16)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(expiresIn))
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+
+	return signToken(claims, key)
+}
+
+// GenerateIDToken OpenID Connect ID 토큰 생성 (exp, iat는 여기서 채운다)
+func GenerateIDToken(claims *IDTokenClaims, key *SigningKey, expiresIn time.Duration) (string, error) {
+	now := time.Now()
+	claims.TokenUse = TokenUseID
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(expiresIn))
+	claims.IssuedAt = jwt.NewNumericDate(now)
+
+	return signToken(claims, key)
+}
+
+// signToken 키의 알고리즘으로 서명 (kid 헤더 포함)
+func signToken(claims jwt.Claims, key *SigningKey) (string, error) {
+	token := jwt.NewWithClaims(key.Method(), claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.Key)
 }
 
 // ValidateJWT JWT 토큰 검증
-func ValidateJWT(tokenString, secret string) (*JWTClaim, error) {
+// 서명과 만료를 확인한 뒤 validators를 순서대로 실행한다.
+func ValidateJWT(ctx context.Context, tokenString string, keys KeyResolver, validators ...ClaimsValidator) (*JWTClaim, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaim{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+		kid, _ := token.Header["kid"].(string)
+		key, err := keys.VerificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+
+		// 알고리즘 혼동 공격 방지: 키에 지정된 알고리즘만 허용
+		if token.Method.Alg() != key.Algorithm {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return key.VerifyKey(), nil
+	}, jwt.WithValidMethods(SupportedAlgorithms))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaim); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*JWTClaim)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	for _, validator := range validators {
+		if err := validator.ValidateClaims(ctx, claims); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil, jwt.ErrSignatureInvalid
+	return claims, nil
 }
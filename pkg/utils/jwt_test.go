@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signWith 임의의 방식과 헤더로 서명한 토큰 (공격자가 만든 토큰 흉내)
+func signWith(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+
+	claims := &JWTClaim{UserID: "attacker", RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestValidateJWT(t *testing.T) {
+	for _, alg := range SupportedAlgorithms {
+		t.Run(alg, func(t *testing.T) {
+			key := generateKey(t, alg)
+			token, err := GenerateJWT(&JWTClaim{UserID: "user-1", TokenVersion: 3}, key, time.Minute)
+			if err != nil {
+				t.Fatalf("GenerateJWT: %v", err)
+			}
+
+			claims, err := ValidateJWT(context.Background(), token, NewKeySet(key))
+			if err != nil {
+				t.Fatalf("ValidateJWT: %v", err)
+			}
+			if claims.UserID != "user-1" || claims.TokenVersion != 3 || claims.ID == "" {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestValidateJWTRejectsAlgorithmConfusion(t *testing.T) {
+	rsaKey := generateKey(t, AlgRS256)
+	keys := NewKeySet(rsaKey)
+
+	// 공개된 RSA 공개키를 HMAC 비밀값으로 써서 서명한 토큰
+	der, err := x509.MarshalPKIXPublicKey(rsaKey.PublicKey())
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	tests := map[string]string{
+		"HS256 with the PEM public key": signWith(t, jwt.SigningMethodHS256, rsaKey.ID, publicPEM),
+		"HS256 with the DER public key": signWith(t, jwt.SigningMethodHS256, rsaKey.ID, der),
+		"none":                          signWith(t, jwt.SigningMethodNone, rsaKey.ID, jwt.UnsafeAllowNoneSignatureType),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if claims, err := ValidateJWT(context.Background(), token, keys); err == nil {
+				t.Errorf("ValidateJWT accepted the token: %+v", claims)
+			}
+		})
+	}
+}
+
+func TestValidateJWTRejectsUnknownKid(t *testing.T) {
+	key := generateKey(t, AlgES256)
+	other := generateKey(t, AlgES256)
+	keys := NewKeySet(key)
+
+	// kid 헤더가 없는 토큰은 "" 키가 있을 때만 검증한다
+	withoutKid := &SigningKey{Algorithm: key.Algorithm, Key: key.Key}
+
+	tests := map[string]*SigningKey{
+		"unknown kid": other,
+		"missing kid": withoutKid,
+		"forged kid":  {ID: key.ID, Algorithm: other.Algorithm, Key: other.Key},
+	}
+	for name, signer := range tests {
+		t.Run(name, func(t *testing.T) {
+			token, err := GenerateJWT(&JWTClaim{UserID: "user-1"}, signer, time.Minute)
+			if err != nil {
+				t.Fatalf("GenerateJWT: %v", err)
+			}
+			if claims, err := ValidateJWT(context.Background(), token, keys); err == nil {
+				t.Errorf("ValidateJWT accepted the token: %+v", claims)
+			}
+		})
+	}
+
+	// 기존 HS256 설정: kid 없이 발급된 토큰은 "" 키로 검증
+	legacy := NewHMACSigningKey("", []byte("0123456789abcdef0123456789abcdef"))
+	token, err := GenerateJWT(&JWTClaim{UserID: "user-1"}, legacy, time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+	if _, err := ValidateJWT(context.Background(), token, NewKeySet(key, legacy)); err != nil {
+		t.Errorf("token without kid signed by the legacy key: %v", err)
+	}
+}
+
+// rejectAll 모든 클레임을 거부하는 검증기
+type rejectAll struct{ called bool }
+
+func (r *rejectAll) ValidateClaims(ctx context.Context, claims *JWTClaim) error {
+	r.called = true
+	return errors.New("rejected")
+}
+
+func TestValidateJWTChecksClaims(t *testing.T) {
+	key := generateKey(t, AlgEdDSA)
+	keys := NewKeySet(key)
+
+	expired, err := GenerateJWT(&JWTClaim{UserID: "user-1"}, key, -time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+	if _, err := ValidateJWT(context.Background(), expired, keys); !errors.Is(err, jwt.ErrTokenExpired) {
+		t.Errorf("expired token = %v, want %v", err, jwt.ErrTokenExpired)
+	}
+
+	token, err := GenerateJWT(&JWTClaim{UserID: "user-1"}, key, time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+
+	// 서명 뒤에 페이로드를 바꾼 토큰
+	parts := strings.Split(token, ".")
+	forged := signWith(t, jwt.SigningMethodHS256, "", []byte("x"))
+	parts[1] = strings.Split(forged, ".")[1]
+	if _, err := ValidateJWT(context.Background(), strings.Join(parts, "."), keys); err == nil {
+		t.Error("token with a replaced payload was accepted")
+	}
+
+	validator := &rejectAll{}
+	if _, err := ValidateJWT(context.Background(), token, keys, validator); err == nil || !validator.called {
+		t.Errorf("validator result = %v, called = %v", err, validator.called)
+	}
+}
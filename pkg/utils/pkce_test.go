@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+// RFC 7636 부록 B의 예시 값
+const (
+	rfcCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestPKCEChallengeS256(t *testing.T) {
+	if got := PKCEChallengeS256(rfcCodeVerifier); got != rfcCodeChallenge {
+		t.Errorf("PKCEChallengeS256 = %q, want %q", got, rfcCodeChallenge)
+	}
+}
+
+func TestVerifyPKCE(t *testing.T) {
+	tests := []struct {
+		name      string
+		verifier  string
+		challenge string
+		want      bool
+	}{
+		{"rfc example", rfcCodeVerifier, rfcCodeChallenge, true},
+		{"wrong verifier", strings.Repeat("a", 43), rfcCodeChallenge, false},
+		{"plain challenge", rfcCodeVerifier, rfcCodeVerifier, false},
+		{"empty verifier", "", PKCEChallengeS256(""), false},
+		{"too short", strings.Repeat("a", 42), PKCEChallengeS256(strings.Repeat("a", 42)), false},
+		{"too long", strings.Repeat("a", 129), PKCEChallengeS256(strings.Repeat("a", 129)), false},
+		{"longest", strings.Repeat("a", 128), PKCEChallengeS256(strings.Repeat("a", 128)), true},
+		{"reserved character", strings.Repeat("a", 42) + "+", PKCEChallengeS256(strings.Repeat("a", 42) + "+"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPKCE(tt.verifier, tt.challenge); got != tt.want {
+				t.Errorf("VerifyPKCE = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 지원하는 서명 알고리즘
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+	AlgEdDSA = "EdDSA"
+)
+
+// SupportedAlgorithms 토큰 검증 시 허용하는 알고리즘 목록
+var SupportedAlgorithms = []string{AlgHS256, AlgRS256, AlgES256, AlgEdDSA}
+
+// SigningKey JWT 서명/검증 키
+// Key는 알고리즘에 따라 []byte(HS256), *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey 중 하나
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	Key       interface{}
+}
+
+// Method golang-jwt 서명 방식
+func (k *SigningKey) Method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// IsSymmetric 대칭키(HMAC) 여부
+func (k *SigningKey) IsSymmetric() bool {
+	return k.Algorithm == AlgHS256
+}
+
+// PublicKey 비대칭키의 공개키 (대칭키면 nil)
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	if signer, ok := k.Key.(crypto.Signer); ok {
+		return signer.Public()
+	}
+	return nil
+}
+
+// VerifyKey 서명 검증에 사용할 키
+func (k *SigningKey) VerifyKey() interface{} {
+	if k.IsSymmetric() {
+		return k.Key
+	}
+	return k.PublicKey()
+}
+
+// GenerateSigningKey 알고리즘에 맞는 새 키 생성 (kid는 JWK thumbprint)
+func GenerateSigningKey(alg string) (*SigningKey, error) {
+	var key interface{}
+	var err error
+
+	switch alg {
+	case AlgHS256:
+		secret := make([]byte, 32)
+		_, err = rand.Read(secret)
+		key = secret
+	case AlgRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newSigningKey(alg, key)
+}
+
+// ParseSigningKeyPEM PKCS#8 / PKCS#1 / SEC1 PEM 개인키를 SigningKey로 변환
+func ParseSigningKeyPEM(alg string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found in private key")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// ed25519는 포인터가 아닌 값으로 반환된다
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		key = *k
+	}
+
+	return newSigningKey(alg, key)
+}
+
+// MarshalSigningKeyPEM 비대칭 개인키를 PKCS#8 PEM으로 직렬화
+func MarshalSigningKeyPEM(key *SigningKey) ([]byte, error) {
+	if key.IsSymmetric() {
+		return nil, errors.New("symmetric keys cannot be encoded as PEM")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key.Key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// newSigningKey 알고리즘과 키 타입이 맞는지 확인하고 kid 부여
+func newSigningKey(alg string, key interface{}) (*SigningKey, error) {
+	switch k := key.(type) {
+	case []byte:
+		if alg != AlgHS256 {
+			return nil, fmt.Errorf("%s requires an asymmetric key", alg)
+		}
+	case *rsa.PrivateKey:
+		if alg != AlgRS256 {
+			return nil, fmt.Errorf("RSA key cannot be used with %s", alg)
+		}
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("RSA key must be at least 2048 bits")
+		}
+	case *ecdsa.PrivateKey:
+		if alg != AlgES256 {
+			return nil, fmt.Errorf("EC key cannot be used with %s", alg)
+		}
+		if k.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 requires a P-256 key")
+		}
+	case ed25519.PrivateKey:
+		if alg != AlgEdDSA {
+			return nil, fmt.Errorf("Ed25519 key cannot be used with %s", alg)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+
+	signingKey := &SigningKey{Algorithm: alg, Key: key}
+	if jwk, ok := signingKey.JWK(); ok {
+		signingKey.ID = jwk.Thumbprint()
+	}
+	return signingKey, nil
+}
+
+// NewHMACSigningKey 공유 비밀값 기반 HS256 키
+func NewHMACSigningKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{ID: id, Algorithm: AlgHS256, Key: secret}
+}
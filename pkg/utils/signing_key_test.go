@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+)
+
+// asymmetricAlgorithms PEM으로 저장할 수 있는 알고리즘
+var asymmetricAlgorithms = []string{AlgRS256, AlgES256, AlgEdDSA}
+
+// generateKey 테스트용 서명 키 생성
+func generateKey(t *testing.T, alg string) *SigningKey {
+	t.Helper()
+
+	key, err := GenerateSigningKey(alg)
+	if err != nil {
+		t.Fatalf("GenerateSigningKey(%s): %v", alg, err)
+	}
+	return key
+}
+
+// publicKeyEqual 서명 키의 공개키가 pub과 같은지 비교
+func publicKeyEqual(key *SigningKey, pub crypto.PublicKey) bool {
+	own, ok := key.PublicKey().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && own.Equal(pub)
+}
+
+func TestSigningKeyPEMRoundTrip(t *testing.T) {
+	for _, alg := range asymmetricAlgorithms {
+		t.Run(alg, func(t *testing.T) {
+			key := generateKey(t, alg)
+			if key.ID == "" {
+				t.Fatal("generated key has no kid")
+			}
+
+			data, err := MarshalSigningKeyPEM(key)
+			if err != nil {
+				t.Fatalf("MarshalSigningKeyPEM: %v", err)
+			}
+			parsed, err := ParseSigningKeyPEM(alg, data)
+			if err != nil {
+				t.Fatalf("ParseSigningKeyPEM: %v", err)
+			}
+
+			if parsed.ID != key.ID || parsed.Algorithm != alg || !publicKeyEqual(parsed, key.PublicKey()) {
+				t.Errorf("parsed key = %s %s, want %s %s with the same public key", parsed.Algorithm, parsed.ID, alg, key.ID)
+			}
+
+			// 다시 읽은 키로 서명한 토큰을 원래 키로 검증
+			token, err := GenerateJWT(&JWTClaim{UserID: "user-1"}, parsed, time.Minute)
+			if err != nil {
+				t.Fatalf("GenerateJWT: %v", err)
+			}
+			if _, err := ValidateJWT(context.Background(), token, NewKeySet(key)); err != nil {
+				t.Errorf("token signed with the parsed key: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseSigningKeyPEMLegacyFormats(t *testing.T) {
+	rsaKey := generateKey(t, AlgRS256)
+	ecKey := generateKey(t, AlgES256)
+	sec1, err := x509.MarshalECPrivateKey(ecKey.Key.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatalf("marshal EC key: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		alg   string
+		block *pem.Block
+		want  *SigningKey
+	}{
+		{"PKCS#1", AlgRS256, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey.Key.(*rsa.PrivateKey))}, rsaKey},
+		{"SEC1", AlgES256, &pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}, ecKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseSigningKeyPEM(tt.alg, pem.EncodeToMemory(tt.block))
+			if err != nil {
+				t.Fatalf("ParseSigningKeyPEM: %v", err)
+			}
+			if parsed.ID != tt.want.ID || !publicKeyEqual(parsed, tt.want.PublicKey()) {
+				t.Errorf("parsed key %s, want %s", parsed.ID, tt.want.ID)
+			}
+		})
+	}
+}
+
+func TestParseSigningKeyPEMRejects(t *testing.T) {
+	rsaPEM, err := MarshalSigningKeyPEM(generateKey(t, AlgRS256))
+	if err != nil {
+		t.Fatalf("MarshalSigningKeyPEM: %v", err)
+	}
+	edPEM, err := MarshalSigningKeyPEM(generateKey(t, AlgEdDSA))
+	if err != nil {
+		t.Fatalf("MarshalSigningKeyPEM: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		alg  string
+		data []byte
+	}{
+		{"RSA key as ES256", AlgES256, rsaPEM},
+		{"RSA key as HS256", AlgHS256, rsaPEM},
+		{"Ed25519 key as RS256", AlgRS256, edPEM},
+		{"not PEM", AlgRS256, []byte("-----BEGIN NOTHING")},
+		{"public key", AlgRS256, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte{0}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if key, err := ParseSigningKeyPEM(tt.alg, tt.data); err == nil {
+				t.Errorf("ParseSigningKeyPEM = %s %s, want error", key.Algorithm, key.ID)
+			}
+		})
+	}
+
+	if _, err := MarshalSigningKeyPEM(NewHMACSigningKey("", []byte("secret"))); err == nil {
+		t.Error("MarshalSigningKeyPEM encoded a symmetric key")
+	}
+	if _, err := GenerateSigningKey("PS256"); err == nil {
+		t.Error("GenerateSigningKey accepted an unsupported algorithm")
+	}
+}
@@ -2,8 +2,11 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
+	"math/big"
 )
 
 // GenerateRandomToken 지정된 바이트 수의 랜덤 토큰 생성
@@ -25,3 +28,23 @@ func GenerateBase64Token(length int) string {
 	}
 	return base64.URLEncoding.EncodeToString(b)
 }
+
+// GenerateNumericCode 지정된 자릿수의 숫자 코드 생성 (이메일 OTP용)
+func GenerateNumericCode(digits int) string {
+	max := big.NewInt(1)
+	for i := 0; i < digits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%0*d", digits, n)
+}
+
+// HashToken 저장용 토큰 해시 (SHA-256, hex)
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}